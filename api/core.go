@@ -3,4 +3,6 @@ package api
 const (
 	GroupName      = "node.eks.aws"
 	KindNodeConfig = "NodeConfig"
+
+	KindNodeConfigList = "NodeConfigList"
 )
@@ -79,6 +79,115 @@ type ContainerdOptions struct {
 	// that will be [imported](https://github.com/containerd/containerd/blob/32169d591dbc6133ef7411329b29d0c0433f8c4d/docs/man/containerd-config.toml.5.md?plain=1#L146-L154)
 	// by the default configuration file.
 	Config string `json:"config,omitempty"`
+
+	// Registries configure how `containerd` pulls images from each registry, like mirrors
+	// and TLS settings. nodeadm writes a `hosts.toml` file for each of them under
+	// `/etc/containerd/certs.d`.
+	// +optional
+	Registries []ContainerdRegistry `json:"registries,omitempty"`
+
+	// Runtimes are additional `containerd` runtime handlers, like gVisor, Kata Containers
+	// or the NVIDIA container runtime, that `RuntimeClass` objects can select.
+	// +optional
+	Runtimes []ContainerdRuntime `json:"runtimes,omitempty"`
+}
+
+// ContainerdRegistry configures how `containerd` pulls images from a registry.
+type ContainerdRegistry struct {
+	// Name is the registry host the configuration applies to, like `docker.io` or
+	// `public.ecr.aws`, or `_default` for registries without their own configuration.
+	Name string `json:"name"`
+
+	// Server is the URL of the upstream registry. Defaults to the registry host.
+	// +optional
+	Server string `json:"server,omitempty"`
+
+	// TLS configures the connection to the upstream registry.
+	// +optional
+	TLS *RegistryTLS `json:"tls,omitempty"`
+
+	// Mirrors are tried in order before the upstream registry.
+	// +optional
+	Mirrors []RegistryMirror `json:"mirrors,omitempty"`
+}
+
+// ContainerdRuntime is an additional `containerd` runtime handler.
+type ContainerdRuntime struct {
+	// Name is the name of the runtime handler, used as the `handler` of the `RuntimeClass`.
+	Name string `json:"name"`
+
+	// RuntimeType is the `containerd` shim of the runtime, like `io.containerd.runsc.v1`
+	// for gVisor or `io.containerd.runc.v2` for the NVIDIA container runtime.
+	RuntimeType string `json:"runtimeType"`
+
+	// BinaryPath is the path of the shim binary. Defaults to the binary `containerd`
+	// derives from RuntimeType, like `containerd-shim-runsc-v1`, looked up in `PATH`.
+	// +optional
+	BinaryPath string `json:"binaryPath,omitempty"`
+
+	// Options are passed to the shim, like `BinaryName` for the NVIDIA container runtime.
+	// `true` and `false` are written as booleans, whole numbers as integers and any other value as a string.
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+
+	// PrivilegedWithoutHostDevices keeps host devices out of privileged containers
+	// that use the runtime.
+	// +optional
+	PrivilegedWithoutHostDevices bool `json:"privilegedWithoutHostDevices,omitempty"`
+
+	// NodeLabel labels the node with `runtime.hybrid.eks.amazonaws.com/<name>=true`, so
+	// the `scheduling` of a `RuntimeClass` can select the nodes with the runtime.
+	// Only applies to hybrid nodes.
+	// +optional
+	NodeLabel bool `json:"nodeLabel,omitempty"`
+}
+
+// RegistryMirror is a registry host `containerd` pulls images from before the upstream registry.
+type RegistryMirror struct {
+	// URL is the URL of the mirror, like `https://mirror.example.com:5000`.
+	URL string `json:"url"`
+
+	// Capabilities are the operations the mirror is used for. Defaults to `pull` and `resolve`.
+	// +optional
+	Capabilities []RegistryCapability `json:"capabilities,omitempty"`
+
+	// OverridePath uses the path of the URL as the registry API root instead of `/v2`.
+	// +optional
+	OverridePath bool `json:"overridePath,omitempty"`
+
+	// TLS configures the connection to the mirror.
+	// +optional
+	TLS *RegistryTLS `json:"tls,omitempty"`
+}
+
+// RegistryCapability is an operation `containerd` can perform against a registry host.
+// +kubebuilder:validation:Enum={pull, resolve, push}
+type RegistryCapability string
+
+const (
+	RegistryCapabilityPull    RegistryCapability = "pull"
+	RegistryCapabilityResolve RegistryCapability = "resolve"
+	RegistryCapabilityPush    RegistryCapability = "push"
+)
+
+// RegistryTLS configures the TLS connection to a registry host.
+type RegistryTLS struct {
+	// CACert is a PEM encoded CA bundle used to verify the certificate of the registry host.
+	// +optional
+	CACert string `json:"caCert,omitempty"`
+
+	// ClientCert is a PEM encoded client certificate to authenticate with the registry host.
+	// It requires ClientKey.
+	// +optional
+	ClientCert string `json:"clientCert,omitempty"`
+
+	// ClientKey is the PEM encoded private key of ClientCert.
+	// +optional
+	ClientKey string `json:"clientKey,omitempty"`
+
+	// SkipVerify disables the verification of the certificate of the registry host.
+	// +optional
+	SkipVerify bool `json:"skipVerify,omitempty"`
 }
 
 // InstanceOptions determines how the node's operating system and devices are configured.
@@ -118,8 +227,196 @@ type HybridOptions struct {
 	// SSM includes Systems Manager specific configuration and is mutually exclusive with
 	// IAMRolesAnywhere.
 	SSM *SSM `json:"ssm,omitempty"`
+
+	// Labels are added to the node when it registers with the cluster, together with the
+	// labels nodeadm sets. Labels in the `kubernetes.io` and `k8s.io` namespaces are reserved,
+	// except for the `kubelet.kubernetes.io` and `node.kubernetes.io` namespaces.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Taints are added to the node when it registers with the cluster.
+	// +optional
+	Taints []Taint `json:"taints,omitempty"`
+
+	// ReservedResources configures the compute resources reserved for system and Kubernetes
+	// daemons and the kubelet hard eviction thresholds.
+	// +optional
+	ReservedResources *ReservedResources `json:"reservedResources,omitempty"`
+
+	// CPUManager is the kubelet CPU manager policy. With `static`, nodeadm reserves whole
+	// physical cores on NUMA node 0 for system and Kubernetes daemons, sized from the
+	// reserved cpu, and gives guaranteed pods exclusive cpus. Defaults to `none`.
+	// +optional
+	CPUManager CPUManagerPolicy `json:"cpuManager,omitempty"`
+
+	// Hugepages are pre-allocated on the node for pods that request them. 1Gi pages that
+	// can't be allocated at runtime are allocated at boot through the kernel command line,
+	// which requires a reboot.
+	// +optional
+	Hugepages []Hugepages `json:"hugepages,omitempty"`
+
+	// Swap is how nodeadm handles swap on the node. With `disable`, nodeadm turns off file
+	// swap and removes swap from /etc/fstab. With `limited`, swap is kept and kubelet lets
+	// burstable pods use it, which requires cgroup v2. Defaults to `disable`.
+	// +optional
+	Swap SwapMode `json:"swap,omitempty"`
+
+	// Artifacts configures where nodeadm downloads the release manifest and artifacts from,
+	// like an internal mirror. The `--manifest-url` and `--artifact-url-rewrite` flags and the
+	// `NODEADM_MANIFEST_URL` and `NODEADM_ARTIFACT_URL_REWRITES` environment variables take
+	// precedence over it.
+	// +optional
+	Artifacts *ArtifactsOptions `json:"artifacts,omitempty"`
+}
+
+// ArtifactsOptions configures where nodeadm downloads the release manifest and artifacts from.
+type ArtifactsOptions struct {
+	// ManifestURL is the URL of the release manifest, replacing the one nodeadm was built with.
+	// +optional
+	ManifestURL string `json:"manifestUrl,omitempty"`
+
+	// URLRewrites replace the prefix of the manifest and artifact URLs, to download them from
+	// a mirror. Checksums are downloaded from the rewritten URLs and still verified. The first
+	// rewrite with a matching prefix applies.
+	// +optional
+	URLRewrites []URLRewrite `json:"urlRewrites,omitempty"`
+
+	// TrustedKeys are armored PGP public keys trusted to sign the release manifest and the
+	// artifact checksums, instead of the keys nodeadm was built with, like the keys of a private
+	// mirror. The `--trusted-keys` flag and `NODEADM_TRUSTED_KEYS` environment variable take
+	// precedence over it.
+	// +optional
+	TrustedKeys string `json:"trustedKeys,omitempty"`
+
+	// SkipSignatureVerification skips verifying the signatures of the release manifest and
+	// artifact checksums, for mirrors serving manifests that aren't signed. The checksums are
+	// still verified.
+	// +optional
+	SkipSignatureVerification bool `json:"skipSignatureVerification,omitempty"`
+
+	// MaxDownloadRate caps the bytes per second of all the downloads together, as a quantity
+	// like `10Mi`, to leave bandwidth for other traffic on constrained links. The
+	// `--max-download-rate` flag takes precedence over it.
+	// +optional
+	MaxDownloadRate string `json:"maxDownloadRate,omitempty"`
+}
+
+// URLRewrite replaces the prefix of a URL.
+type URLRewrite struct {
+	// Prefix is the start of the URLs to rewrite, like `https://hybrid-assets.eks.amazonaws.com/`.
+	// +kubebuilder:validation:Required
+	Prefix string `json:"prefix"`
+
+	// Replacement replaces the prefix, like `https://artifactory.example.com/eks-hybrid/`.
+	// +kubebuilder:validation:Required
+	Replacement string `json:"replacement"`
+}
+
+// SwapMode is how nodeadm handles swap on the node.
+// +kubebuilder:validation:Enum={disable, limited}
+type SwapMode string
+
+const (
+	SwapModeDisable SwapMode = "disable"
+	SwapModeLimited SwapMode = "limited"
+)
+
+// Hugepages is a number of pre-allocated hugepages of a size.
+type Hugepages struct {
+	// Size is the size of the pages.
+	Size HugepageSize `json:"size"`
+
+	// Count is the number of pages to allocate.
+	// +kubebuilder:validation:Minimum=0
+	Count int32 `json:"count"`
+}
+
+// HugepageSize is the size of a hugepage.
+// +kubebuilder:validation:Enum={2Mi, 1Gi}
+type HugepageSize string
+
+const (
+	HugepageSize2Mi HugepageSize = "2Mi"
+	HugepageSize1Gi HugepageSize = "1Gi"
+)
+
+// CPUManagerPolicy is a kubelet CPU manager policy.
+// +kubebuilder:validation:Enum={none, static}
+type CPUManagerPolicy string
+
+const (
+	CPUManagerPolicyNone   CPUManagerPolicy = "none"
+	CPUManagerPolicyStatic CPUManagerPolicy = "static"
+)
+
+// ReservedResources configures the compute resources kubelet keeps out of the node allocatable.
+// Values are quantities like `500Mi`, for `cpu` and `memory` a percentage of the node
+// capacity like `5%`, or formulas like `255Mi + 11Mi * maxPods`, `min(10%, 2Gi)` or
+// `tiered(25%:4Gi, 20%:4Gi, 10%:8Gi, 2%)`. Formulas combine quantities, numbers,
+// percentages and `maxPods` with `+`, `-`, `*`, `min` and `max`. `tiered` reserves a
+// percentage of each tier of the capacity, the last one without a size covering the
+// rest. Resources set here override the ones from the profile.
+type ReservedResources struct {
+	// Profile is the named set of kube reserved resources to start from.
+	// Defaults to `hybrid-default`.
+	// +optional
+	Profile ReservedResourcesProfile `json:"profile,omitempty"`
+
+	// KubeReserved is the resources reserved for Kubernetes daemons, by resource name
+	// (`cpu`, `memory`, `ephemeral-storage` or `pid`).
+	// +optional
+	KubeReserved map[string]string `json:"kubeReserved,omitempty"`
+
+	// SystemReserved is the resources reserved for system daemons, by resource name
+	// (`cpu`, `memory`, `ephemeral-storage` or `pid`).
+	// +optional
+	SystemReserved map[string]string `json:"systemReserved,omitempty"`
+
+	// EvictionHard overrides the kubelet hard eviction thresholds, by signal name like
+	// `memory.available`. Values are quantities or percentages.
+	// +optional
+	EvictionHard map[string]string `json:"evictionHard,omitempty"`
+}
+
+// ReservedResourcesProfile is a named set of kube reserved resources.
+// +kubebuilder:validation:Enum={eks-default, hybrid-default, minimal}
+type ReservedResourcesProfile string
+
+const (
+	// ReservedResourcesProfileEKSDefault reserves memory based on the max pods of the node,
+	// like EKS optimized AMIs.
+	ReservedResourcesProfileEKSDefault ReservedResourcesProfile = "eks-default"
+
+	// ReservedResourcesProfileHybridDefault reserves a tiered share of the node memory.
+	ReservedResourcesProfileHybridDefault ReservedResourcesProfile = "hybrid-default"
+
+	// ReservedResourcesProfileMinimal reserves a small fixed amount of cpu and memory.
+	ReservedResourcesProfileMinimal ReservedResourcesProfile = "minimal"
+)
+
+// Taint is a taint the node registers with.
+type Taint struct {
+	// Key is the taint key.
+	Key string `json:"key"`
+
+	// Value is the taint value.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Effect is the effect of the taint on pods that don't tolerate it.
+	Effect TaintEffect `json:"effect"`
 }
 
+// TaintEffect is the effect of a taint.
+// +kubebuilder:validation:Enum={NoSchedule, PreferNoSchedule, NoExecute}
+type TaintEffect string
+
+const (
+	TaintEffectNoSchedule       TaintEffect = "NoSchedule"
+	TaintEffectPreferNoSchedule TaintEffect = "PreferNoSchedule"
+	TaintEffectNoExecute        TaintEffect = "NoExecute"
+)
+
 // IsHybridNode returns true when the nc.Hybrid configuration is non-nil.
 func (nc NodeConfig) IsHybridNode() bool {
 	return nc.Spec.Hybrid != nil
@@ -137,12 +434,15 @@ type IAMRolesAnywhere struct {
 	NodeName string `json:"nodeName,omitempty"`
 
 	// TrustAnchorARN is the ARN of the trust anchor.
+	// +kubebuilder:validation:Pattern=`^arn:aws[a-z-]*:[a-z0-9-]+:[a-z0-9-]*:[0-9]{12}:.+$`
 	TrustAnchorARN string `json:"trustAnchorArn,omitempty"`
 
 	// ProfileARN is the ARN of the profile linked with the Hybrid IAM Role.
+	// +kubebuilder:validation:Pattern=`^arn:aws[a-z-]*:[a-z0-9-]+:[a-z0-9-]*:[0-9]{12}:.+$`
 	ProfileARN string `json:"profileArn,omitempty"`
 
 	// RoleARN is the role to IAM roles anywhere gets authorized as to get temporary credentials.
+	// +kubebuilder:validation:Pattern=`^arn:aws[a-z-]*:[a-z0-9-]+:[a-z0-9-]*:[0-9]{12}:.+$`
 	RoleARN string `json:"roleArn,omitempty"`
 
 	// AwsConfigPath is the path where the Aws config is stored for hybrid nodes.
@@ -164,8 +464,34 @@ type IAMRolesAnywhere struct {
 // During activation an IAM role is chosen for the SSM agent to assume. This is not overridable from the agent.
 type SSM struct {
 	// ActivationCode is the token generated when creating an SSM activation.
+	// +kubebuilder:validation:Pattern=`^.{20,250}$`
 	ActivationCode string `json:"activationCode,omitempty"`
 
+	// ActivationCodeFrom reads the activation code from a file, an environment variable or
+	// a systemd credential instead of storing it in the config. It is mutually exclusive
+	// with ActivationCode.
+	// +optional
+	ActivationCodeFrom *SecretSource `json:"activationCodeFrom,omitempty"`
+
 	// ActivationToken is the ID generated when creating an SSM activation.
+	// +kubebuilder:validation:Pattern=`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`
 	ActivationID string `json:"activationId,omitempty"`
 }
+
+// SecretSource points to where a sensitive value is read from, so it doesn't need to be
+// stored in the config. Only one of its fields can be set. Values read from a secret source
+// are redacted from nodeadm logs and errors.
+type SecretSource struct {
+	// File is the path of a file holding the value. Trailing newlines are ignored.
+	// +optional
+	File string `json:"file,omitempty"`
+
+	// Env is the name of an environment variable holding the value.
+	// +optional
+	Env string `json:"env,omitempty"`
+
+	// Credential is the name of a [systemd credential](https://systemd.io/CREDENTIALS/)
+	// holding the value, read from `$CREDENTIALS_DIRECTORY`.
+	// +optional
+	Credential string `json:"credential,omitempty"`
+}
@@ -0,0 +1,141 @@
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/bundle"
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/containerd"
+	"github.com/aws/eks-hybrid/internal/creds"
+	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/packagemanager"
+	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+const createHelpText = `Examples:
+  # Create a bundle for Kubernetes version 1.31 with AWS IAM Roles Anywhere as the credential provider
+  nodeadm bundle create 1.31 --arch amd64 --credential-provider iam-ra
+
+  # Include the containerd and iptables packages, downloaded for the OS release of this host
+  nodeadm bundle create 1.31 --credential-provider iam-ra --include-packages --output /tmp/bundle.tgz`
+
+func NewCreateCommand() cli.Command {
+	cmd := createCmd{
+		arch:    runtime.GOARCH,
+		output:  "nodeadm-bundle.tgz",
+		timeout: 20 * time.Minute,
+	}
+	fc := flaggy.NewSubcommand("create")
+	fc.Description = "Create a bundle with the artifacts to install without network access"
+	fc.AdditionalHelpAppend = createHelpText
+	fc.AddPositionalValue(&cmd.kubernetesVersion, "KUBERNETES_VERSION", 1, true, "The major[.minor[.patch]] version of Kubernetes to bundle.")
+	fc.String(&cmd.arch, "a", "arch", "Architecture of the nodes the bundle installs on. Allowed values: [amd64, arm64].")
+	fc.String(&cmd.credentialProvider, "p", "credential-provider", "Credential process to bundle. Allowed values: [iam-ra].")
+	fc.Bool(&cmd.includePackages, "", "include-packages", "Include the containerd and iptables packages from the distro of this host.")
+	fc.String(&cmd.output, "o", "output", "Path of the bundle to create.")
+	fc.String(&cmd.manifestURL, "", "manifest-url", "URL of the release manifest to download the artifacts from, like an internal mirror.")
+	fc.StringSlice(&cmd.artifactURLRewrites, "", "artifact-url-rewrite", "Replace the prefix of the manifest and artifact URLs. Format: prefix=replacement.")
+	fc.String(&cmd.trustedKeys, "", "trusted-keys", "File with the armored PGP public keys that sign the manifest and artifact checksums, like the keys of a private mirror.")
+	fc.Bool(&cmd.skipSignatureVerification, "", "skip-signature-verification", "Don't verify the signatures of the release manifest and artifacts, for manifests that aren't signed. The checksums are still verified.")
+	fc.String(&cmd.maxDownloadRate, "", "max-download-rate", "Maximum bytes per second of all the downloads together, like 10Mi. Unlimited by default.")
+	fc.Duration(&cmd.timeout, "t", "timeout", "Maximum bundle create duration. Input follows duration format. Example: 1h23s")
+	cmd.flaggy = fc
+	return &cmd
+}
+
+type createCmd struct {
+	flaggy                    *flaggy.Subcommand
+	kubernetesVersion         string
+	arch                      string
+	credentialProvider        string
+	includePackages           bool
+	output                    string
+	timeout                   time.Duration
+	manifestURL               string
+	artifactURLRewrites       []string
+	trustedKeys               string
+	skipSignatureVerification bool
+	maxDownloadRate           string
+}
+
+func (c *createCmd) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+func (c *createCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := context.Background()
+	ctx = logger.NewContext(ctx, log)
+
+	if c.credentialProvider == "" {
+		flaggy.ShowHelpAndExit("--credential-provider is a required flag. Allowed values are iam-ra")
+	}
+	credentialProvider, err := creds.GetCredentialProvider(c.credentialProvider)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	createOpts := bundle.CreateOptions{
+		Arch:               c.arch,
+		CredentialProvider: credentialProvider,
+		ContainerdVersion:  containerd.ContainerdVersion,
+		Logger:             log,
+	}
+	if c.includePackages {
+		// the package managers need root to download packages
+		root, err := cli.IsRunningAsRoot()
+		if err != nil {
+			return err
+		}
+		if !root {
+			return cli.ErrMustRunAsRoot
+		}
+		if c.arch != runtime.GOARCH {
+			return fmt.Errorf("--include-packages downloads packages for this host, which uses arch %s, not %s", runtime.GOARCH, c.arch)
+		}
+		packageManager, err := packagemanager.New(tracker.ContainerdSourceDistro, log)
+		if err != nil {
+			return err
+		}
+		createOpts.Packages = packageManager
+	}
+
+	mirrorOpts, err := aws.MirrorOptions(c.manifestURL, c.artifactURLRewrites, c.trustedKeys, nil)
+	if err != nil {
+		return err
+	}
+	if c.skipSignatureVerification {
+		mirrorOpts = append(mirrorOpts, aws.WithoutSignatureVerification())
+	}
+
+	createOpts.Verifier, err = aws.SignatureVerifier(mirrorOpts...)
+	if err != nil {
+		return err
+	}
+	maxDownloadRate, err := aws.MaxDownloadRate(c.maxDownloadRate, nil)
+	if err != nil {
+		return err
+	}
+	ctx = util.WithDownloadOptions(ctx, util.DownloadOptions{MaxRate: maxDownloadRate})
+
+	log.Info("Validating Kubernetes version", zap.Reflect("kubernetes version", c.kubernetesVersion))
+	createOpts.Manifest, err = aws.GetBundleManifest(ctx, c.kubernetesVersion, c.arch, credentialProvider == creds.IamRolesAnywhereCredentialProvider, mirrorOpts...)
+	if err != nil {
+		return err
+	}
+	if err := bundle.Create(ctx, c.output, createOpts); err != nil {
+		return err
+	}
+	log.Info("Bundle created", zap.String("output", c.output))
+	return nil
+}
@@ -0,0 +1,19 @@
+package bundle
+
+import (
+	"github.com/aws/eks-hybrid/internal/cli"
+)
+
+const bundleHelpText = `Examples:
+  # Create a bundle to install Kubernetes version 1.31 with AWS IAM Roles Anywhere on amd64 nodes
+  nodeadm bundle create 1.31 --arch amd64 --credential-provider iam-ra
+
+  # Install from the bundle on a node without internet access
+  nodeadm install 1.31 --credential-provider iam-ra --bundle ./nodeadm-bundle.tgz`
+
+func NewBundleCommand() cli.Command {
+	container := cli.NewCommandContainer("bundle", "Manage offline install bundles")
+	container.Flaggy().AdditionalHelpAppend = bundleHelpText
+	container.AddCommand(NewCreateCommand())
+	return container.AsCommand()
+}
@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/aws/eks-hybrid/internal/cache"
+	"github.com/aws/eks-hybrid/internal/cli"
+)
+
+func NewListCommand() cli.Command {
+	cmd := listCmd{
+		dir: cache.DefaultDir,
+	}
+	fc := flaggy.NewSubcommand("list")
+	fc.Description = "List the cached artifacts, the most recently used first"
+	fc.String(&cmd.dir, "d", "cache-dir", "Directory of the artifact cache.")
+	cmd.flaggy = fc
+	return &cmd
+}
+
+type listCmd struct {
+	flaggy *flaggy.Subcommand
+	dir    string
+}
+
+func (c *listCmd) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+func (c *listCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	entries, err := cache.New(c.dir, cache.DefaultMaxSize).List()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SHA256\tNAME\tSIZE\tLAST USED")
+	for _, entry := range entries {
+		size := resource.NewQuantity(entry.Size, resource.BinarySI)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", entry.Checksum, entry.Name, size, entry.LastUsed.Format(time.RFC3339))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/aws/eks-hybrid/internal/cache"
+	"github.com/aws/eks-hybrid/internal/cli"
+)
+
+func NewPruneCommand() cli.Command {
+	cmd := pruneCmd{
+		dir:     cache.DefaultDir,
+		maxSize: resource.NewQuantity(cache.DefaultMaxSize, resource.BinarySI).String(),
+	}
+	fc := flaggy.NewSubcommand("prune")
+	fc.Description = "Remove cached artifacts, the least recently used first"
+	fc.String(&cmd.dir, "d", "cache-dir", "Directory of the artifact cache.")
+	fc.String(&cmd.maxSize, "", "max-size", "Remove the least recently used artifacts until the cache is under this size. Example: 512Mi")
+	fc.Duration(&cmd.unusedFor, "", "unused-for", "Remove the artifacts not used for longer than this duration. Example: 720h")
+	cmd.flaggy = fc
+	return &cmd
+}
+
+type pruneCmd struct {
+	flaggy    *flaggy.Subcommand
+	dir       string
+	maxSize   string
+	unusedFor time.Duration
+}
+
+func (c *pruneCmd) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+func (c *pruneCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	maxSize, err := resource.ParseQuantity(c.maxSize)
+	if err != nil {
+		return fmt.Errorf("invalid --max-size %s: %w", c.maxSize, err)
+	}
+	removed, err := cache.New(c.dir, maxSize.Value()).Prune(maxSize.Value(), c.unusedFor)
+	for _, entry := range removed {
+		log.Info("Removed cached artifact", zap.String("name", entry.Name), zap.String("sha256", entry.Checksum))
+	}
+	return err
+}
@@ -0,0 +1,21 @@
+package cache
+
+import (
+	"github.com/aws/eks-hybrid/internal/cli"
+)
+
+const cacheHelpText = `Examples:
+  # List the artifacts cached by install and upgrade
+  nodeadm cache list
+
+  # Remove the artifacts not used in the last 30 days
+  nodeadm cache prune --unused-for 720h`
+
+func NewCacheCommand() cli.Command {
+	container := cli.NewCommandContainer("cache", "Manage the local cache of downloaded artifacts")
+	container.Flaggy().AdditionalHelpAppend = cacheHelpText
+	container.AddCommand(NewListCommand())
+	container.AddCommand(NewPruneCommand())
+	container.AddCommand(NewVerifyCommand())
+	return container.AsCommand()
+}
@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/cache"
+	"github.com/aws/eks-hybrid/internal/cli"
+)
+
+func NewVerifyCommand() cli.Command {
+	cmd := verifyCmd{
+		dir: cache.DefaultDir,
+	}
+	fc := flaggy.NewSubcommand("verify")
+	fc.Description = "Check the checksum of every cached artifact, removing the corrupted ones"
+	fc.String(&cmd.dir, "d", "cache-dir", "Directory of the artifact cache.")
+	cmd.flaggy = fc
+	return &cmd
+}
+
+type verifyCmd struct {
+	flaggy *flaggy.Subcommand
+	dir    string
+}
+
+func (c *verifyCmd) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+func (c *verifyCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	corrupted, err := cache.New(c.dir, cache.DefaultMaxSize).Verify()
+	for _, entry := range corrupted {
+		log.Warn("Removed corrupted cached artifact", zap.String("name", entry.Name), zap.String("sha256", entry.Checksum))
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("Verified cached artifacts", zap.Int("removed", len(corrupted)))
+	return nil
+}
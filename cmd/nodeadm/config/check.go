@@ -1,24 +1,51 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/cli"
 	"github.com/aws/eks-hybrid/internal/configprovider"
+	"github.com/aws/eks-hybrid/internal/containerd"
+	internalerrors "github.com/aws/eks-hybrid/internal/errors"
 	"github.com/aws/eks-hybrid/internal/node"
+	"github.com/aws/eks-hybrid/internal/secret"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+const (
+	textOutput = "text"
+	jsonOutput = "json"
 )
 
+const checkHelpText = `Examples:
+  # Check configuration file
+  nodeadm config check --config-source file:///root/nodeConfig.yaml
+
+  # Report every problem as JSON, for CI pipelines
+  nodeadm config check --config-source file:///root/nodeConfig.yaml --output json`
+
 type fileCmd struct {
 	cmd          *flaggy.Subcommand
 	configSource string
+	output       string
 }
 
 func NewCheckCommand() cli.Command {
-	file := fileCmd{}
+	file := fileCmd{
+		output: textOutput,
+	}
 	file.cmd = flaggy.NewSubcommand("check")
 	file.cmd.Description = "Verify configuration"
-	file.cmd.String(&file.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds].")
+	file.cmd.AdditionalHelpAppend = checkHelpText
+	file.cmd.String(&file.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, http, https, s3, nocloud, guestinfo, ovf].")
+	file.cmd.String(&file.output, "o", "output", fmt.Sprintf("Format of the check result. One of: [%s, %s].", textOutput, jsonOutput))
 	return &file
 }
 
@@ -26,26 +53,158 @@ func (c *fileCmd) Flaggy() *flaggy.Subcommand {
 	return c.cmd
 }
 
+// checkResult is the result of checking a configuration, as printed with the json output.
+type checkResult struct {
+	Valid  bool         `json:"valid"`
+	Errors []checkError `json:"errors"`
+	// Warnings are problems that don't make the configuration invalid.
+	Warnings []checkError `json:"warnings"`
+	// ContainerdConfig is the containerd config with the user config in
+	// spec.containerd.config merged on top, if the user config is set.
+	ContainerdConfig string `json:"containerdConfig,omitempty"`
+}
+
+// checkError is a single configuration problem.
+type checkError struct {
+	// Field is the JSON path of the field with the problem, if known.
+	Field       string `json:"field,omitempty"`
+	Message     string `json:"message"`
+	Remediation string `json:"remediation,omitempty"`
+	// File, Line and Column point to where the field is set when the source is a file.
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
 func (c *fileCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	if c.output != textOutput && c.output != jsonOutput {
+		return fmt.Errorf("unsupported output %q, must be one of: [%s, %s]", c.output, textOutput, jsonOutput)
+	}
+	if c.output == jsonOutput {
+		// keep stdout for the result
+		log = zap.NewNop()
+	}
+
 	log.Info("Checking configuration", zap.String("source", c.configSource))
-	provider, err := configprovider.BuildConfigProvider(c.configSource)
+	result, err := c.check(log)
 	if err != nil {
 		return err
 	}
-	_, err = provider.Provide()
-	if err != nil {
-		return err
+
+	if c.output == jsonOutput {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(os.Stdout, string(data)); err != nil {
+			return err
+		}
+	} else {
+		for _, e := range result.Errors {
+			fields := []zap.Field{zap.String("field", e.Field)}
+			if e.File != "" {
+				fields = append(fields, zap.String("file", e.File), zap.Int("line", e.Line), zap.Int("column", e.Column))
+			}
+			if e.Remediation != "" {
+				fields = append(fields, zap.String("remediation", e.Remediation))
+			}
+			log.Error(e.Message, fields...)
+		}
+		for _, w := range result.Warnings {
+			log.Warn(w.Message, zap.String("field", w.Field))
+		}
+		if result.ContainerdConfig != "" {
+			log.Info("Effective containerd config")
+			if _, err := fmt.Fprint(os.Stdout, result.ContainerdConfig); err != nil {
+				return err
+			}
+		}
 	}
 
-	nodeProvider, err := node.NewNodeProvider(c.configSource, []string{}, log)
+	if !result.Valid {
+		return internalerrors.NewSilent(fmt.Errorf("configuration has %d errors", len(result.Errors)))
+	}
+	log.Info("Configuration is valid")
+	return nil
+}
+
+// check loads and validates the configuration, collecting every problem found.
+// Only errors that prevent checking the configuration at all are returned.
+func (c *fileCmd) check(log *zap.Logger) (checkResult, error) {
+	result := checkResult{Errors: []checkError{}, Warnings: []checkError{}}
+	provider, err := configprovider.BuildConfigProvider(c.configSource)
 	if err != nil {
-		return err
+		return result, err
+	}
+	nodeConfig, err := provider.Provide()
+	if err != nil {
+		result.Errors = append(result.Errors, checkError{Message: err.Error()})
+		return result, nil
+	}
+
+	locator, _ := provider.(configprovider.FieldLocator)
+	nodeProvider, err := node.NewNodeProviderFromConfig(nodeConfig, []string{}, log)
+	if err != nil && validation.Field(err) != "" {
+		// the config was read, but one of its fields, like a secret source, is invalid
+		result.Errors = append(result.Errors, newCheckError(err, locator))
+		return result, nil
+	} else if err != nil {
+		return result, err
 	}
 
 	if err := nodeProvider.ValidateConfig(); err != nil {
-		return err
+		for _, e := range validation.Unwrap(err) {
+			result.Errors = append(result.Errors, newCheckError(e, locator))
+		}
 	}
+	result.Valid = len(result.Errors) == 0
+	if result.Valid {
+		if err := checkContainerdConfig(&result, nodeProvider.GetNodeConfig(), log); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
 
-	log.Info("Configuration is valid")
+// checkContainerdConfig adds the warnings for the user containerd config and the
+// effective containerd config to result. The checks that depend on the containerd
+// version are skipped when containerd isn't installed. The sandbox image is resolved
+// during init, so it's left empty in the effective config.
+func checkContainerdConfig(result *checkResult, cfg *api.NodeConfig, log *zap.Logger) error {
+	if cfg.Spec.Containerd.Config == "" {
+		return nil
+	}
+	containerdVersion, err := containerd.GetContainerdVersion()
+	if err != nil {
+		log.Info("Skipping containerd version checks", zap.Error(err))
+		containerdVersion = ""
+	}
+	for _, warning := range containerd.UserConfigWarnings(cfg.Spec.Containerd.Config, containerdVersion) {
+		result.Warnings = append(result.Warnings, checkError{Field: "spec.containerd.config", Message: warning})
+	}
+	mergedConfig, err := containerd.MergedConfig(cfg, containerdVersion)
+	if err != nil {
+		return err
+	}
+	result.ContainerdConfig = secret.Redact(mergedConfig)
 	return nil
 }
+
+func newCheckError(err error, locator configprovider.FieldLocator) checkError {
+	checkErr := checkError{
+		Field:   validation.Field(err),
+		Message: secret.Redact(err.Error()),
+	}
+	for e := err; e != nil && checkErr.Remediation == ""; e = errors.Unwrap(e) {
+		checkErr.Remediation = validation.Remediation(e)
+	}
+	if checkErr.Field == "" || locator == nil {
+		return checkErr
+	}
+	if location, ok := locator.LocateField(checkErr.Field); ok {
+		checkErr.File = location.File
+		checkErr.Line = location.Line
+		checkErr.Column = location.Column
+	}
+	return checkErr
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/errors"
+	"github.com/aws/eks-hybrid/internal/flows"
+	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/node"
+	"github.com/aws/eks-hybrid/internal/render"
+)
+
+const diffHelpText = `Examples:
+  # Show the differences between the files on disk and the files nodeadm init would write
+  nodeadm config diff --config-source file:///root/nodeConfig.yaml
+
+The command exits with a non-zero status when any file differs.`
+
+type diffCmd struct {
+	cmd          *flaggy.Subcommand
+	configSource string
+}
+
+func NewDiffCommand() cli.Command {
+	diff := diffCmd{}
+	diff.cmd = flaggy.NewSubcommand("diff")
+	diff.cmd.Description = "Show drift between the files on disk and the files nodeadm init would write"
+	diff.cmd.AdditionalHelpAppend = diffHelpText
+	diff.cmd.String(&diff.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, http, https, s3, nocloud, guestinfo, ovf].")
+	return &diff
+}
+
+func (c *diffCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *diffCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := logger.NewContext(context.Background(), log)
+
+	if c.configSource == "" {
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, http, https, s3, nocloud, guestinfo, ovf]." +
+			" For example on hybrid nodes --config-source file://nodeConfig.yaml")
+	}
+
+	nodeProvider, err := node.NewNodeProvider(c.configSource, []string{}, log)
+	if err != nil {
+		return err
+	}
+	defer nodeProvider.Cleanup()
+
+	renderer := &flows.Renderer{
+		NodeProvider: nodeProvider,
+		Logger:       log,
+	}
+	files, err := renderer.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	var drifted []string
+	for _, file := range files {
+		diff, err := render.Diff(file)
+		if err != nil {
+			return fmt.Errorf("comparing %s: %w", file.Path, err)
+		}
+		if diff == "" {
+			continue
+		}
+		drifted = append(drifted, file.Path)
+		if _, err := fmt.Fprint(os.Stdout, diff); err != nil {
+			return err
+		}
+	}
+
+	if len(drifted) > 0 {
+		log.Error("Configuration drift detected", zap.Strings("files", drifted))
+		return errors.NewSilent(fmt.Errorf("%d of %d files differ from the generated configuration", len(drifted), len(files)))
+	}
+	log.Info("No configuration drift detected", zap.Int("files", len(files)))
+	return nil
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"context"
+	"os"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/flows"
+	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/node"
+	"github.com/aws/eks-hybrid/internal/render"
+)
+
+const renderHelpText = `Examples:
+  # Print every file nodeadm init would write
+  nodeadm config render --config-source file:///root/nodeConfig.yaml
+
+  # Write the files under a directory, keeping their paths
+  nodeadm config render --config-source file:///root/nodeConfig.yaml --output-dir /tmp/nodeadm-render`
+
+type renderCmd struct {
+	cmd          *flaggy.Subcommand
+	configSource string
+	outputDir    string
+}
+
+func NewRenderCommand() cli.Command {
+	render := renderCmd{}
+	render.cmd = flaggy.NewSubcommand("render")
+	render.cmd.Description = "Generate the files nodeadm init writes without applying them"
+	render.cmd.AdditionalHelpAppend = renderHelpText
+	render.cmd.String(&render.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, http, https, s3, nocloud, guestinfo, ovf].")
+	render.cmd.String(&render.outputDir, "o", "output-dir", "Directory to write the generated files to, instead of stdout. Files keep their paths relative to this directory.")
+	return &render
+}
+
+func (c *renderCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *renderCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := logger.NewContext(context.Background(), log)
+
+	if c.configSource == "" {
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, http, https, s3, nocloud, guestinfo, ovf]." +
+			" For example on hybrid nodes --config-source file://nodeConfig.yaml")
+	}
+
+	nodeProvider, err := node.NewNodeProvider(c.configSource, []string{}, log)
+	if err != nil {
+		return err
+	}
+	defer nodeProvider.Cleanup()
+
+	renderer := &flows.Renderer{
+		NodeProvider: nodeProvider,
+		Logger:       log,
+	}
+	files, err := renderer.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range files {
+		files[i].Content = render.Redact(files[i].Content)
+	}
+
+	if c.outputDir == "" {
+		return render.Print(os.Stdout, files)
+	}
+	log.Info("Writing generated files", zap.String("outputDir", c.outputDir), zap.Int("count", len(files)))
+	return render.WriteToDir(c.outputDir, files)
+}
@@ -7,6 +7,15 @@ import (
 const configHelpText = `Examples:
   # Check configuration file
   nodeadm config check --config-source file:///root/nodeConfig.yaml
+
+  # Show the files nodeadm init would write
+  nodeadm config render --config-source file:///root/nodeConfig.yaml
+
+  # Show drift between the files on disk and the generated configuration
+  nodeadm config diff --config-source file:///root/nodeConfig.yaml
+
+  # Print the JSON Schema of the configuration
+  nodeadm config schema
   
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_config_check`
@@ -15,5 +24,8 @@ func NewConfigCommand() cli.Command {
 	container := cli.NewCommandContainer("config", "Manage configuration")
 	container.Flaggy().AdditionalHelpAppend = configHelpText
 	container.AddCommand(NewCheckCommand())
+	container.AddCommand(NewRenderCommand())
+	container.AddCommand(NewDiffCommand())
+	container.AddCommand(NewSchemaCommand())
 	return container.AsCommand()
 }
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/api/v1alpha1"
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/schema"
+)
+
+const (
+	jsonSchemaFormat = "json-schema"
+	openAPIFormat    = "openapi"
+)
+
+const schemaHelpText = `Examples:
+  # Print the JSON Schema of NodeConfig
+  nodeadm config schema > nodeconfig.schema.json
+
+  # Print the OpenAPI v3 schema of NodeConfig
+  nodeadm config schema --format openapi`
+
+type schemaCmd struct {
+	cmd    *flaggy.Subcommand
+	format string
+}
+
+func NewSchemaCommand() cli.Command {
+	schema := schemaCmd{
+		format: jsonSchemaFormat,
+	}
+	schema.cmd = flaggy.NewSubcommand("schema")
+	schema.cmd.Description = "Print the schema of the node configuration"
+	schema.cmd.AdditionalHelpAppend = schemaHelpText
+	schema.cmd.String(&schema.format, "f", "format", fmt.Sprintf("Format of the schema. One of: [%s, %s].", jsonSchemaFormat, openAPIFormat))
+	return &schema
+}
+
+func (c *schemaCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *schemaCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	var nodeConfigSchema map[string]interface{}
+	var err error
+	switch c.format {
+	case jsonSchemaFormat:
+		nodeConfigSchema, err = schema.JSONSchema(v1alpha1.GroupVersion.Version)
+	case openAPIFormat:
+		nodeConfigSchema, err = schema.OpenAPI(v1alpha1.GroupVersion.Version)
+	default:
+		return fmt.Errorf("unsupported schema format %q, must be one of: [%s, %s]", c.format, jsonSchemaFormat, openAPIFormat)
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := schema.Marshal(nodeConfigSchema)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(data))
+	return err
+}
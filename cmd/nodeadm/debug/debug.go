@@ -21,6 +21,7 @@ import (
 	"github.com/aws/eks-hybrid/internal/kubernetes"
 	"github.com/aws/eks-hybrid/internal/logger"
 	"github.com/aws/eks-hybrid/internal/network"
+	"github.com/aws/eks-hybrid/internal/secret"
 	"github.com/aws/eks-hybrid/internal/system"
 	"github.com/aws/eks-hybrid/internal/validation"
 )
@@ -35,7 +36,7 @@ Documentation:
 func NewCommand() cli.Command {
 	debug := debug{}
 	debug.cmd = flaggy.NewSubcommand("debug")
-	debug.cmd.String(&debug.nodeConfigSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds].")
+	debug.cmd.String(&debug.nodeConfigSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, http, https, s3, nocloud, guestinfo, ovf].")
 	debug.cmd.Bool(&debug.noColor, "", "no-color", "If set, suppresses color output.")
 	debug.cmd.Description = "Debug the node registration process"
 	debug.cmd.AdditionalHelpPrepend = debugHelpText
@@ -57,7 +58,7 @@ func (c *debug) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	ctx = logger.NewContext(ctx, log)
 
 	if c.nodeConfigSource == "" {
-		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds]." +
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, http, https, s3, nocloud, guestinfo, ovf]." +
 			" For example on hybrid nodes --config-source file://nodeConfig.yaml")
 	}
 
@@ -69,6 +70,9 @@ func (c *debug) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	if err != nil {
 		return err
 	}
+	if err := secret.ResolveNodeConfig(nodeConfig); err != nil {
+		return err
+	}
 
 	awsConfig, err := creds.ReadConfigAsKubelet(ctx, nodeConfig, config.WithLogger(logging.Nop{}))
 	if err != nil {
@@ -54,13 +54,16 @@ const initHelpText = `Examples:
   # Initialize using configuration file
   nodeadm init --config-source file://nodeConfig.yaml
 
+  # Initialize using configuration served over https, pinned to its sha256 digest
+  nodeadm init --config-source "https://config.example.com/nodeConfig.yaml#sha256=<hex digest>"
+
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_init`
 
 func NewInitCommand() cli.Command {
 	init := initCmd{}
 	init.cmd = flaggy.NewSubcommand("init")
-	init.cmd.String(&init.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds].")
+	init.cmd.String(&init.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, http, https, s3, nocloud, guestinfo, ovf].")
 	init.cmd.StringSlice(&init.daemons, "d", "daemon", "Specify one or more of `containerd` and `kubelet`. This is intended for testing and should not be used in a production environment.")
 	init.cmd.StringSlice(&init.skipPhases, "s", "skip", fmt.Sprintf("Phases of the bootstrap to skip. Allowed values: [%s].", strings.Join(Phases(), ", ")))
 	init.cmd.Description = "Initialize this instance as a node in an EKS cluster"
@@ -92,7 +95,7 @@ func (c *initCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	}
 
 	if c.configSource == "" {
-		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds]." +
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, http, https, s3, nocloud, guestinfo, ovf]." +
 			" For example on hybrid nodes --config-source file://nodeConfig.yaml")
 	}
 
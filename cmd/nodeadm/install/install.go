@@ -2,12 +2,18 @@ package install
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
 	"time"
 
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/bundle"
+	"github.com/aws/eks-hybrid/internal/cache"
 	"github.com/aws/eks-hybrid/internal/cli"
 	"github.com/aws/eks-hybrid/internal/containerd"
 	"github.com/aws/eks-hybrid/internal/creds"
@@ -16,6 +22,7 @@ import (
 	"github.com/aws/eks-hybrid/internal/packagemanager"
 	"github.com/aws/eks-hybrid/internal/ssm"
 	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/util"
 )
 
 const installHelpText = `Examples:
@@ -25,6 +32,12 @@ const installHelpText = `Examples:
   # Install Kubernetes version 1.31 with AWS IAM Roles Anywhere as the credential provider and Docker as the containerd source
   nodeadm install 1.31 --credential-provider iam-ra --containerd-source docker
 
+  # Install Kubernetes version 1.31 from an offline bundle created with nodeadm bundle create
+  nodeadm install 1.31 --credential-provider iam-ra --bundle ./nodeadm-bundle.tgz
+
+  # Install Kubernetes version 1.31 downloading the artifacts from an internal mirror
+  nodeadm install 1.31 --credential-provider iam-ra --artifact-url-rewrite https://hybrid-assets.eks.amazonaws.com/=https://artifactory.example.com/eks-hybrid/
+
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_install`
 
@@ -43,18 +56,32 @@ func NewCommand() cli.Command {
 	fc.String(&cmd.containerdSource, "s", "containerd-source", "Source for containerd artifact. Allowed values: [none, distro, docker].")
 	fc.String(&cmd.region, "r", "region", "AWS region for downloading regional artifacts.")
 	fc.Duration(&cmd.timeout, "t", "timeout", "Maximum install command duration. Input follows duration format. Example: 1h23s")
+	fc.String(&cmd.manifestURL, "", "manifest-url", "URL of the release manifest to download the artifacts from, like an internal mirror.")
+	fc.StringSlice(&cmd.artifactURLRewrites, "", "artifact-url-rewrite", "Replace the prefix of the manifest and artifact URLs. Format: prefix=replacement.")
+	fc.String(&cmd.trustedKeys, "", "trusted-keys", "File with the armored PGP public keys that sign the manifest and artifact checksums, like the keys of a private mirror.")
+	fc.Bool(&cmd.skipSignatureVerification, "", "skip-signature-verification", "Don't verify the signatures of the release manifest and artifacts, for manifests that aren't signed. The checksums are still verified.")
+	fc.Bool(&cmd.noCache, "", "no-cache", fmt.Sprintf("Download every artifact instead of reusing the ones cached in %s.", cache.DefaultDir))
+	fc.String(&cmd.maxDownloadRate, "", "max-download-rate", "Maximum bytes per second of all the downloads together, like 10Mi. Unlimited by default.")
+	fc.String(&cmd.bundle, "b", "bundle", "Offline bundle to install from instead of downloading the artifacts, created with nodeadm bundle create.")
 	cmd.flaggy = fc
 
 	return &cmd
 }
 
 type command struct {
-	flaggy             *flaggy.Subcommand
-	kubernetesVersion  string
-	credentialProvider string
-	containerdSource   string
-	region             string
-	timeout            time.Duration
+	flaggy                    *flaggy.Subcommand
+	kubernetesVersion         string
+	credentialProvider        string
+	containerdSource          string
+	region                    string
+	timeout                   time.Duration
+	manifestURL               string
+	artifactURLRewrites       []string
+	trustedKeys               string
+	skipSignatureVerification bool
+	noCache                   bool
+	maxDownloadRate           string
+	bundle                    string
 }
 
 func (c *command) Flaggy() *flaggy.Subcommand {
@@ -89,21 +116,56 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		return err
 	}
 
-	log.Info("Creating package manager...")
-	packageManager, err := packagemanager.New(containerdSource, log)
-	if err != nil {
-		return err
-	}
-
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
-	log.Info("Validating Kubernetes version", zap.Reflect("kubernetes version", c.kubernetesVersion))
-	// Create a Source for all AWS managed artifacts.
-	awsSource, err := aws.GetLatestSource(ctx, c.kubernetesVersion, c.region)
+	mirrorOpts, err := aws.MirrorOptions(c.manifestURL, c.artifactURLRewrites, c.trustedKeys, nil)
 	if err != nil {
 		return err
 	}
+	if c.skipSignatureVerification {
+		mirrorOpts = append(mirrorOpts, aws.WithoutSignatureVerification())
+	}
+	maxDownloadRate, err := aws.MaxDownloadRate(c.maxDownloadRate, nil)
+	if err != nil {
+		return err
+	}
+	ctx = util.WithDownloadOptions(ctx, util.DownloadOptions{
+		MaxRate:    maxDownloadRate,
+		PartialDir: cache.PartialDownloadsDir,
+	})
+
+	var packageManager *packagemanager.DistroPackageManager
+	var awsSource aws.Source
+	if c.bundle != "" {
+		bundleDir, err := os.MkdirTemp("", "nodeadm-bundle")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(bundleDir)
+
+		packageManager, awsSource, err = c.openBundle(log, bundleDir, credentialProvider, containerdSource, mirrorOpts)
+		if err != nil {
+			return err
+		}
+	} else {
+		log.Info("Creating package manager...")
+		packageManager, err = packagemanager.New(containerdSource, log)
+		if err != nil {
+			return err
+		}
+
+		if !c.noCache {
+			mirrorOpts = append(mirrorOpts, aws.WithCache(cache.New(cache.DefaultDir, cache.DefaultMaxSize)))
+		}
+
+		log.Info("Validating Kubernetes version", zap.Reflect("kubernetes version", c.kubernetesVersion))
+		// Create a Source for all AWS managed artifacts.
+		awsSource, err = aws.GetLatestSource(ctx, c.kubernetesVersion, c.region, mirrorOpts...)
+		if err != nil {
+			return err
+		}
+	}
 	log.Info("Using Kubernetes version", zap.Reflect("kubernetes version", awsSource.Eks.Version))
 
 	installer := &flows.Installer{
@@ -117,3 +179,38 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 
 	return installer.Run(ctx)
 }
+
+// openBundle extracts the bundle to dir and returns the package manager and source
+// to install from it, verifying the artifact signatures with the keys in sourceOpts.
+func (c *command) openBundle(log *zap.Logger, dir string, credentialProvider creds.CredentialProvider, containerdSource tracker.ContainerdSourceName, sourceOpts []aws.SourceOption) (*packagemanager.DistroPackageManager, aws.Source, error) {
+	log.Info("Extracting bundle...", zap.String("bundle", c.bundle))
+	metadata, err := bundle.Extract(dir, c.bundle)
+	if err != nil {
+		return nil, aws.Source{}, err
+	}
+	if err := metadata.Validate(c.kubernetesVersion, credentialProvider, runtime.GOARCH); err != nil {
+		return nil, aws.Source{}, err
+	}
+
+	var opts []packagemanager.Option
+	if metadata.PackageManager != "" {
+		if containerdSource == tracker.ContainerdSourceDocker {
+			return nil, aws.Source{}, fmt.Errorf("bundle packages can't be installed with containerd source %s", containerdSource)
+		}
+		opts = append(opts, packagemanager.WithLocalPackages(filepath.Join(dir, bundle.PackagesDir)))
+	}
+	log.Info("Creating package manager...")
+	packageManager, err := packagemanager.New(containerdSource, log, opts...)
+	if err != nil {
+		return nil, aws.Source{}, err
+	}
+	if metadata.PackageManager != "" && metadata.PackageManager != packageManager.Manager() {
+		return nil, aws.Source{}, fmt.Errorf("bundle packages are for %s, but the node uses %s", metadata.PackageManager, packageManager.Manager())
+	}
+
+	awsSource, err := aws.GetBundleSource(dir, c.kubernetesVersion, c.region, sourceOpts...)
+	if err != nil {
+		return nil, aws.Source{}, err
+	}
+	return packageManager, awsSource, nil
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/cmd/nodeadm/bundle"
+	"github.com/aws/eks-hybrid/cmd/nodeadm/cache"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/config"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/debug"
 	initcmd "github.com/aws/eks-hybrid/cmd/nodeadm/init"
@@ -37,6 +39,8 @@ func main() {
 		uninstall.NewCommand(),
 		upgrade.NewUpgradeCommand(),
 		debug.NewCommand(),
+		bundle.NewBundleCommand(),
+		cache.NewCacheCommand(),
 	}
 
 	for _, cmd := range cmds {
@@ -127,7 +127,7 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	}
 
 	uninstaller := &flows.Uninstaller{
-		Artifacts:      installed.Artifacts,
+		Tracker:        installed,
 		DaemonManager:  daemonManager,
 		PackageManager: packageManager,
 		Logger:         log,
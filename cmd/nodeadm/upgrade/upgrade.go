@@ -13,6 +13,7 @@ import (
 
 	initCmd "github.com/aws/eks-hybrid/cmd/nodeadm/init"
 	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/cache"
 	"github.com/aws/eks-hybrid/internal/cli"
 	"github.com/aws/eks-hybrid/internal/creds"
 	"github.com/aws/eks-hybrid/internal/daemon"
@@ -22,6 +23,7 @@ import (
 	"github.com/aws/eks-hybrid/internal/node"
 	"github.com/aws/eks-hybrid/internal/packagemanager"
 	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/util"
 )
 
 const (
@@ -64,19 +66,31 @@ func NewUpgradeCommand() cli.Command {
 	fc.Description = "Upgrade components installed using the install sub-command"
 	fc.AdditionalHelpAppend = upgradeHelpText
 	fc.AddPositionalValue(&cmd.kubernetesVersion, "KUBERNETES_VERSION", 1, true, "The major[.minor[.patch]] version of Kubernetes to install.")
-	fc.String(&cmd.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds].")
+	fc.String(&cmd.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, http, https, s3, nocloud, guestinfo, ovf].")
 	fc.StringSlice(&cmd.skipPhases, "s", "skip", fmt.Sprintf("Phases of the upgrade to skip. Allowed values: [%s].", strings.Join(upgradePhases(), ", ")))
 	fc.Duration(&cmd.timeout, "t", "timeout", "Maximum upgrade command duration. Input follows duration format. Example: 1h23s")
+	fc.String(&cmd.manifestURL, "", "manifest-url", "URL of the release manifest to download the artifacts from, like an internal mirror.")
+	fc.StringSlice(&cmd.artifactURLRewrites, "", "artifact-url-rewrite", "Replace the prefix of the manifest and artifact URLs. Format: prefix=replacement.")
+	fc.String(&cmd.trustedKeys, "", "trusted-keys", "File with the armored PGP public keys that sign the manifest and artifact checksums, like the keys of a private mirror.")
+	fc.Bool(&cmd.skipSignatureVerification, "", "skip-signature-verification", "Don't verify the signatures of the release manifest and artifacts, for manifests that aren't signed. The checksums are still verified.")
+	fc.Bool(&cmd.noCache, "", "no-cache", fmt.Sprintf("Download every artifact instead of reusing the ones cached in %s.", cache.DefaultDir))
+	fc.String(&cmd.maxDownloadRate, "", "max-download-rate", "Maximum bytes per second of all the downloads together, like 10Mi. Unlimited by default.")
 	cmd.flaggy = fc
 	return &cmd
 }
 
 type command struct {
-	flaggy            *flaggy.Subcommand
-	configSource      string
-	skipPhases        []string
-	kubernetesVersion string
-	timeout           time.Duration
+	flaggy                    *flaggy.Subcommand
+	configSource              string
+	skipPhases                []string
+	kubernetesVersion         string
+	timeout                   time.Duration
+	manifestURL               string
+	artifactURLRewrites       []string
+	trustedKeys               string
+	skipSignatureVerification bool
+	noCache                   bool
+	maxDownloadRate           string
 }
 
 func (c *command) Flaggy() *flaggy.Subcommand {
@@ -96,7 +110,7 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	}
 
 	if c.configSource == "" {
-		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds]." +
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, http, https, s3, nocloud, guestinfo, ovf]." +
 			" For example on hybrid nodes --config-source file://nodeConfig.yaml")
 	}
 
@@ -149,9 +163,28 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		return fmt.Errorf("upgrade does not support changing credential providers. Please uninstall and install with new credential provider")
 	}
 
+	mirrorOpts, err := aws.MirrorOptions(c.manifestURL, c.artifactURLRewrites, c.trustedKeys, nodeConfig.GetArtifactsOptions())
+	if err != nil {
+		return err
+	}
+	if c.skipSignatureVerification {
+		mirrorOpts = append(mirrorOpts, aws.WithoutSignatureVerification())
+	}
+	if !c.noCache {
+		mirrorOpts = append(mirrorOpts, aws.WithCache(cache.New(cache.DefaultDir, cache.DefaultMaxSize)))
+	}
+	maxDownloadRate, err := aws.MaxDownloadRate(c.maxDownloadRate, nodeConfig.GetArtifactsOptions())
+	if err != nil {
+		return err
+	}
+	ctx = util.WithDownloadOptions(ctx, util.DownloadOptions{
+		MaxRate:    maxDownloadRate,
+		PartialDir: cache.PartialDownloadsDir,
+	})
+
 	log.Info("Validating Kubernetes version", zap.Reflect("kubernetes version", c.kubernetesVersion))
 	// Create a Source for all AWS managed artifacts.
-	awsSource, err := aws.GetLatestSource(ctx, c.kubernetesVersion, region)
+	awsSource, err := aws.GetLatestSource(ctx, c.kubernetesVersion, region, mirrorOpts...)
 	if err != nil {
 		return err
 	}
@@ -201,7 +234,7 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		AwsSource:          awsSource,
 		PackageManager:     packageManager,
 		CredentialProvider: credsProvider,
-		Artifacts:          installed.Artifacts,
+		Tracker:            installed,
 		DaemonManager:      daemonManager,
 		SkipPhases:         c.skipPhases,
 		Logger:             log,
@@ -0,0 +1,9 @@
+// Package crds contains the CustomResourceDefinitions generated from the nodeadm API types.
+package crds
+
+import _ "embed"
+
+// NodeConfig is the CustomResourceDefinition of the NodeConfig kind.
+//
+//go:embed node.eks.aws_nodeconfigs.yaml
+var NodeConfig []byte
@@ -3,6 +3,7 @@ package bridge
 import (
 	"fmt"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"sigs.k8s.io/yaml"
@@ -36,6 +37,51 @@ func DecodeNodeConfig(data []byte) (*internalapi.NodeConfig, error) {
 	return nil, fmt.Errorf("unable to convert %T to internal NodeConfig", obj)
 }
 
+// DecodeNodeConfigList unmarshals the given data into an internal NodeConfigList object.
+// The data may be JSON or YAML.
+func DecodeNodeConfigList(data []byte) (*internalapi.NodeConfigList, error) {
+	scheme := runtime.NewScheme()
+	err := localSchemeBuilder.AddToScheme(scheme)
+	if err != nil {
+		return nil, err
+	}
+	codecs := serializer.NewCodecFactory(scheme)
+	obj, gvk, err := codecs.UniversalDecoder().Decode(data, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if gvk.Kind != api.KindNodeConfigList {
+		return nil, fmt.Errorf("failed to decode %q (wrong Kind)", gvk.Kind)
+	}
+	if gvk.Group != api.GroupName {
+		return nil, fmt.Errorf("failed to decode %q, unexpected group: %s", gvk.Kind, gvk.Group)
+	}
+	if internalList, ok := obj.(*internalapi.NodeConfigList); ok {
+		return internalList, nil
+	}
+	return nil, fmt.Errorf("unable to convert %T to internal NodeConfigList", obj)
+}
+
+// IsNodeConfigList returns true if the given data declares the NodeConfigList kind.
+func IsNodeConfigList(data []byte) bool {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(data, &typeMeta); err != nil {
+		return false
+	}
+	return typeMeta.Kind == api.KindNodeConfigList
+}
+
+// DecodeStrictNodeConfigList unmarshals the given data into an internal NodeConfigList object.
+// It attempts a struct unmarshalling. Will throw an error if unknown fields are present.
+func DecodeStrictNodeConfigList(data []byte) (*internalapi.NodeConfigList, error) {
+	var obj internalapi.NodeConfigList
+	if err := yaml.UnmarshalStrict(data, &obj); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
+
 // DecodeStrictNodeConfig unmarshals the given data into an internal NodeConfig object.
 // It attempts a struct unmarshalling. Will throw an error if unknown fields are present.
 func DecodeStrictNodeConfig(data []byte) (*internalapi.NodeConfig, error) {
@@ -18,6 +18,7 @@ func addInternalTypes(scheme *runtime.Scheme) error {
 	groupVersion := schema.GroupVersion{Group: api.GroupName, Version: runtime.APIVersionInternal}
 	scheme.AddKnownTypes(groupVersion,
 		&internalapi.NodeConfig{},
+		&internalapi.NodeConfigList{},
 	)
 	return nil
 }
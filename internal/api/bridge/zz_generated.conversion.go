@@ -21,6 +21,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ArtifactsOptions)(nil), (*api.ArtifactsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ArtifactsOptions_To_api_ArtifactsOptions(a.(*v1alpha1.ArtifactsOptions), b.(*api.ArtifactsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ArtifactsOptions)(nil), (*v1alpha1.ArtifactsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ArtifactsOptions_To_v1alpha1_ArtifactsOptions(a.(*api.ArtifactsOptions), b.(*v1alpha1.ArtifactsOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.ClusterDetails)(nil), (*api.ClusterDetails)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ClusterDetails_To_api_ClusterDetails(a.(*v1alpha1.ClusterDetails), b.(*api.ClusterDetails), scope)
 	}); err != nil {
@@ -41,6 +51,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ContainerdRegistry)(nil), (*api.ContainerdRegistry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ContainerdRegistry_To_api_ContainerdRegistry(a.(*v1alpha1.ContainerdRegistry), b.(*api.ContainerdRegistry), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ContainerdRegistry)(nil), (*v1alpha1.ContainerdRegistry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ContainerdRegistry_To_v1alpha1_ContainerdRegistry(a.(*api.ContainerdRegistry), b.(*v1alpha1.ContainerdRegistry), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ContainerdRuntime)(nil), (*api.ContainerdRuntime)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ContainerdRuntime_To_api_ContainerdRuntime(a.(*v1alpha1.ContainerdRuntime), b.(*api.ContainerdRuntime), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ContainerdRuntime)(nil), (*v1alpha1.ContainerdRuntime)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ContainerdRuntime_To_v1alpha1_ContainerdRuntime(a.(*api.ContainerdRuntime), b.(*v1alpha1.ContainerdRuntime), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.Hugepages)(nil), (*api.Hugepages)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Hugepages_To_api_Hugepages(a.(*v1alpha1.Hugepages), b.(*api.Hugepages), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.Hugepages)(nil), (*v1alpha1.Hugepages)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_Hugepages_To_v1alpha1_Hugepages(a.(*api.Hugepages), b.(*v1alpha1.Hugepages), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.HybridOptions)(nil), (*api.HybridOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_HybridOptions_To_api_HybridOptions(a.(*v1alpha1.HybridOptions), b.(*api.HybridOptions), scope)
 	}); err != nil {
@@ -121,6 +161,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.RegistryMirror)(nil), (*api.RegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegistryMirror_To_api_RegistryMirror(a.(*v1alpha1.RegistryMirror), b.(*api.RegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RegistryMirror)(nil), (*v1alpha1.RegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RegistryMirror_To_v1alpha1_RegistryMirror(a.(*api.RegistryMirror), b.(*v1alpha1.RegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.RegistryTLS)(nil), (*api.RegistryTLS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegistryTLS_To_api_RegistryTLS(a.(*v1alpha1.RegistryTLS), b.(*api.RegistryTLS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RegistryTLS)(nil), (*v1alpha1.RegistryTLS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RegistryTLS_To_v1alpha1_RegistryTLS(a.(*api.RegistryTLS), b.(*v1alpha1.RegistryTLS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ReservedResources)(nil), (*api.ReservedResources)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ReservedResources_To_api_ReservedResources(a.(*v1alpha1.ReservedResources), b.(*api.ReservedResources), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ReservedResources)(nil), (*v1alpha1.ReservedResources)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ReservedResources_To_v1alpha1_ReservedResources(a.(*api.ReservedResources), b.(*v1alpha1.ReservedResources), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.SSM)(nil), (*api.SSM)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_SSM_To_api_SSM(a.(*v1alpha1.SSM), b.(*api.SSM), scope)
 	}); err != nil {
@@ -131,9 +201,67 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SecretSource)(nil), (*api.SecretSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SecretSource_To_api_SecretSource(a.(*v1alpha1.SecretSource), b.(*api.SecretSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SecretSource)(nil), (*v1alpha1.SecretSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SecretSource_To_v1alpha1_SecretSource(a.(*api.SecretSource), b.(*v1alpha1.SecretSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.Taint)(nil), (*api.Taint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Taint_To_api_Taint(a.(*v1alpha1.Taint), b.(*api.Taint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.Taint)(nil), (*v1alpha1.Taint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_Taint_To_v1alpha1_Taint(a.(*api.Taint), b.(*v1alpha1.Taint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.URLRewrite)(nil), (*api.URLRewrite)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_URLRewrite_To_api_URLRewrite(a.(*v1alpha1.URLRewrite), b.(*api.URLRewrite), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.URLRewrite)(nil), (*v1alpha1.URLRewrite)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_URLRewrite_To_v1alpha1_URLRewrite(a.(*api.URLRewrite), b.(*v1alpha1.URLRewrite), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
+func autoConvert_v1alpha1_ArtifactsOptions_To_api_ArtifactsOptions(in *v1alpha1.ArtifactsOptions, out *api.ArtifactsOptions, s conversion.Scope) error {
+	out.ManifestURL = in.ManifestURL
+	out.URLRewrites = *(*[]api.URLRewrite)(unsafe.Pointer(&in.URLRewrites))
+	out.TrustedKeys = in.TrustedKeys
+	out.SkipSignatureVerification = in.SkipSignatureVerification
+	out.MaxDownloadRate = in.MaxDownloadRate
+	return nil
+}
+
+// Convert_v1alpha1_ArtifactsOptions_To_api_ArtifactsOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ArtifactsOptions_To_api_ArtifactsOptions(in *v1alpha1.ArtifactsOptions, out *api.ArtifactsOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ArtifactsOptions_To_api_ArtifactsOptions(in, out, s)
+}
+
+func autoConvert_api_ArtifactsOptions_To_v1alpha1_ArtifactsOptions(in *api.ArtifactsOptions, out *v1alpha1.ArtifactsOptions, s conversion.Scope) error {
+	out.ManifestURL = in.ManifestURL
+	out.URLRewrites = *(*[]v1alpha1.URLRewrite)(unsafe.Pointer(&in.URLRewrites))
+	out.TrustedKeys = in.TrustedKeys
+	out.SkipSignatureVerification = in.SkipSignatureVerification
+	out.MaxDownloadRate = in.MaxDownloadRate
+	return nil
+}
+
+// Convert_api_ArtifactsOptions_To_v1alpha1_ArtifactsOptions is an autogenerated conversion function.
+func Convert_api_ArtifactsOptions_To_v1alpha1_ArtifactsOptions(in *api.ArtifactsOptions, out *v1alpha1.ArtifactsOptions, s conversion.Scope) error {
+	return autoConvert_api_ArtifactsOptions_To_v1alpha1_ArtifactsOptions(in, out, s)
+}
+
 func autoConvert_v1alpha1_ClusterDetails_To_api_ClusterDetails(in *v1alpha1.ClusterDetails, out *api.ClusterDetails, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Region = in.Region
@@ -168,6 +296,8 @@ func Convert_api_ClusterDetails_To_v1alpha1_ClusterDetails(in *api.ClusterDetail
 
 func autoConvert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(in *v1alpha1.ContainerdOptions, out *api.ContainerdOptions, s conversion.Scope) error {
 	out.Config = in.Config
+	out.Registries = *(*[]api.ContainerdRegistry)(unsafe.Pointer(&in.Registries))
+	out.Runtimes = *(*[]api.ContainerdRuntime)(unsafe.Pointer(&in.Runtimes))
 	return nil
 }
 
@@ -178,6 +308,8 @@ func Convert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(in *v1alpha1.Co
 
 func autoConvert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in *api.ContainerdOptions, out *v1alpha1.ContainerdOptions, s conversion.Scope) error {
 	out.Config = in.Config
+	out.Registries = *(*[]v1alpha1.ContainerdRegistry)(unsafe.Pointer(&in.Registries))
+	out.Runtimes = *(*[]v1alpha1.ContainerdRuntime)(unsafe.Pointer(&in.Runtimes))
 	return nil
 }
 
@@ -186,10 +318,95 @@ func Convert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in *api.Contain
 	return autoConvert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in, out, s)
 }
 
+func autoConvert_v1alpha1_ContainerdRegistry_To_api_ContainerdRegistry(in *v1alpha1.ContainerdRegistry, out *api.ContainerdRegistry, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Server = in.Server
+	out.TLS = (*api.RegistryTLS)(unsafe.Pointer(in.TLS))
+	out.Mirrors = *(*[]api.RegistryMirror)(unsafe.Pointer(&in.Mirrors))
+	return nil
+}
+
+// Convert_v1alpha1_ContainerdRegistry_To_api_ContainerdRegistry is an autogenerated conversion function.
+func Convert_v1alpha1_ContainerdRegistry_To_api_ContainerdRegistry(in *v1alpha1.ContainerdRegistry, out *api.ContainerdRegistry, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ContainerdRegistry_To_api_ContainerdRegistry(in, out, s)
+}
+
+func autoConvert_api_ContainerdRegistry_To_v1alpha1_ContainerdRegistry(in *api.ContainerdRegistry, out *v1alpha1.ContainerdRegistry, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Server = in.Server
+	out.TLS = (*v1alpha1.RegistryTLS)(unsafe.Pointer(in.TLS))
+	out.Mirrors = *(*[]v1alpha1.RegistryMirror)(unsafe.Pointer(&in.Mirrors))
+	return nil
+}
+
+// Convert_api_ContainerdRegistry_To_v1alpha1_ContainerdRegistry is an autogenerated conversion function.
+func Convert_api_ContainerdRegistry_To_v1alpha1_ContainerdRegistry(in *api.ContainerdRegistry, out *v1alpha1.ContainerdRegistry, s conversion.Scope) error {
+	return autoConvert_api_ContainerdRegistry_To_v1alpha1_ContainerdRegistry(in, out, s)
+}
+
+func autoConvert_v1alpha1_ContainerdRuntime_To_api_ContainerdRuntime(in *v1alpha1.ContainerdRuntime, out *api.ContainerdRuntime, s conversion.Scope) error {
+	out.Name = in.Name
+	out.RuntimeType = in.RuntimeType
+	out.BinaryPath = in.BinaryPath
+	out.Options = *(*map[string]string)(unsafe.Pointer(&in.Options))
+	out.PrivilegedWithoutHostDevices = in.PrivilegedWithoutHostDevices
+	out.NodeLabel = in.NodeLabel
+	return nil
+}
+
+// Convert_v1alpha1_ContainerdRuntime_To_api_ContainerdRuntime is an autogenerated conversion function.
+func Convert_v1alpha1_ContainerdRuntime_To_api_ContainerdRuntime(in *v1alpha1.ContainerdRuntime, out *api.ContainerdRuntime, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ContainerdRuntime_To_api_ContainerdRuntime(in, out, s)
+}
+
+func autoConvert_api_ContainerdRuntime_To_v1alpha1_ContainerdRuntime(in *api.ContainerdRuntime, out *v1alpha1.ContainerdRuntime, s conversion.Scope) error {
+	out.Name = in.Name
+	out.RuntimeType = in.RuntimeType
+	out.BinaryPath = in.BinaryPath
+	out.Options = *(*map[string]string)(unsafe.Pointer(&in.Options))
+	out.PrivilegedWithoutHostDevices = in.PrivilegedWithoutHostDevices
+	out.NodeLabel = in.NodeLabel
+	return nil
+}
+
+// Convert_api_ContainerdRuntime_To_v1alpha1_ContainerdRuntime is an autogenerated conversion function.
+func Convert_api_ContainerdRuntime_To_v1alpha1_ContainerdRuntime(in *api.ContainerdRuntime, out *v1alpha1.ContainerdRuntime, s conversion.Scope) error {
+	return autoConvert_api_ContainerdRuntime_To_v1alpha1_ContainerdRuntime(in, out, s)
+}
+
+func autoConvert_v1alpha1_Hugepages_To_api_Hugepages(in *v1alpha1.Hugepages, out *api.Hugepages, s conversion.Scope) error {
+	out.Size = api.HugepageSize(in.Size)
+	out.Count = in.Count
+	return nil
+}
+
+// Convert_v1alpha1_Hugepages_To_api_Hugepages is an autogenerated conversion function.
+func Convert_v1alpha1_Hugepages_To_api_Hugepages(in *v1alpha1.Hugepages, out *api.Hugepages, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Hugepages_To_api_Hugepages(in, out, s)
+}
+
+func autoConvert_api_Hugepages_To_v1alpha1_Hugepages(in *api.Hugepages, out *v1alpha1.Hugepages, s conversion.Scope) error {
+	out.Size = v1alpha1.HugepageSize(in.Size)
+	out.Count = in.Count
+	return nil
+}
+
+// Convert_api_Hugepages_To_v1alpha1_Hugepages is an autogenerated conversion function.
+func Convert_api_Hugepages_To_v1alpha1_Hugepages(in *api.Hugepages, out *v1alpha1.Hugepages, s conversion.Scope) error {
+	return autoConvert_api_Hugepages_To_v1alpha1_Hugepages(in, out, s)
+}
+
 func autoConvert_v1alpha1_HybridOptions_To_api_HybridOptions(in *v1alpha1.HybridOptions, out *api.HybridOptions, s conversion.Scope) error {
 	out.EnableCredentialsFile = in.EnableCredentialsFile
 	out.IAMRolesAnywhere = (*api.IAMRolesAnywhere)(unsafe.Pointer(in.IAMRolesAnywhere))
 	out.SSM = (*api.SSM)(unsafe.Pointer(in.SSM))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Taints = *(*[]api.Taint)(unsafe.Pointer(&in.Taints))
+	out.ReservedResources = (*api.ReservedResources)(unsafe.Pointer(in.ReservedResources))
+	out.CPUManager = api.CPUManagerPolicy(in.CPUManager)
+	out.Hugepages = *(*[]api.Hugepages)(unsafe.Pointer(&in.Hugepages))
+	out.Swap = api.SwapMode(in.Swap)
+	out.Artifacts = (*api.ArtifactsOptions)(unsafe.Pointer(in.Artifacts))
 	return nil
 }
 
@@ -202,6 +419,13 @@ func autoConvert_api_HybridOptions_To_v1alpha1_HybridOptions(in *api.HybridOptio
 	out.EnableCredentialsFile = in.EnableCredentialsFile
 	out.IAMRolesAnywhere = (*v1alpha1.IAMRolesAnywhere)(unsafe.Pointer(in.IAMRolesAnywhere))
 	out.SSM = (*v1alpha1.SSM)(unsafe.Pointer(in.SSM))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Taints = *(*[]v1alpha1.Taint)(unsafe.Pointer(&in.Taints))
+	out.ReservedResources = (*v1alpha1.ReservedResources)(unsafe.Pointer(in.ReservedResources))
+	out.CPUManager = v1alpha1.CPUManagerPolicy(in.CPUManager)
+	out.Hugepages = *(*[]v1alpha1.Hugepages)(unsafe.Pointer(&in.Hugepages))
+	out.Swap = v1alpha1.SwapMode(in.Swap)
+	out.Artifacts = (*v1alpha1.ArtifactsOptions)(unsafe.Pointer(in.Artifacts))
 	return nil
 }
 
@@ -421,8 +645,87 @@ func Convert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in *api.NodeConfigSpe
 	return autoConvert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in, out, s)
 }
 
+func autoConvert_v1alpha1_RegistryMirror_To_api_RegistryMirror(in *v1alpha1.RegistryMirror, out *api.RegistryMirror, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Capabilities = *(*[]api.RegistryCapability)(unsafe.Pointer(&in.Capabilities))
+	out.OverridePath = in.OverridePath
+	out.TLS = (*api.RegistryTLS)(unsafe.Pointer(in.TLS))
+	return nil
+}
+
+// Convert_v1alpha1_RegistryMirror_To_api_RegistryMirror is an autogenerated conversion function.
+func Convert_v1alpha1_RegistryMirror_To_api_RegistryMirror(in *v1alpha1.RegistryMirror, out *api.RegistryMirror, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegistryMirror_To_api_RegistryMirror(in, out, s)
+}
+
+func autoConvert_api_RegistryMirror_To_v1alpha1_RegistryMirror(in *api.RegistryMirror, out *v1alpha1.RegistryMirror, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Capabilities = *(*[]v1alpha1.RegistryCapability)(unsafe.Pointer(&in.Capabilities))
+	out.OverridePath = in.OverridePath
+	out.TLS = (*v1alpha1.RegistryTLS)(unsafe.Pointer(in.TLS))
+	return nil
+}
+
+// Convert_api_RegistryMirror_To_v1alpha1_RegistryMirror is an autogenerated conversion function.
+func Convert_api_RegistryMirror_To_v1alpha1_RegistryMirror(in *api.RegistryMirror, out *v1alpha1.RegistryMirror, s conversion.Scope) error {
+	return autoConvert_api_RegistryMirror_To_v1alpha1_RegistryMirror(in, out, s)
+}
+
+func autoConvert_v1alpha1_RegistryTLS_To_api_RegistryTLS(in *v1alpha1.RegistryTLS, out *api.RegistryTLS, s conversion.Scope) error {
+	out.CACert = in.CACert
+	out.ClientCert = in.ClientCert
+	out.ClientKey = in.ClientKey
+	out.SkipVerify = in.SkipVerify
+	return nil
+}
+
+// Convert_v1alpha1_RegistryTLS_To_api_RegistryTLS is an autogenerated conversion function.
+func Convert_v1alpha1_RegistryTLS_To_api_RegistryTLS(in *v1alpha1.RegistryTLS, out *api.RegistryTLS, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegistryTLS_To_api_RegistryTLS(in, out, s)
+}
+
+func autoConvert_api_RegistryTLS_To_v1alpha1_RegistryTLS(in *api.RegistryTLS, out *v1alpha1.RegistryTLS, s conversion.Scope) error {
+	out.CACert = in.CACert
+	out.ClientCert = in.ClientCert
+	out.ClientKey = in.ClientKey
+	out.SkipVerify = in.SkipVerify
+	return nil
+}
+
+// Convert_api_RegistryTLS_To_v1alpha1_RegistryTLS is an autogenerated conversion function.
+func Convert_api_RegistryTLS_To_v1alpha1_RegistryTLS(in *api.RegistryTLS, out *v1alpha1.RegistryTLS, s conversion.Scope) error {
+	return autoConvert_api_RegistryTLS_To_v1alpha1_RegistryTLS(in, out, s)
+}
+
+func autoConvert_v1alpha1_ReservedResources_To_api_ReservedResources(in *v1alpha1.ReservedResources, out *api.ReservedResources, s conversion.Scope) error {
+	out.Profile = api.ReservedResourcesProfile(in.Profile)
+	out.KubeReserved = *(*map[string]string)(unsafe.Pointer(&in.KubeReserved))
+	out.SystemReserved = *(*map[string]string)(unsafe.Pointer(&in.SystemReserved))
+	out.EvictionHard = *(*map[string]string)(unsafe.Pointer(&in.EvictionHard))
+	return nil
+}
+
+// Convert_v1alpha1_ReservedResources_To_api_ReservedResources is an autogenerated conversion function.
+func Convert_v1alpha1_ReservedResources_To_api_ReservedResources(in *v1alpha1.ReservedResources, out *api.ReservedResources, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ReservedResources_To_api_ReservedResources(in, out, s)
+}
+
+func autoConvert_api_ReservedResources_To_v1alpha1_ReservedResources(in *api.ReservedResources, out *v1alpha1.ReservedResources, s conversion.Scope) error {
+	out.Profile = v1alpha1.ReservedResourcesProfile(in.Profile)
+	out.KubeReserved = *(*map[string]string)(unsafe.Pointer(&in.KubeReserved))
+	out.SystemReserved = *(*map[string]string)(unsafe.Pointer(&in.SystemReserved))
+	out.EvictionHard = *(*map[string]string)(unsafe.Pointer(&in.EvictionHard))
+	return nil
+}
+
+// Convert_api_ReservedResources_To_v1alpha1_ReservedResources is an autogenerated conversion function.
+func Convert_api_ReservedResources_To_v1alpha1_ReservedResources(in *api.ReservedResources, out *v1alpha1.ReservedResources, s conversion.Scope) error {
+	return autoConvert_api_ReservedResources_To_v1alpha1_ReservedResources(in, out, s)
+}
+
 func autoConvert_v1alpha1_SSM_To_api_SSM(in *v1alpha1.SSM, out *api.SSM, s conversion.Scope) error {
 	out.ActivationCode = in.ActivationCode
+	out.ActivationCodeFrom = (*api.SecretSource)(unsafe.Pointer(in.ActivationCodeFrom))
 	out.ActivationID = in.ActivationID
 	return nil
 }
@@ -434,6 +737,7 @@ func Convert_v1alpha1_SSM_To_api_SSM(in *v1alpha1.SSM, out *api.SSM, s conversio
 
 func autoConvert_api_SSM_To_v1alpha1_SSM(in *api.SSM, out *v1alpha1.SSM, s conversion.Scope) error {
 	out.ActivationCode = in.ActivationCode
+	out.ActivationCodeFrom = (*v1alpha1.SecretSource)(unsafe.Pointer(in.ActivationCodeFrom))
 	out.ActivationID = in.ActivationID
 	return nil
 }
@@ -442,3 +746,73 @@ func autoConvert_api_SSM_To_v1alpha1_SSM(in *api.SSM, out *v1alpha1.SSM, s conve
 func Convert_api_SSM_To_v1alpha1_SSM(in *api.SSM, out *v1alpha1.SSM, s conversion.Scope) error {
 	return autoConvert_api_SSM_To_v1alpha1_SSM(in, out, s)
 }
+
+func autoConvert_v1alpha1_SecretSource_To_api_SecretSource(in *v1alpha1.SecretSource, out *api.SecretSource, s conversion.Scope) error {
+	out.File = in.File
+	out.Env = in.Env
+	out.Credential = in.Credential
+	return nil
+}
+
+// Convert_v1alpha1_SecretSource_To_api_SecretSource is an autogenerated conversion function.
+func Convert_v1alpha1_SecretSource_To_api_SecretSource(in *v1alpha1.SecretSource, out *api.SecretSource, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SecretSource_To_api_SecretSource(in, out, s)
+}
+
+func autoConvert_api_SecretSource_To_v1alpha1_SecretSource(in *api.SecretSource, out *v1alpha1.SecretSource, s conversion.Scope) error {
+	out.File = in.File
+	out.Env = in.Env
+	out.Credential = in.Credential
+	return nil
+}
+
+// Convert_api_SecretSource_To_v1alpha1_SecretSource is an autogenerated conversion function.
+func Convert_api_SecretSource_To_v1alpha1_SecretSource(in *api.SecretSource, out *v1alpha1.SecretSource, s conversion.Scope) error {
+	return autoConvert_api_SecretSource_To_v1alpha1_SecretSource(in, out, s)
+}
+
+func autoConvert_v1alpha1_Taint_To_api_Taint(in *v1alpha1.Taint, out *api.Taint, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Value = in.Value
+	out.Effect = api.TaintEffect(in.Effect)
+	return nil
+}
+
+// Convert_v1alpha1_Taint_To_api_Taint is an autogenerated conversion function.
+func Convert_v1alpha1_Taint_To_api_Taint(in *v1alpha1.Taint, out *api.Taint, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Taint_To_api_Taint(in, out, s)
+}
+
+func autoConvert_api_Taint_To_v1alpha1_Taint(in *api.Taint, out *v1alpha1.Taint, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Value = in.Value
+	out.Effect = v1alpha1.TaintEffect(in.Effect)
+	return nil
+}
+
+// Convert_api_Taint_To_v1alpha1_Taint is an autogenerated conversion function.
+func Convert_api_Taint_To_v1alpha1_Taint(in *api.Taint, out *v1alpha1.Taint, s conversion.Scope) error {
+	return autoConvert_api_Taint_To_v1alpha1_Taint(in, out, s)
+}
+
+func autoConvert_v1alpha1_URLRewrite_To_api_URLRewrite(in *v1alpha1.URLRewrite, out *api.URLRewrite, s conversion.Scope) error {
+	out.Prefix = in.Prefix
+	out.Replacement = in.Replacement
+	return nil
+}
+
+// Convert_v1alpha1_URLRewrite_To_api_URLRewrite is an autogenerated conversion function.
+func Convert_v1alpha1_URLRewrite_To_api_URLRewrite(in *v1alpha1.URLRewrite, out *api.URLRewrite, s conversion.Scope) error {
+	return autoConvert_v1alpha1_URLRewrite_To_api_URLRewrite(in, out, s)
+}
+
+func autoConvert_api_URLRewrite_To_v1alpha1_URLRewrite(in *api.URLRewrite, out *v1alpha1.URLRewrite, s conversion.Scope) error {
+	out.Prefix = in.Prefix
+	out.Replacement = in.Replacement
+	return nil
+}
+
+// Convert_api_URLRewrite_To_v1alpha1_URLRewrite is an autogenerated conversion function.
+func Convert_api_URLRewrite_To_v1alpha1_URLRewrite(in *api.URLRewrite, out *v1alpha1.URLRewrite, s conversion.Scope) error {
+	return autoConvert_api_URLRewrite_To_v1alpha1_URLRewrite(in, out, s)
+}
@@ -87,7 +87,47 @@ type ContainerdOptions struct {
 	// Config is an inline containerd config toml document that can be provided
 	// by the user to override default generated configurations
 	// https://github.com/containerd/containerd/blob/main/docs/man/containerd-config.toml.5.md
-	Config string `json:"config,omitempty"`
+	Config     string               `json:"config,omitempty"`
+	Registries []ContainerdRegistry `json:"registries,omitempty"`
+	Runtimes   []ContainerdRuntime  `json:"runtimes,omitempty"`
+}
+
+type ContainerdRegistry struct {
+	Name    string           `json:"name"`
+	Server  string           `json:"server,omitempty"`
+	TLS     *RegistryTLS     `json:"tls,omitempty"`
+	Mirrors []RegistryMirror `json:"mirrors,omitempty"`
+}
+
+type ContainerdRuntime struct {
+	Name                         string            `json:"name"`
+	RuntimeType                  string            `json:"runtimeType"`
+	BinaryPath                   string            `json:"binaryPath,omitempty"`
+	Options                      map[string]string `json:"options,omitempty"`
+	PrivilegedWithoutHostDevices bool              `json:"privilegedWithoutHostDevices,omitempty"`
+	NodeLabel                    bool              `json:"nodeLabel,omitempty"`
+}
+
+type RegistryMirror struct {
+	URL          string               `json:"url"`
+	Capabilities []RegistryCapability `json:"capabilities,omitempty"`
+	OverridePath bool                 `json:"overridePath,omitempty"`
+	TLS          *RegistryTLS         `json:"tls,omitempty"`
+}
+
+type RegistryCapability string
+
+const (
+	RegistryCapabilityPull    RegistryCapability = "pull"
+	RegistryCapabilityResolve RegistryCapability = "resolve"
+	RegistryCapabilityPush    RegistryCapability = "push"
+)
+
+type RegistryTLS struct {
+	CACert     string `json:"caCert,omitempty"`
+	ClientCert string `json:"clientCert,omitempty"`
+	ClientKey  string `json:"clientKey,omitempty"`
+	SkipVerify bool   `json:"skipVerify,omitempty"`
 }
 
 type IPFamily string
@@ -122,11 +162,86 @@ const (
 )
 
 type HybridOptions struct {
-	EnableCredentialsFile bool              `json:"enableCredentialsFile,omitempty"`
-	IAMRolesAnywhere      *IAMRolesAnywhere `json:"iamRolesAnywhere,omitempty"`
-	SSM                   *SSM              `json:"ssm,omitempty"`
+	EnableCredentialsFile bool               `json:"enableCredentialsFile,omitempty"`
+	IAMRolesAnywhere      *IAMRolesAnywhere  `json:"iamRolesAnywhere,omitempty"`
+	SSM                   *SSM               `json:"ssm,omitempty"`
+	Labels                map[string]string  `json:"labels,omitempty"`
+	Taints                []Taint            `json:"taints,omitempty"`
+	ReservedResources     *ReservedResources `json:"reservedResources,omitempty"`
+	CPUManager            CPUManagerPolicy   `json:"cpuManager,omitempty"`
+	Hugepages             []Hugepages        `json:"hugepages,omitempty"`
+	Swap                  SwapMode           `json:"swap,omitempty"`
+	Artifacts             *ArtifactsOptions  `json:"artifacts,omitempty"`
 }
 
+type ArtifactsOptions struct {
+	ManifestURL               string       `json:"manifestUrl,omitempty"`
+	URLRewrites               []URLRewrite `json:"urlRewrites,omitempty"`
+	TrustedKeys               string       `json:"trustedKeys,omitempty"`
+	SkipSignatureVerification bool         `json:"skipSignatureVerification,omitempty"`
+	MaxDownloadRate           string       `json:"maxDownloadRate,omitempty"`
+}
+
+type URLRewrite struct {
+	Prefix      string `json:"prefix"`
+	Replacement string `json:"replacement"`
+}
+
+type SwapMode string
+
+const (
+	SwapModeDisable SwapMode = "disable"
+	SwapModeLimited SwapMode = "limited"
+)
+
+type Hugepages struct {
+	Size  HugepageSize `json:"size"`
+	Count int32        `json:"count"`
+}
+
+type HugepageSize string
+
+const (
+	HugepageSize2Mi HugepageSize = "2Mi"
+	HugepageSize1Gi HugepageSize = "1Gi"
+)
+
+type CPUManagerPolicy string
+
+const (
+	CPUManagerPolicyNone   CPUManagerPolicy = "none"
+	CPUManagerPolicyStatic CPUManagerPolicy = "static"
+)
+
+type ReservedResources struct {
+	Profile        ReservedResourcesProfile `json:"profile,omitempty"`
+	KubeReserved   map[string]string        `json:"kubeReserved,omitempty"`
+	SystemReserved map[string]string        `json:"systemReserved,omitempty"`
+	EvictionHard   map[string]string        `json:"evictionHard,omitempty"`
+}
+
+type ReservedResourcesProfile string
+
+const (
+	ReservedResourcesProfileEKSDefault    ReservedResourcesProfile = "eks-default"
+	ReservedResourcesProfileHybridDefault ReservedResourcesProfile = "hybrid-default"
+	ReservedResourcesProfileMinimal       ReservedResourcesProfile = "minimal"
+)
+
+type Taint struct {
+	Key    string      `json:"key"`
+	Value  string      `json:"value,omitempty"`
+	Effect TaintEffect `json:"effect"`
+}
+
+type TaintEffect string
+
+const (
+	TaintEffectNoSchedule       TaintEffect = "NoSchedule"
+	TaintEffectPreferNoSchedule TaintEffect = "PreferNoSchedule"
+	TaintEffectNoExecute        TaintEffect = "NoExecute"
+)
+
 func (nc NodeConfig) IsHybridNode() bool {
 	return nc.Spec.Hybrid != nil
 }
@@ -144,6 +259,14 @@ func (nc NodeConfig) IsSSM() bool {
 	return nc.Spec.Hybrid != nil && nc.Spec.Hybrid.SSM != nil
 }
 
+// GetArtifactsOptions returns the artifacts config of hybrid nodes, nil if it's not set.
+func (nc NodeConfig) GetArtifactsOptions() *ArtifactsOptions {
+	if nc.Spec.Hybrid == nil {
+		return nil
+	}
+	return nc.Spec.Hybrid.Artifacts
+}
+
 func (nc NodeConfig) GetNodeType() NodeType {
 	if nc.IsSSM() {
 		return Ssm
@@ -166,6 +289,14 @@ type IAMRolesAnywhere struct {
 }
 
 type SSM struct {
-	ActivationCode string `json:"activationCode,omitempty"`
-	ActivationID   string `json:"activationId,omitempty"`
+	ActivationCode     string        `json:"activationCode,omitempty"`
+	ActivationCodeFrom *SecretSource `json:"activationCodeFrom,omitempty"`
+	ActivationID       string        `json:"activationId,omitempty"`
+}
+
+// SecretSource points to where a sensitive value is read from. Only one of its fields can be set.
+type SecretSource struct {
+	File       string `json:"file,omitempty"`
+	Env        string `json:"env,omitempty"`
+	Credential string `json:"credential,omitempty"`
 }
@@ -40,6 +40,20 @@ func InstallFile(dst string, src io.Reader, perms fs.FileMode) error {
 
 // InstallTarGz untars the src file into the dst directory and deletes the src tgz file
 func InstallTarGz(dst, src string) error {
+	if err := ExtractTarGz(dst, src); err != nil {
+		return err
+	}
+
+	// Remove the tgz file
+	if err := os.Remove(src); err != nil {
+		return errors.Wrap(err, "removing source file")
+	}
+	return nil
+}
+
+// ExtractTarGz untars the src file into the dst directory. Entries with paths out of dst
+// are rejected.
+func ExtractTarGz(dst, src string) error {
 	if err := os.MkdirAll(dst, DefaultDirPerms); err != nil {
 		return err
 	}
@@ -89,11 +103,6 @@ func InstallTarGz(dst, src string) error {
 			return errors.Wrap(err, "copying file contents")
 		}
 	}
-
-	// Remove the tgz file
-	if err := os.Remove(src); err != nil {
-		return errors.Wrap(err, "removing source file")
-	}
 	return nil
 }
 
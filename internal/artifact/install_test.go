@@ -326,3 +326,20 @@ func TestInstallTarGz(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractTarGzKeepsSource(t *testing.T) {
+	g := NewWithT(t)
+	tmp := t.TempDir()
+	src := filepath.Join(tmp, "archive.tar.gz")
+	dst := filepath.Join(tmp, "extracted")
+	g.Expect(os.WriteFile(src, tarGzBytes(t, map[string]struct {
+		content string
+		mode    int64
+	}{
+		"dir/file.txt": {"content", 0o644},
+	}), 0o644)).To(Succeed())
+
+	g.Expect(artifact.ExtractTarGz(dst, src)).To(Succeed())
+	g.Expect(filepath.Join(dst, "dir", "file.txt")).To(BeARegularFile())
+	g.Expect(src).To(BeARegularFile())
+}
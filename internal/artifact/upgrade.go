@@ -29,24 +29,23 @@ func checksumMatch(installedArtifactPath string, src Source) (bool, error) {
 }
 
 // Upgrade upgrades an artifact from the source only if the expected checksum doesn't match with the
-// checksum of artifact already installed.
-func Upgrade(artifactName, path string, source Source, perms fs.FileMode, log *zap.Logger) error {
+// checksum of artifact already installed. It returns whether the artifact was replaced.
+func Upgrade(artifactName, path string, source Source, perms fs.FileMode, log *zap.Logger) (bool, error) {
 	match, err := checksumMatch(path, source)
 	if err != nil {
-		return err
+		return false, err
 	}
-
-	if !match {
-		if err := InstallFile(path, source, perms); err != nil {
-			return errors.Wrapf(err, "installing %s", artifactName)
-		}
-
-		if !source.VerifyChecksum() {
-			return errors.Wrapf(NewChecksumError(source), "verifying checksum post installing %s", artifactName)
-		}
-		log.Info("Upgraded", zap.String("artifact", artifactName))
-	} else {
+	if match {
 		log.Info(fmt.Sprintf("No new version found for artifact %s. Skipping upgrade.", artifactName))
+		return false, nil
+	}
+
+	if err := InstallFile(path, source, perms); err != nil {
+		return false, errors.Wrapf(err, "installing %s", artifactName)
+	}
+	if !source.VerifyChecksum() {
+		return false, errors.Wrapf(NewChecksumError(source), "verifying checksum post installing %s", artifactName)
 	}
-	return nil
+	log.Info("Upgraded", zap.String("artifact", artifactName))
+	return true, nil
 }
@@ -80,6 +80,7 @@ func TestUpgrade(t *testing.T) {
 		installedData  string
 		upgradedData   string
 		sourceChecksum []byte
+		wantReplaced   bool
 	}{
 		{
 			name:           "Same file, nothing to upgrade",
@@ -92,6 +93,7 @@ func TestUpgrade(t *testing.T) {
 			installedData:  installedFileData,
 			upgradedData:   upgradedFileData,
 			sourceChecksum: upgradedChecksum,
+			wantReplaced:   true,
 		},
 	}
 
@@ -106,8 +108,9 @@ func TestUpgrade(t *testing.T) {
 			source, err := WithChecksum(io.NopCloser(bytes.NewBufferString(tt.upgradedData)), sha256.New(), tt.sourceChecksum)
 			g.Expect(err).To(BeNil())
 
-			err = Upgrade("dummyArtifact", artifact.Name(), source, 0o755, zap.NewNop())
+			replaced, err := Upgrade("dummyArtifact", artifact.Name(), source, 0o755, zap.NewNop())
 			g.Expect(err).To(BeNil())
+			g.Expect(replaced).To(Equal(tt.wantReplaced))
 
 			// Check upgraded written data
 			fileData, err := os.ReadFile(artifact.Name())
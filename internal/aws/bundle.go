@@ -0,0 +1,125 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// BundleManifestFile is the name of the release manifest in an offline bundle.
+const BundleManifestFile = "manifest.yaml"
+
+// GetBundleManifest returns the subset of the release manifest an offline bundle needs to
+// install eksVersion on linux and arch: the matching EKS patch release, the latest IAM Roles
+// Anywhere release when withIamRolesAnywhere is set, and the config of every region.
+func GetBundleManifest(ctx context.Context, eksVersion, arch string, withIamRolesAnywhere bool, opts ...SourceOption) (*Manifest, error) {
+	o := newSourceOptions(opts)
+	verifier, err := o.signatureVerifier()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := getReleaseManifest(ctx, o, verifier)
+	if err != nil {
+		return nil, err
+	}
+	return bundleManifest(manifest, eksVersion, arch, withIamRolesAnywhere)
+}
+
+func bundleManifest(manifest *Manifest, eksVersion, arch string, withIamRolesAnywhere bool) (*Manifest, error) {
+	eksPatchRelease, err := getLatestEksSource(eksVersion, manifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting latest eks release")
+	}
+	eksPatchRelease.Artifacts = filterArtifacts(eksPatchRelease.Artifacts, arch)
+	var majorMinorVersion string
+	for _, release := range manifest.SupportedEksReleases {
+		if slices.ContainsFunc(release.PatchReleases, func(r EksPatchRelease) bool { return r.Version == eksPatchRelease.Version }) {
+			majorMinorVersion = release.MajorMinorVersion
+		}
+	}
+
+	bundle := &Manifest{
+		SupportedEksReleases: []SupportedEksRelease{
+			{
+				MajorMinorVersion:  majorMinorVersion,
+				LatestPatchVersion: eksPatchRelease.PatchVersion,
+				PatchReleases:      []EksPatchRelease{eksPatchRelease},
+			},
+		},
+		RegionConfig: manifest.RegionConfig,
+	}
+	if withIamRolesAnywhere {
+		iamRolesAnywhereRelease, err := getLatestIamRolesAnywhereSource(manifest)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting iam roles anywhere release")
+		}
+		iamRolesAnywhereRelease.Artifacts = filterArtifacts(iamRolesAnywhereRelease.Artifacts, arch)
+		bundle.IamRolesAnywhereReleases = []IamRolesAnywhereRelease{iamRolesAnywhereRelease}
+	}
+	return bundle, nil
+}
+
+func filterArtifacts(artifacts []Artifact, arch string) []Artifact {
+	var filtered []Artifact
+	for _, artifact := range artifacts {
+		if artifact.Arch == arch && artifact.OS == "linux" {
+			filtered = append(filtered, artifact)
+		}
+	}
+	return filtered
+}
+
+// GetBundleSource gets the source for the aws provided artifacts in an offline bundle
+// extracted to dir. The artifact URIs in the bundle manifest are paths relative to dir.
+// The bundle only holds IAM Roles Anywhere artifacts if it was created for them. The
+// bundle manifest is not signed, but the signatures of the artifact checksums are still
+// verified, with the keys from opts, unless signature verification is skipped.
+func GetBundleSource(dir, eksVersion, region string, opts ...SourceOption) (Source, error) {
+	verifier, err := newSourceOptions(opts).signatureVerifier()
+	if err != nil {
+		return Source{}, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, BundleManifestFile))
+	if err != nil {
+		return Source{}, errors.Wrap(err, "reading bundle manifest")
+	}
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return Source{}, errors.Wrap(err, "invalid yaml data in bundle manifest")
+	}
+
+	eksPatchRelease, err := getLatestEksSource(eksVersion, &manifest)
+	if err != nil {
+		return Source{}, errors.Wrap(err, "getting eks release from bundle")
+	}
+	regionCfg, ok := manifest.RegionConfig[region]
+	if !ok {
+		return Source{}, fmt.Errorf("region %s not found in bundle manifest", region)
+	}
+	source := Source{
+		Eks:        eksPatchRelease,
+		RegionInfo: regionCfg,
+		Verifier:   verifier,
+		open:       bundleFileOpener(dir),
+	}
+	if len(manifest.IamRolesAnywhereReleases) > 0 {
+		source.Iam = manifest.IamRolesAnywhereReleases[0]
+	}
+	return source, nil
+}
+
+// bundleFileOpener opens the files under dir, without following paths out of it.
+func bundleFileOpener(dir string) openFunc {
+	return func(_ context.Context, uri string) (io.ReadCloser, error) {
+		if !filepath.IsLocal(uri) {
+			return nil, fmt.Errorf("bundle file %s is not a local path", uri)
+		}
+		return os.Open(filepath.Join(dir, uri))
+	}
+}
@@ -0,0 +1,150 @@
+package aws
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/internal/signature"
+)
+
+func testBundleManifest() *Manifest {
+	return &Manifest{
+		SupportedEksReleases: []SupportedEksRelease{
+			{
+				MajorMinorVersion:  "1.31",
+				LatestPatchVersion: "2",
+				PatchReleases: []EksPatchRelease{
+					{
+						Version:      "1.31.1",
+						PatchVersion: "1",
+						ReleaseDate:  "2024-11-01",
+						Artifacts: []Artifact{
+							{Name: "kubelet", Arch: "amd64", OS: "linux", URI: "https://example.com/1.31.1/amd64/kubelet"},
+						},
+					},
+					{
+						Version:      "1.31.2",
+						PatchVersion: "2",
+						ReleaseDate:  "2024-12-01",
+						Artifacts: []Artifact{
+							{Name: "kubelet", Arch: "amd64", OS: "linux", URI: "https://example.com/1.31.2/amd64/kubelet"},
+							{Name: "kubelet", Arch: "arm64", OS: "linux", URI: "https://example.com/1.31.2/arm64/kubelet"},
+						},
+					},
+				},
+			},
+			{
+				MajorMinorVersion:  "1.30",
+				LatestPatchVersion: "1",
+				PatchReleases: []EksPatchRelease{
+					{Version: "1.30.1", PatchVersion: "1", ReleaseDate: "2024-12-01"},
+				},
+			},
+		},
+		IamRolesAnywhereReleases: []IamRolesAnywhereRelease{
+			{
+				Version: "v1.1.0",
+				Artifacts: []Artifact{
+					{Name: "aws_signing_helper", Arch: "amd64", OS: "linux", URI: "https://example.com/v1.1.0/amd64/aws_signing_helper"},
+				},
+			},
+			{
+				Version: "v1.2.0",
+				Artifacts: []Artifact{
+					{Name: "aws_signing_helper", Arch: "amd64", OS: "linux", URI: "https://example.com/v1.2.0/amd64/aws_signing_helper"},
+					{Name: "aws_signing_helper", Arch: "arm64", OS: "linux", URI: "https://example.com/v1.2.0/arm64/aws_signing_helper"},
+				},
+			},
+		},
+		RegionConfig: RegionConfig{
+			"us-west-2": {EcrAccountID: "123456789012", CredProviders: map[string]bool{"iam-ra": true}},
+		},
+	}
+}
+
+func TestBundleManifest(t *testing.T) {
+	g := NewWithT(t)
+	bundle, err := bundleManifest(testBundleManifest(), "1.31", "amd64", true)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(bundle).To(Equal(&Manifest{
+		SupportedEksReleases: []SupportedEksRelease{
+			{
+				MajorMinorVersion:  "1.31",
+				LatestPatchVersion: "2",
+				PatchReleases: []EksPatchRelease{
+					{
+						Version:      "1.31.2",
+						PatchVersion: "2",
+						ReleaseDate:  "2024-12-01",
+						Artifacts: []Artifact{
+							{Name: "kubelet", Arch: "amd64", OS: "linux", URI: "https://example.com/1.31.2/amd64/kubelet"},
+						},
+					},
+				},
+			},
+		},
+		IamRolesAnywhereReleases: []IamRolesAnywhereRelease{
+			{
+				Version: "v1.2.0",
+				Artifacts: []Artifact{
+					{Name: "aws_signing_helper", Arch: "amd64", OS: "linux", URI: "https://example.com/v1.2.0/amd64/aws_signing_helper"},
+				},
+			},
+		},
+		RegionConfig: testBundleManifest().RegionConfig,
+	}))
+}
+
+func TestBundleManifestPatchVersion(t *testing.T) {
+	g := NewWithT(t)
+	bundle, err := bundleManifest(testBundleManifest(), "1.31.1", "amd64", false)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(bundle.SupportedEksReleases).To(HaveLen(1))
+	g.Expect(bundle.SupportedEksReleases[0].LatestPatchVersion).To(Equal("1"))
+	g.Expect(bundle.SupportedEksReleases[0].PatchReleases[0].Version).To(Equal("1.31.1"))
+	g.Expect(bundle.IamRolesAnywhereReleases).To(BeEmpty())
+}
+
+func TestGetBundleSource(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	manifest := testBundleManifest()
+	manifest.SupportedEksReleases[0].PatchReleases[1].Artifacts = []Artifact{
+		{Name: "kubelet", Arch: "amd64", OS: "linux", URI: "artifacts/kubelet/kubelet"},
+	}
+	data, err := yaml.Marshal(manifest)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(os.WriteFile(filepath.Join(dir, BundleManifestFile), data, 0o644)).To(Succeed())
+	g.Expect(os.MkdirAll(filepath.Join(dir, "artifacts", "kubelet"), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "artifacts", "kubelet", "kubelet"), []byte("kubelet"), 0o755)).To(Succeed())
+
+	_, err = GetBundleSource(dir, "1.31", "us-west-2")
+	g.Expect(err).To(MatchError(signature.ErrNoTrustedKeys))
+
+	verifier, err := signature.NewVerifier(newTestSigningKey(g).publicKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	source, err := GetBundleSource(dir, "1.31", "us-west-2", WithVerifier(verifier))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(source.Eks.Version).To(Equal("1.31.2"))
+	g.Expect(source.Iam.Version).To(Equal("v1.1.0"))
+	g.Expect(source.RegionInfo.EcrAccountID).To(Equal("123456789012"))
+
+	file, err := source.openFile(context.Background(), "artifacts/kubelet/kubelet")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("kubelet"))
+
+	_, err = source.openFile(context.Background(), "../manifest.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("is not a local path")))
+
+	_, err = GetBundleSource(dir, "1.31", "eu-west-1", WithVerifier(verifier))
+	g.Expect(err).To(MatchError("region eu-west-1 not found in bundle manifest"))
+}
@@ -6,12 +6,16 @@ import (
 	"github.com/pkg/errors"
 	"sigs.k8s.io/yaml"
 
+	"github.com/aws/eks-hybrid/internal/signature"
 	"github.com/aws/eks-hybrid/internal/util"
 )
 
 // set build time
 var manifestUrl string
 
+// SignatureSuffix is appended to the release manifest URL to get its detached signature.
+const SignatureSuffix = ".sig"
+
 type Manifest struct {
 	SupportedEksReleases     []SupportedEksRelease     `json:"supported_eks_releases"`
 	IamRolesAnywhereReleases []IamRolesAnywhereRelease `json:"iam_roles_anywhere_releases"`
@@ -58,18 +62,33 @@ type Artifact struct {
 	URI         string `json:"uri"`
 	ChecksumURI string `json:"checksum_uri"`
 	GzipURI     string `json:"gzip_uri"`
+	// SignatureURI is the detached PGP signature of the checksum file, which in turn
+	// covers the artifact.
+	SignatureURI string `json:"signature_uri"`
 }
 
-// Read from the manifest file on s3 and parse into Manifest struct
-func getReleaseManifest(ctx context.Context) (*Manifest, error) {
-	yamlFileData, err := util.GetHttpFile(ctx, manifestUrl)
+// Read from the manifest file on s3 and parse into Manifest struct. The manifest signature
+// is verified with verifier, unless it's nil.
+func getReleaseManifest(ctx context.Context, opts sourceOptions, verifier *signature.Verifier) (*Manifest, error) {
+	manifestURL := RewriteURL(opts.manifestURL, opts.urlRewrites)
+	yamlFileData, err := util.GetHttpFile(ctx, manifestURL)
 	if err != nil {
 		return nil, err
 	}
+	if verifier != nil {
+		manifestSignature, err := util.GetHttpFile(ctx, manifestURL+SignatureSuffix)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting release manifest signature")
+		}
+		if err := verifier.Verify(yamlFileData, manifestSignature); err != nil {
+			return nil, errors.Wrap(err, "verifying release manifest signature")
+		}
+	}
 	var manifest Manifest
 	err = yaml.Unmarshal(yamlFileData, &manifest)
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid yaml data in release manifest")
 	}
+	manifest.rewriteArtifactURLs(opts.urlRewrites)
 	return &manifest, nil
 }
@@ -0,0 +1,309 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/cache"
+	"github.com/aws/eks-hybrid/internal/signature"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+const (
+	// ManifestURLEnv is the environment variable with the release manifest URL.
+	ManifestURLEnv = "NODEADM_MANIFEST_URL"
+
+	// ArtifactURLRewritesEnv is the environment variable with comma separated URL
+	// rewrites, each formatted as prefix=replacement.
+	ArtifactURLRewritesEnv = "NODEADM_ARTIFACT_URL_REWRITES"
+)
+
+// URLRewrite replaces the Prefix of the manifest and artifact URLs with Replacement,
+// to download them from a mirror.
+type URLRewrite struct {
+	Prefix      string
+	Replacement string
+}
+
+// SourceOption configures where the release manifest and artifacts are downloaded from.
+type SourceOption func(*sourceOptions)
+
+type sourceOptions struct {
+	manifestURL string
+	urlRewrites []URLRewrite
+	cache       *cache.Cache
+	verifier    *signature.Verifier
+	skipVerify  bool
+}
+
+// WithManifestURL downloads the release manifest from url instead of the URL nodeadm
+// was built with.
+func WithManifestURL(url string) SourceOption {
+	return func(o *sourceOptions) {
+		o.manifestURL = url
+	}
+}
+
+// WithURLRewrites rewrites the manifest and artifact URLs with the first rewrite
+// with a matching prefix.
+func WithURLRewrites(rewrites []URLRewrite) SourceOption {
+	return func(o *sourceOptions) {
+		o.urlRewrites = rewrites
+	}
+}
+
+// WithCache reuses the artifacts in c with the checksum from the manifest, and adds the
+// downloaded ones to it.
+func WithCache(c *cache.Cache) SourceOption {
+	return func(o *sourceOptions) {
+		o.cache = c
+	}
+}
+
+// WithVerifier verifies the signatures of the release manifest and artifacts with
+// verifier instead of the keys nodeadm was built with.
+func WithVerifier(verifier *signature.Verifier) SourceOption {
+	return func(o *sourceOptions) {
+		o.verifier = verifier
+	}
+}
+
+// WithoutSignatureVerification doesn't verify the signatures of the release manifest and
+// artifacts, for manifests that aren't signed. The checksums are still verified.
+func WithoutSignatureVerification() SourceOption {
+	return func(o *sourceOptions) {
+		o.skipVerify = true
+	}
+}
+
+func newSourceOptions(opts []SourceOption) sourceOptions {
+	o := sourceOptions{manifestURL: manifestUrl}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// SignatureVerifier returns the verifier for the manifest and artifact signatures with opts,
+// which is nil when the signatures aren't verified.
+func SignatureVerifier(opts ...SourceOption) (*signature.Verifier, error) {
+	return newSourceOptions(opts).signatureVerifier()
+}
+
+// signatureVerifier returns the verifier for the manifest and artifact signatures, trusting
+// the keys nodeadm was built with unless set with WithVerifier. It returns nil only when
+// verification is skipped with WithoutSignatureVerification, and fails when there are no
+// keys to trust.
+func (o sourceOptions) signatureVerifier() (*signature.Verifier, error) {
+	if o.skipVerify {
+		return nil, nil
+	}
+	if o.verifier != nil {
+		return o.verifier, nil
+	}
+	keys, err := signature.DefaultKeys()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := signature.NewVerifier(keys)
+	if errors.Is(err, signature.ErrNoTrustedKeys) {
+		return nil, fmt.Errorf("%w: nodeadm was built without release signing keys, set trusted keys or skip signature verification", err)
+	}
+	return verifier, err
+}
+
+// RewriteURL applies the first rewrite with a prefix matching uri.
+func RewriteURL(uri string, rewrites []URLRewrite) string {
+	for _, rewrite := range rewrites {
+		if rest, ok := strings.CutPrefix(uri, rewrite.Prefix); ok {
+			return rewrite.Replacement + rest
+		}
+	}
+	return uri
+}
+
+// rewriteArtifactURLs rewrites the URLs of every artifact in the manifest.
+func (m *Manifest) rewriteArtifactURLs(rewrites []URLRewrite) {
+	if len(rewrites) == 0 {
+		return
+	}
+	rewriteArtifacts := func(artifacts []Artifact) {
+		for i := range artifacts {
+			artifacts[i].URI = rewriteOptionalURL(artifacts[i].URI, rewrites)
+			artifacts[i].ChecksumURI = rewriteOptionalURL(artifacts[i].ChecksumURI, rewrites)
+			artifacts[i].GzipURI = rewriteOptionalURL(artifacts[i].GzipURI, rewrites)
+			artifacts[i].SignatureURI = rewriteOptionalURL(artifacts[i].SignatureURI, rewrites)
+		}
+	}
+	for _, release := range m.SupportedEksReleases {
+		for _, patchRelease := range release.PatchReleases {
+			rewriteArtifacts(patchRelease.Artifacts)
+		}
+	}
+	for _, release := range m.IamRolesAnywhereReleases {
+		rewriteArtifacts(release.Artifacts)
+	}
+	for _, release := range m.SsmReleases {
+		rewriteArtifacts(release.Artifacts)
+	}
+}
+
+func rewriteOptionalURL(uri string, rewrites []URLRewrite) string {
+	if uri == "" {
+		return ""
+	}
+	return RewriteURL(uri, rewrites)
+}
+
+// ParseURLRewrite parses a rewrite formatted as prefix=replacement.
+func ParseURLRewrite(value string) (URLRewrite, error) {
+	prefix, replacement, ok := strings.Cut(value, "=")
+	if !ok || prefix == "" || replacement == "" {
+		return URLRewrite{}, fmt.Errorf("invalid artifact url rewrite %q, must be formatted as prefix=replacement", value)
+	}
+	return URLRewrite{Prefix: prefix, Replacement: replacement}, nil
+}
+
+// MirrorOptions returns the source options for the manifest URL, URL rewrites and
+// trusted signing keys. Each is taken from the flag values when set, then from the
+// environment, then from cfg, which can be nil. Signature verification is skipped when
+// cfg sets SkipSignatureVerification.
+func MirrorOptions(manifestURL string, urlRewrites []string, trustedKeysFile string, cfg *api.ArtifactsOptions) ([]SourceOption, error) {
+	var opts []SourceOption
+	var configKeys string
+	if cfg != nil {
+		configKeys = cfg.TrustedKeys
+	}
+	trustedKeys, err := signature.OverrideKeys(trustedKeysFile, configKeys)
+	if err != nil {
+		return nil, err
+	}
+	if trustedKeys != "" {
+		verifier, err := signature.NewVerifier(trustedKeys)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithVerifier(verifier))
+	}
+	if cfg != nil && cfg.SkipSignatureVerification {
+		opts = append(opts, WithoutSignatureVerification())
+	}
+
+	if manifestURL == "" {
+		manifestURL = os.Getenv(ManifestURLEnv)
+	}
+	if manifestURL == "" && cfg != nil {
+		manifestURL = cfg.ManifestURL
+	}
+	if manifestURL != "" {
+		if err := validateMirrorURL(manifestURL); err != nil {
+			return nil, fmt.Errorf("invalid manifest url: %w", err)
+		}
+		opts = append(opts, WithManifestURL(manifestURL))
+	}
+
+	if len(urlRewrites) == 0 {
+		if env := os.Getenv(ArtifactURLRewritesEnv); env != "" {
+			urlRewrites = strings.Split(env, ",")
+		}
+	}
+	var rewrites []URLRewrite
+	if len(urlRewrites) > 0 {
+		for _, value := range urlRewrites {
+			rewrite, err := ParseURLRewrite(value)
+			if err != nil {
+				return nil, err
+			}
+			rewrites = append(rewrites, rewrite)
+		}
+	} else if cfg != nil {
+		for _, rewrite := range cfg.URLRewrites {
+			rewrites = append(rewrites, URLRewrite{Prefix: rewrite.Prefix, Replacement: rewrite.Replacement})
+		}
+	}
+	for _, rewrite := range rewrites {
+		if err := validateMirrorURL(rewrite.Replacement); err != nil {
+			return nil, fmt.Errorf("invalid artifact url rewrite replacement: %w", err)
+		}
+	}
+	if len(rewrites) > 0 {
+		opts = append(opts, WithURLRewrites(rewrites))
+	}
+	return opts, nil
+}
+
+// MaxDownloadRate returns the bytes per second the downloads are capped at, parsed from
+// the flag value when set, then from cfg, which can be nil. Zero doesn't limit them.
+func MaxDownloadRate(maxDownloadRate string, cfg *api.ArtifactsOptions) (int64, error) {
+	if maxDownloadRate == "" && cfg != nil {
+		maxDownloadRate = cfg.MaxDownloadRate
+	}
+	if maxDownloadRate == "" {
+		return 0, nil
+	}
+	return parseDownloadRate(maxDownloadRate)
+}
+
+func parseDownloadRate(value string) (int64, error) {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid max download rate %s: %w", value, err)
+	}
+	if quantity.Value() <= 0 {
+		return 0, fmt.Errorf("invalid max download rate %s: must be positive", value)
+	}
+	return quantity.Value(), nil
+}
+
+func validateMirrorURL(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("%q must be an http or https url", value)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("%q must have a host", value)
+	}
+	return nil
+}
+
+// ValidateArtifactsOptions checks the artifacts config, reporting each problem
+// as a [validation.FieldError] under path.
+func ValidateArtifactsOptions(cfg *api.ArtifactsOptions, path string) []error {
+	if cfg == nil {
+		return nil
+	}
+	var errs []error
+	if cfg.ManifestURL != "" {
+		if err := validateMirrorURL(cfg.ManifestURL); err != nil {
+			errs = append(errs, validation.NewFieldError(path+".manifestUrl", fmt.Sprintf("invalid manifest url: %s", err)))
+		}
+	}
+	if cfg.TrustedKeys != "" {
+		if _, err := signature.NewVerifier(cfg.TrustedKeys); err != nil {
+			errs = append(errs, validation.NewFieldError(path+".trustedKeys", fmt.Sprintf("invalid trusted keys: %s", err)))
+		}
+	}
+	if cfg.MaxDownloadRate != "" {
+		if _, err := parseDownloadRate(cfg.MaxDownloadRate); err != nil {
+			errs = append(errs, validation.NewFieldError(path+".maxDownloadRate", err.Error()))
+		}
+	}
+	for i, rewrite := range cfg.URLRewrites {
+		if rewrite.Prefix == "" {
+			errs = append(errs, validation.NewFieldError(path+".urlRewrites", fmt.Sprintf("prefix is missing for rewrite %d", i)))
+		}
+		if err := validateMirrorURL(rewrite.Replacement); err != nil {
+			errs = append(errs, validation.NewFieldError(path+".urlRewrites", fmt.Sprintf("invalid replacement for rewrite %d: %s", i, err)))
+		}
+	}
+	return errs
+}
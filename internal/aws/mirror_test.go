@@ -0,0 +1,255 @@
+package aws
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/signature"
+)
+
+func TestGetLatestSourceFromMirror(t *testing.T) {
+	g := NewWithT(t)
+	key := newTestSigningKey(g)
+	release := newTestRelease(g, key, "/mirror")
+	defer release.server.Close()
+
+	source, err := GetLatestSource(context.Background(), "1.31", "us-west-2",
+		WithManifestURL(release.server.URL+"/mirror/manifest.yaml"),
+		WithURLRewrites([]URLRewrite{{Prefix: "https://assets.example.com/", Replacement: release.server.URL + "/mirror/"}}),
+		WithVerifier(key.verifier(g)),
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(source.RewriteURL("https://assets.example.com/ssm-setup-cli")).To(Equal(release.server.URL + "/mirror/ssm-setup-cli"))
+
+	kubeletSource, err := source.GetKubelet(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	defer kubeletSource.Close()
+	content, err := io.ReadAll(kubeletSource)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(content).To(Equal(release.kubelet))
+	g.Expect(kubeletSource.VerifyChecksum()).To(BeTrue())
+}
+
+func TestRewriteURL(t *testing.T) {
+	g := NewWithT(t)
+	rewrites := []URLRewrite{
+		{Prefix: "https://hybrid-assets.eks.amazonaws.com/", Replacement: "https://artifactory.example.com/eks-hybrid/"},
+		{Prefix: "https://hybrid-assets.eks.amazonaws.com/releases/", Replacement: "https://unused.example.com/"},
+		{Prefix: "https://rolesanywhere.amazonaws.com/", Replacement: "https://artifactory.example.com/rolesanywhere/"},
+	}
+	g.Expect(RewriteURL("https://hybrid-assets.eks.amazonaws.com/releases/kubelet", rewrites)).To(Equal("https://artifactory.example.com/eks-hybrid/releases/kubelet"))
+	g.Expect(RewriteURL("https://rolesanywhere.amazonaws.com/releases/aws_signing_helper", rewrites)).To(Equal("https://artifactory.example.com/rolesanywhere/releases/aws_signing_helper"))
+	g.Expect(RewriteURL("https://example.com/kubelet", rewrites)).To(Equal("https://example.com/kubelet"))
+}
+
+func TestManifestRewriteArtifactURLs(t *testing.T) {
+	g := NewWithT(t)
+	manifest := &Manifest{
+		SupportedEksReleases: []SupportedEksRelease{
+			{
+				PatchReleases: []EksPatchRelease{
+					{
+						Artifacts: []Artifact{
+							{
+								Name:         "kubectl",
+								URI:          "https://assets.example.com/kubectl",
+								ChecksumURI:  "https://assets.example.com/kubectl.sha256",
+								GzipURI:      "https://assets.example.com/kubectl.gz",
+								SignatureURI: "https://assets.example.com/kubectl.sha256.sig",
+							},
+							{Name: "kubelet", URI: "https://assets.example.com/kubelet"},
+						},
+					},
+				},
+			},
+		},
+		IamRolesAnywhereReleases: []IamRolesAnywhereRelease{
+			{Artifacts: []Artifact{{Name: "aws_signing_helper", URI: "https://assets.example.com/aws_signing_helper"}}},
+		},
+	}
+	manifest.rewriteArtifactURLs([]URLRewrite{{Prefix: "https://assets.example.com/", Replacement: "https://mirror.example.com/"}})
+	g.Expect(manifest.SupportedEksReleases[0].PatchReleases[0].Artifacts).To(Equal([]Artifact{
+		{
+			Name:         "kubectl",
+			URI:          "https://mirror.example.com/kubectl",
+			ChecksumURI:  "https://mirror.example.com/kubectl.sha256",
+			GzipURI:      "https://mirror.example.com/kubectl.gz",
+			SignatureURI: "https://mirror.example.com/kubectl.sha256.sig",
+		},
+		{Name: "kubelet", URI: "https://mirror.example.com/kubelet"},
+	}))
+	g.Expect(manifest.IamRolesAnywhereReleases[0].Artifacts[0].URI).To(Equal("https://mirror.example.com/aws_signing_helper"))
+}
+
+func TestMirrorOptions(t *testing.T) {
+	cfg := &api.ArtifactsOptions{
+		ManifestURL: "https://config.example.com/manifest.yaml",
+		URLRewrites: []api.URLRewrite{{Prefix: "https://assets.example.com/", Replacement: "https://config.example.com/"}},
+	}
+	testCases := []struct {
+		name            string
+		manifestURL     string
+		urlRewrites     []string
+		env             map[string]string
+		cfg             *api.ArtifactsOptions
+		wantManifestURL string
+		wantRewrites    []URLRewrite
+		wantErr         string
+	}{
+		{
+			name:            "defaults",
+			wantManifestURL: manifestUrl,
+		},
+		{
+			name:            "node config",
+			cfg:             cfg,
+			wantManifestURL: "https://config.example.com/manifest.yaml",
+			wantRewrites:    []URLRewrite{{Prefix: "https://assets.example.com/", Replacement: "https://config.example.com/"}},
+		},
+		{
+			name: "environment over node config",
+			env: map[string]string{
+				ManifestURLEnv:         "https://env.example.com/manifest.yaml",
+				ArtifactURLRewritesEnv: "https://assets.example.com/=https://env.example.com/,https://other.example.com/=https://env.example.com/other/",
+			},
+			cfg:             cfg,
+			wantManifestURL: "https://env.example.com/manifest.yaml",
+			wantRewrites: []URLRewrite{
+				{Prefix: "https://assets.example.com/", Replacement: "https://env.example.com/"},
+				{Prefix: "https://other.example.com/", Replacement: "https://env.example.com/other/"},
+			},
+		},
+		{
+			name:        "flags over environment",
+			manifestURL: "https://flag.example.com/manifest.yaml",
+			urlRewrites: []string{"https://assets.example.com/=https://flag.example.com/"},
+			env: map[string]string{
+				ManifestURLEnv:         "https://env.example.com/manifest.yaml",
+				ArtifactURLRewritesEnv: "https://assets.example.com/=https://env.example.com/",
+			},
+			cfg:             cfg,
+			wantManifestURL: "https://flag.example.com/manifest.yaml",
+			wantRewrites:    []URLRewrite{{Prefix: "https://assets.example.com/", Replacement: "https://flag.example.com/"}},
+		},
+		{
+			name:        "invalid rewrite",
+			urlRewrites: []string{"https://assets.example.com/"},
+			wantErr:     `invalid artifact url rewrite "https://assets.example.com/", must be formatted as prefix=replacement`,
+		},
+		{
+			name:        "invalid manifest url",
+			manifestURL: "file:///tmp/manifest.yaml",
+			wantErr:     `invalid manifest url: "file:///tmp/manifest.yaml" must be an http or https url`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Setenv(ManifestURLEnv, tc.env[ManifestURLEnv])
+			t.Setenv(ArtifactURLRewritesEnv, tc.env[ArtifactURLRewritesEnv])
+			opts, err := MirrorOptions(tc.manifestURL, tc.urlRewrites, "", tc.cfg)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(tc.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			o := newSourceOptions(opts)
+			g.Expect(o.manifestURL).To(Equal(tc.wantManifestURL))
+			g.Expect(o.urlRewrites).To(Equal(tc.wantRewrites))
+		})
+	}
+}
+
+func TestMirrorOptionsTrustedKeys(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(signature.TrustedKeysEnv, "")
+	key := newTestSigningKey(g)
+	data := []byte("manifest")
+
+	opts, err := MirrorOptions("", nil, "", nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newSourceOptions(opts).verifier).To(BeNil())
+
+	opts, err = MirrorOptions("", nil, "", &api.ArtifactsOptions{TrustedKeys: key.publicKey})
+	g.Expect(err).NotTo(HaveOccurred())
+	verifier, err := SignatureVerifier(opts...)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(verifier.Verify(data, key.sign(g, data))).To(Succeed())
+
+	keysFile := filepath.Join(t.TempDir(), "keys.asc")
+	otherKey := newTestSigningKey(g)
+	g.Expect(os.WriteFile(keysFile, []byte(otherKey.publicKey), 0o644)).To(Succeed())
+	opts, err = MirrorOptions("", nil, keysFile, &api.ArtifactsOptions{TrustedKeys: key.publicKey})
+	g.Expect(err).NotTo(HaveOccurred())
+	verifier, err = SignatureVerifier(opts...)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(verifier.Verify(data, otherKey.sign(g, data))).To(Succeed())
+	g.Expect(verifier.Verify(data, key.sign(g, data))).NotTo(Succeed())
+
+	_, err = MirrorOptions("", nil, "", &api.ArtifactsOptions{TrustedKeys: "not a key"})
+	g.Expect(err).To(MatchError(signature.ErrNoTrustedKeys))
+
+	opts, err = MirrorOptions("", nil, "", nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = SignatureVerifier(opts...)
+	g.Expect(err).To(MatchError(signature.ErrNoTrustedKeys))
+
+	opts, err = MirrorOptions("", nil, "", &api.ArtifactsOptions{SkipSignatureVerification: true})
+	g.Expect(err).NotTo(HaveOccurred())
+	verifier, err = SignatureVerifier(opts...)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(verifier).To(BeNil())
+}
+
+func TestMaxDownloadRate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		flag      string
+		cfg       *api.ArtifactsOptions
+		want      int64
+		wantError string
+	}{
+		{
+			name: "unlimited",
+		},
+		{
+			name: "from config",
+			cfg:  &api.ArtifactsOptions{MaxDownloadRate: "2Mi"},
+			want: 2 << 20,
+		},
+		{
+			name: "flag over config",
+			flag: "500k",
+			cfg:  &api.ArtifactsOptions{MaxDownloadRate: "2Mi"},
+			want: 500000,
+		},
+		{
+			name:      "invalid quantity",
+			flag:      "fast",
+			wantError: "invalid max download rate fast",
+		},
+		{
+			name:      "not positive",
+			cfg:       &api.ArtifactsOptions{MaxDownloadRate: "0"},
+			wantError: "invalid max download rate 0: must be positive",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			rate, err := MaxDownloadRate(tc.flag, tc.cfg)
+			if tc.wantError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantError)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(rate).To(Equal(tc.want))
+		})
+	}
+}
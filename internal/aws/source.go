@@ -3,15 +3,21 @@ package aws
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"runtime"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 	"golang.org/x/mod/semver"
 
 	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/cache"
+	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/signature"
 	"github.com/aws/eks-hybrid/internal/util"
 )
 
@@ -20,15 +26,49 @@ type Source struct {
 	Eks        EksPatchRelease
 	Iam        IamRolesAnywhereRelease
 	RegionInfo RegionData
+
+	// Verifier checks the signatures of the artifact checksums. The signatures aren't
+	// verified without it.
+	Verifier *signature.Verifier
+
+	// open reads the artifact and checksum files. Defaults to downloading them over http.
+	open openFunc
+
+	// urlRewrites were applied to the artifact URLs in the manifest.
+	urlRewrites []URLRewrite
+
+	// cache holds the artifacts downloaded before. Optional.
+	cache *cache.Cache
 }
 
+// openFunc opens the artifact or checksum file at uri.
+type openFunc func(ctx context.Context, uri string) (io.ReadCloser, error)
+
 // GetLatestSource gets the source for latest version of aws provided artifacts
-func GetLatestSource(ctx context.Context, eksVersion, region string) (Source, error) {
-	manifest, err := getReleaseManifest(ctx)
+func GetLatestSource(ctx context.Context, eksVersion, region string, opts ...SourceOption) (Source, error) {
+	o := newSourceOptions(opts)
+	verifier, err := o.signatureVerifier()
+	if err != nil {
+		return Source{}, err
+	}
+	if verifier == nil {
+		logger.FromContext(ctx).Warn("Skipping signature verification of the release manifest and artifacts")
+	}
+	manifest, err := getReleaseManifest(ctx, o, verifier)
 	if err != nil {
 		return Source{}, err
 	}
+	source, err := getLatestSourceFromManifest(manifest, eksVersion, region)
+	if err != nil {
+		return Source{}, err
+	}
+	source.urlRewrites = o.urlRewrites
+	source.cache = o.cache
+	source.Verifier = verifier
+	return source, nil
+}
 
+func getLatestSourceFromManifest(manifest *Manifest, eksVersion, region string) (Source, error) {
 	eksPatchRelease, err := getLatestEksSource(eksVersion, manifest)
 	if err != nil {
 		return Source{}, errors.Wrap(err, "getting latest eks release")
@@ -138,8 +178,16 @@ func getLatestDateEksPatchRelease(patchReleases []EksPatchRelease) (EksPatchRele
 	return latestRelease, nil
 }
 
-func GetRegionConfig(ctx context.Context, region string) (*RegionData, error) {
-	manifest, err := getReleaseManifest(ctx)
+// GetRegionConfig returns the config of region from the release manifest, verifying the
+// manifest signature like GetLatestSource, since the region config decides the ECR account
+// images are pulled from.
+func GetRegionConfig(ctx context.Context, region string, opts ...SourceOption) (*RegionData, error) {
+	o := newSourceOptions(opts)
+	verifier, err := o.signatureVerifier()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := getReleaseManifest(ctx, o, verifier)
 	if err != nil {
 		return nil, err
 	}
@@ -178,15 +226,62 @@ func (as Source) GetCniPlugins(ctx context.Context) (artifact.Source, error) {
 }
 
 func (as Source) getEksSource(ctx context.Context, artifactName string) (artifact.Source, error) {
-	return getSource(ctx, artifactName, as.Eks.Artifacts)
+	return as.getSource(ctx, artifactName, as.Eks.Artifacts)
 }
 
 // GetSingingHelper satisfies iamrolesanywhere.SigningHelperSource
 func (as Source) GetSigningHelper(ctx context.Context) (artifact.Source, error) {
-	return getSource(ctx, "aws_signing_helper", as.Iam.Artifacts)
+	return as.getSource(ctx, "aws_signing_helper", as.Iam.Artifacts)
 }
 
-func getSource(ctx context.Context, artifactName string, availableArtifacts []Artifact) (artifact.Source, error) {
+// EksArtifactURI returns the URI the EKS artifact is downloaded from for this os and arch,
+// or an empty string when the release doesn't have it.
+func (as Source) EksArtifactURI(artifactName string) string {
+	return artifactURI(artifactName, as.Eks.Artifacts)
+}
+
+// SigningHelperURI returns the URI the signing helper is downloaded from for this os and
+// arch, or an empty string when the release doesn't have it.
+func (as Source) SigningHelperURI() string {
+	return artifactURI("aws_signing_helper", as.Iam.Artifacts)
+}
+
+func artifactURI(artifactName string, availableArtifacts []Artifact) string {
+	for _, releaseArtifact := range availableArtifacts {
+		if releaseArtifact.Name == artifactName && releaseArtifact.Arch == runtime.GOARCH && releaseArtifact.OS == runtime.GOOS {
+			if releaseArtifact.GzipURI != "" {
+				return releaseArtifact.GzipURI
+			}
+			return releaseArtifact.URI
+		}
+	}
+	return ""
+}
+
+// RewriteURL applies the URL rewrites of the source to uri, for artifacts that are not
+// in the manifest like the SSM installer.
+func (as Source) RewriteURL(uri string) string {
+	return RewriteURL(uri, as.urlRewrites)
+}
+
+func (as Source) openFile(ctx context.Context, uri string) (io.ReadCloser, error) {
+	if as.open != nil {
+		return as.open(ctx, uri)
+	}
+	return util.GetHttpFileReader(ctx, uri)
+}
+
+func (as Source) readFile(ctx context.Context, uri string) ([]byte, error) {
+	// checksums and signatures are too small to report the progress of
+	reader, err := as.openFile(util.WithProgress(ctx, nil), uri)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+func (as Source) getSource(ctx context.Context, artifactName string, availableArtifacts []Artifact) (artifact.Source, error) {
 	for _, releaseArtifact := range availableArtifacts {
 		if releaseArtifact.Name == artifactName && releaseArtifact.Arch == runtime.GOARCH && releaseArtifact.OS == runtime.GOOS {
 			uri := releaseArtifact.URI
@@ -195,15 +290,24 @@ func getSource(ctx context.Context, artifactName string, availableArtifacts []Ar
 				// gzip decompression will happen before checksum verification
 				uri = releaseArtifact.GzipURI
 			}
-			obj, err := util.GetHttpFileReader(ctx, uri)
+
+			artifactChecksum, err := as.readFile(ctx, releaseArtifact.ChecksumURI)
 			if err != nil {
-				return nil, fmt.Errorf("getting artifact file reader: %w", err)
+				return nil, fmt.Errorf("getting artifact checksum file reader: %w", err)
+			}
+			if err := as.verifyChecksumSignature(ctx, releaseArtifact, artifactChecksum); err != nil {
+				return nil, err
+			}
+
+			if as.cache != nil {
+				if source, ok := as.getCachedSource(ctx, artifactName, artifactChecksum); ok {
+					return source, nil
+				}
 			}
 
-			artifactChecksum, err := util.GetHttpFile(ctx, releaseArtifact.ChecksumURI)
+			obj, err := as.openFile(ctx, uri)
 			if err != nil {
-				obj.Close()
-				return nil, fmt.Errorf("getting artifact checksum file reader: %w", err)
+				return nil, fmt.Errorf("getting artifact file reader: %w", err)
 			}
 
 			var source artifact.Source
@@ -217,8 +321,54 @@ func getSource(ctx context.Context, artifactName string, availableArtifacts []Ar
 				obj.Close()
 				return nil, fmt.Errorf("getting artifact with checksum: %w", err)
 			}
+			if as.cache != nil {
+				cached, err := as.cache.Store(source, artifactName, uri)
+				if err == nil {
+					return cached, nil
+				}
+				logger.FromContext(ctx).Warn("Failed to cache artifact", zap.String("artifact", artifactName), zap.Error(err))
+			}
 			return source, nil
 		}
 	}
 	return nil, fmt.Errorf("could not find artifact for %s arch and %s os", runtime.GOARCH, runtime.GOOS)
 }
+
+// verifyChecksumSignature checks the checksum file of the artifact was signed by a trusted
+// key, failing when the artifact has no signature. It does nothing without a Verifier.
+func (as Source) verifyChecksumSignature(ctx context.Context, releaseArtifact Artifact, artifactChecksum []byte) error {
+	if as.Verifier == nil {
+		return nil
+	}
+	if releaseArtifact.SignatureURI == "" {
+		return fmt.Errorf("artifact %s has no signature", releaseArtifact.Name)
+	}
+	checksumSignature, err := as.readFile(ctx, releaseArtifact.SignatureURI)
+	if err != nil {
+		return fmt.Errorf("getting artifact signature: %w", err)
+	}
+	if err := as.Verifier.Verify(artifactChecksum, checksumSignature); err != nil {
+		return fmt.Errorf("verifying %s signature: %w", releaseArtifact.Name, err)
+	}
+	return nil
+}
+
+// getCachedSource returns the cached artifact with the checksum, which is still verified
+// while it's read.
+func (as Source) getCachedSource(ctx context.Context, artifactName string, artifactChecksum []byte) (artifact.Source, bool) {
+	checksum, err := artifact.ParseGNUChecksum(artifactChecksum)
+	if err != nil {
+		return nil, false
+	}
+	cached, err := as.cache.Open(checksum)
+	if err != nil {
+		return nil, false
+	}
+	source, err := artifact.WithChecksum(cached, sha256.New(), artifactChecksum)
+	if err != nil {
+		cached.Close()
+		return nil, false
+	}
+	logger.FromContext(ctx).Info("Using cached artifact", zap.String("artifact", artifactName), zap.String("sha256", hex.EncodeToString(checksum)))
+	return source, true
+}
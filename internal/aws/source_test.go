@@ -0,0 +1,256 @@
+package aws
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/gopenpgp/v3/crypto"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/cache"
+	"github.com/aws/eks-hybrid/internal/signature"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+// testSigningKey signs the test releases. internal/test can't be used here since it
+// imports this package.
+type testSigningKey struct {
+	key       *crypto.Key
+	publicKey string
+}
+
+func newTestSigningKey(g *WithT) testSigningKey {
+	key, err := crypto.PGP().KeyGeneration().
+		AddUserId("test", "test@example.com").
+		New().
+		GenerateKey()
+	g.Expect(err).NotTo(HaveOccurred())
+	publicKey, err := key.GetArmoredPublicKey()
+	g.Expect(err).NotTo(HaveOccurred())
+	return testSigningKey{key: key, publicKey: publicKey}
+}
+
+func (k testSigningKey) sign(g *WithT, data []byte) []byte {
+	signer, err := crypto.PGP().Sign().
+		SigningKey(k.key).
+		Detached().
+		New()
+	g.Expect(err).NotTo(HaveOccurred())
+	sig, err := signer.Sign(data, crypto.Armor)
+	g.Expect(err).NotTo(HaveOccurred())
+	return sig
+}
+
+func (k testSigningKey) verifier(g *WithT) *signature.Verifier {
+	verifier, err := signature.NewVerifier(k.publicKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	return verifier
+}
+
+// testRelease serves a release manifest with a kubelet artifact from https://assets.example.com/,
+// which is rewritten to the files under path on the server.
+type testRelease struct {
+	server           *httptest.Server
+	files            map[string][]byte
+	kubelet          []byte
+	kubeletDownloads int
+}
+
+func newTestRelease(g *WithT, key testSigningKey, path string) *testRelease {
+	kubelet := []byte("kubelet")
+	manifest := []byte(fmt.Sprintf(`supported_eks_releases:
+- major_minor_version: "1.31"
+  latest_patch_version: "2"
+  patch_releases:
+  - version: 1.31.2
+    patch_version: "2"
+    release_date: "2024-12-01"
+    artifacts:
+    - name: kubelet
+      arch: %[1]s
+      os: %[2]s
+      uri: https://assets.example.com/kubelet
+      checksum_uri: https://assets.example.com/kubelet.sha256
+      signature_uri: https://assets.example.com/kubelet.sha256.sig
+iam_roles_anywhere_releases:
+- version: v1.2.0
+region_config:
+  us-west-2:
+    ecr_account_id: "123456789012"
+`, runtime.GOARCH, runtime.GOOS))
+	checksum := []byte(fmt.Sprintf("%x  kubelet\n", sha256.Sum256(kubelet)))
+	release := &testRelease{
+		kubelet: kubelet,
+		files: map[string][]byte{
+			path + "/manifest.yaml":      manifest,
+			path + "/manifest.yaml.sig":  key.sign(g, manifest),
+			path + "/kubelet":            kubelet,
+			path + "/kubelet.sha256":     checksum,
+			path + "/kubelet.sha256.sig": key.sign(g, checksum),
+		},
+	}
+	release.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := release.files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Path == path+"/kubelet" {
+			release.kubeletDownloads++
+		}
+		_, _ = w.Write(content)
+	}))
+	return release
+}
+
+func (r *testRelease) source(g *WithT, key testSigningKey, opts ...SourceOption) (Source, error) {
+	opts = append([]SourceOption{
+		WithManifestURL(r.server.URL + "/manifest.yaml"),
+		WithURLRewrites([]URLRewrite{{Prefix: "https://assets.example.com/", Replacement: r.server.URL + "/"}}),
+		WithVerifier(key.verifier(g)),
+	}, opts...)
+	return GetLatestSource(fastRetriesContext(), "1.31", "us-west-2", opts...)
+}
+
+// fastRetriesContext retries failed requests without waiting, for the tests of missing files.
+func fastRetriesContext() context.Context {
+	return util.WithDownloadOptions(context.Background(), util.DownloadOptions{RetryBackoff: time.Millisecond})
+}
+
+func TestGetLatestSourceVerifiesManifestSignature(t *testing.T) {
+	g := NewWithT(t)
+	key := newTestSigningKey(g)
+	release := newTestRelease(g, key, "")
+	defer release.server.Close()
+
+	_, err := release.source(g, newTestSigningKey(g))
+	g.Expect(err).To(MatchError(ContainSubstring("verifying release manifest signature")))
+
+	delete(release.files, "/manifest.yaml.sig")
+	_, err = release.source(g, key)
+	g.Expect(err).To(MatchError(ContainSubstring("getting release manifest signature")))
+
+	// nodeadm built without keys fails unless verification is skipped
+	_, err = GetLatestSource(context.Background(), "1.31", "us-west-2", WithManifestURL(release.server.URL+"/manifest.yaml"))
+	g.Expect(err).To(MatchError(signature.ErrNoTrustedKeys))
+}
+
+func TestGetLatestSourceWithoutSignatureVerification(t *testing.T) {
+	g := NewWithT(t)
+	release := newTestRelease(g, newTestSigningKey(g), "")
+	defer release.server.Close()
+	delete(release.files, "/manifest.yaml.sig")
+	delete(release.files, "/kubelet.sha256.sig")
+
+	// the keys are ignored when verification is skipped
+	source, err := release.source(g, newTestSigningKey(g), WithoutSignatureVerification())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(source.Verifier).To(BeNil())
+	kubelet, err := source.GetKubelet(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	kubelet.Close()
+
+	// the checksums are still verified
+	release.files["/kubelet"] = []byte("tampered")
+	kubelet, err = source.GetKubelet(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = io.ReadAll(kubelet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(kubelet.VerifyChecksum()).To(BeFalse())
+	kubelet.Close()
+}
+
+func TestGetRegionConfigVerifiesManifestSignature(t *testing.T) {
+	g := NewWithT(t)
+	key := newTestSigningKey(g)
+	release := newTestRelease(g, key, "")
+	defer release.server.Close()
+	manifestURL := WithManifestURL(release.server.URL + "/manifest.yaml")
+
+	regionConfig, err := GetRegionConfig(context.Background(), "us-west-2", manifestURL, WithVerifier(key.verifier(g)))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(regionConfig.EcrAccountID).To(Equal("123456789012"))
+
+	_, err = GetRegionConfig(context.Background(), "us-west-2", manifestURL, WithVerifier(newTestSigningKey(g).verifier(g)))
+	g.Expect(err).To(MatchError(ContainSubstring("verifying release manifest signature")))
+
+	_, err = GetRegionConfig(context.Background(), "us-west-2", manifestURL)
+	g.Expect(err).To(MatchError(signature.ErrNoTrustedKeys))
+
+	delete(release.files, "/manifest.yaml.sig")
+	_, err = GetRegionConfig(fastRetriesContext(), "us-west-2", manifestURL, WithVerifier(key.verifier(g)))
+	g.Expect(err).To(MatchError(ContainSubstring("getting release manifest signature")))
+
+	regionConfig, err = GetRegionConfig(context.Background(), "us-west-2", manifestURL, WithoutSignatureVerification())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(regionConfig.EcrAccountID).To(Equal("123456789012"))
+}
+
+func TestGetSourceVerifiesChecksumSignature(t *testing.T) {
+	g := NewWithT(t)
+	key := newTestSigningKey(g)
+	release := newTestRelease(g, key, "")
+	defer release.server.Close()
+
+	source, err := release.source(g, key)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// a mirror replacing the artifact and its checksum can't sign the checksum
+	release.files["/kubelet"] = []byte("tampered")
+	release.files["/kubelet.sha256"] = []byte(fmt.Sprintf("%x  kubelet\n", sha256.Sum256([]byte("tampered"))))
+	_, err = source.GetKubelet(fastRetriesContext())
+	g.Expect(err).To(MatchError(ContainSubstring("verifying kubelet signature")))
+
+	delete(release.files, "/kubelet.sha256.sig")
+	_, err = source.GetKubelet(fastRetriesContext())
+	g.Expect(err).To(MatchError(ContainSubstring("getting artifact signature")))
+
+	source.Eks.Artifacts[0].SignatureURI = ""
+	_, err = source.GetKubelet(fastRetriesContext())
+	g.Expect(err).To(MatchError("artifact kubelet has no signature"))
+}
+
+func TestGetSourceFromCache(t *testing.T) {
+	g := NewWithT(t)
+	key := newTestSigningKey(g)
+	release := newTestRelease(g, key, "")
+	defer release.server.Close()
+
+	cacheDir := t.TempDir()
+	source, err := release.source(g, key, WithCache(cache.New(cacheDir, cache.DefaultMaxSize)))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	readKubelet := func() ([]byte, bool) {
+		kubeletSource, err := source.GetKubelet(context.Background())
+		g.Expect(err).NotTo(HaveOccurred())
+		content, err := io.ReadAll(kubeletSource)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(kubeletSource.Close()).To(Succeed())
+		return content, kubeletSource.VerifyChecksum()
+	}
+
+	content, verified := readKubelet()
+	g.Expect(content).To(Equal(release.kubelet))
+	g.Expect(verified).To(BeTrue())
+	g.Expect(release.kubeletDownloads).To(Equal(1))
+
+	content, verified = readKubelet()
+	g.Expect(content).To(Equal(release.kubelet))
+	g.Expect(verified).To(BeTrue())
+	g.Expect(release.kubeletDownloads).To(Equal(1), "cache hit should not download the artifact")
+
+	checksum := sha256.Sum256(release.kubelet)
+	g.Expect(os.WriteFile(filepath.Join(cacheDir, "sha256", fmt.Sprintf("%x", checksum)), []byte("corrupted"), 0o644)).To(Succeed())
+	content, verified = readKubelet()
+	g.Expect(content).To(Equal([]byte("corrupted")))
+	g.Expect(verified).To(BeFalse(), "cache hit should still verify the checksum")
+}
@@ -0,0 +1,362 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/cmd/nodeadm/version"
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/creds"
+	"github.com/aws/eks-hybrid/internal/signature"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+const (
+	// MetadataFile is the name of the file describing what a bundle was created for.
+	MetadataFile = "bundle.yaml"
+
+	// PackagesDir is the directory of the distro packages in a bundle, with a
+	// directory per package.
+	PackagesDir = "packages"
+
+	artifactsDir = "artifacts"
+)
+
+// Metadata describes what a bundle was created for. Bundles can only install on
+// nodes that match it.
+type Metadata struct {
+	KubernetesVersion  string                   `json:"kubernetesVersion"`
+	Arch               string                   `json:"arch"`
+	CredentialProvider creds.CredentialProvider `json:"credentialProvider"`
+	NodeadmVersion     string                   `json:"nodeadmVersion"`
+	// PackageManager is the package manager of the distro packages in the bundle,
+	// empty if the bundle doesn't include them.
+	PackageManager string `json:"packageManager,omitempty"`
+}
+
+// PackageDownloader downloads the distro packages to include in a bundle.
+type PackageDownloader interface {
+	DownloadPackages(ctx context.Context, dir, containerdVersion string) error
+	Manager() string
+}
+
+// CreateOptions configures the bundle created by Create.
+type CreateOptions struct {
+	// Manifest is the subset of the release manifest to bundle, like the one
+	// returned by aws.GetBundleManifest.
+	Manifest           *aws.Manifest
+	Arch               string
+	CredentialProvider creds.CredentialProvider
+	// Packages downloads the distro packages. Optional, the bundle doesn't include
+	// distro packages if nil.
+	Packages          PackageDownloader
+	ContainerdVersion string
+	// Verifier checks the signatures of the artifact checksums, like the one returned
+	// by aws.SignatureVerifier. The signatures aren't verified without it, but the ones
+	// in the manifest are still added to the bundle.
+	Verifier *signature.Verifier
+	Logger   *zap.Logger
+}
+
+// Create writes a bundle to output with the artifacts in the manifest and their checksums,
+// after verifying them. The artifact URIs in the bundled manifest are rewritten to paths
+// relative to the bundle root.
+func Create(ctx context.Context, output string, opts CreateOptions) error {
+	if opts.CredentialProvider == creds.SsmCredentialProvider {
+		return fmt.Errorf("credential provider %s is not supported in bundles, the SSM agent installer downloads the agent during install", opts.CredentialProvider)
+	}
+	if len(opts.Manifest.SupportedEksReleases) != 1 || len(opts.Manifest.SupportedEksReleases[0].PatchReleases) != 1 {
+		return fmt.Errorf("bundle manifest must have a single eks patch release")
+	}
+
+	staging, err := os.MkdirTemp("", "nodeadm-bundle")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+
+	manifest := *opts.Manifest
+	release := manifest.SupportedEksReleases[0].PatchReleases[0]
+	release.Artifacts, err = downloadArtifacts(ctx, staging, release.Artifacts, opts)
+	if err != nil {
+		return err
+	}
+	manifest.SupportedEksReleases = []aws.SupportedEksRelease{manifest.SupportedEksReleases[0]}
+	manifest.SupportedEksReleases[0].PatchReleases = []aws.EksPatchRelease{release}
+
+	if opts.CredentialProvider == creds.IamRolesAnywhereCredentialProvider {
+		if len(manifest.IamRolesAnywhereReleases) != 1 {
+			return fmt.Errorf("bundle manifest must have a single iam roles anywhere release")
+		}
+		iamRelease := manifest.IamRolesAnywhereReleases[0]
+		iamRelease.Artifacts, err = downloadArtifacts(ctx, staging, iamRelease.Artifacts, opts)
+		if err != nil {
+			return err
+		}
+		manifest.IamRolesAnywhereReleases = []aws.IamRolesAnywhereRelease{iamRelease}
+	} else {
+		manifest.IamRolesAnywhereReleases = nil
+	}
+	manifest.SsmReleases = nil
+
+	metadata := Metadata{
+		KubernetesVersion:  release.Version,
+		Arch:               opts.Arch,
+		CredentialProvider: opts.CredentialProvider,
+		NodeadmVersion:     version.GitVersion,
+	}
+	if opts.Packages != nil {
+		opts.Logger.Info("Downloading distro packages...")
+		if err := opts.Packages.DownloadPackages(ctx, filepath.Join(staging, PackagesDir), opts.ContainerdVersion); err != nil {
+			return err
+		}
+		metadata.PackageManager = opts.Packages.Manager()
+	}
+
+	if err := writeYaml(filepath.Join(staging, aws.BundleManifestFile), manifest); err != nil {
+		return err
+	}
+	if err := writeYaml(filepath.Join(staging, MetadataFile), metadata); err != nil {
+		return err
+	}
+	opts.Logger.Info("Writing bundle...", zap.String("output", output))
+	return writeTarGz(output, staging)
+}
+
+// downloadArtifacts downloads the artifacts with their checksums and signatures to the
+// artifacts directory under dir, returning the artifacts with their URIs relative to dir.
+func downloadArtifacts(ctx context.Context, dir string, artifacts []aws.Artifact, opts CreateOptions) ([]aws.Artifact, error) {
+	var bundled []aws.Artifact
+	for _, releaseArtifact := range artifacts {
+		opts.Logger.Info("Downloading artifact...", zap.String("artifact", releaseArtifact.Name))
+		uri := releaseArtifact.URI
+		if releaseArtifact.GzipURI != "" {
+			uri = releaseArtifact.GzipURI
+		}
+		if releaseArtifact.SignatureURI == "" && opts.Verifier != nil {
+			return nil, fmt.Errorf("artifact %s has no signature", releaseArtifact.Name)
+		}
+		artifactPath, err := artifactFilePath(releaseArtifact.Name, uri)
+		if err != nil {
+			return nil, err
+		}
+		checksumPath := artifactPath + ".sha256"
+		signaturePath := checksumPath + aws.SignatureSuffix
+		if err := downloadFile(ctx, filepath.Join(dir, artifactPath), uri); err != nil {
+			return nil, errors.Wrapf(err, "downloading %s", releaseArtifact.Name)
+		}
+		if err := downloadFile(ctx, filepath.Join(dir, checksumPath), releaseArtifact.ChecksumURI); err != nil {
+			return nil, errors.Wrapf(err, "downloading %s checksum", releaseArtifact.Name)
+		}
+		if releaseArtifact.SignatureURI != "" {
+			if err := downloadFile(ctx, filepath.Join(dir, signaturePath), releaseArtifact.SignatureURI); err != nil {
+				return nil, errors.Wrapf(err, "downloading %s signature", releaseArtifact.Name)
+			}
+		} else {
+			signaturePath = ""
+		}
+		if opts.Verifier != nil {
+			if err := verifySignature(opts.Verifier, filepath.Join(dir, checksumPath), filepath.Join(dir, signaturePath)); err != nil {
+				return nil, errors.Wrapf(err, "verifying %s signature", releaseArtifact.Name)
+			}
+		}
+		if err := verifyArtifact(filepath.Join(dir, artifactPath), filepath.Join(dir, checksumPath), releaseArtifact.GzipURI != ""); err != nil {
+			return nil, errors.Wrapf(err, "verifying %s", releaseArtifact.Name)
+		}
+
+		bundledArtifact := releaseArtifact
+		bundledArtifact.ChecksumURI = checksumPath
+		bundledArtifact.SignatureURI = signaturePath
+		if releaseArtifact.GzipURI != "" {
+			bundledArtifact.URI = ""
+			bundledArtifact.GzipURI = artifactPath
+		} else {
+			bundledArtifact.URI = artifactPath
+		}
+		bundled = append(bundled, bundledArtifact)
+	}
+	return bundled, nil
+}
+
+// artifactFilePath returns the path of the artifact in the bundle, keeping the
+// file name of uri so the gzipped artifacts keep their extension.
+func artifactFilePath(name, uri string) (string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing %s uri", name)
+	}
+	fileName := path.Base(parsed.Path)
+	if !filepath.IsLocal(name) || !filepath.IsLocal(fileName) {
+		return "", fmt.Errorf("invalid file name %s for artifact %s", fileName, name)
+	}
+	return path.Join(artifactsDir, name, fileName), nil
+}
+
+func downloadFile(ctx context.Context, dst, uri string) error {
+	reader, err := util.GetHttpFileReader(ctx, uri)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return artifact.InstallFile(dst, reader, 0o644)
+}
+
+func verifySignature(verifier *signature.Verifier, checksumPath, signaturePath string) error {
+	checksum, err := os.ReadFile(checksumPath)
+	if err != nil {
+		return err
+	}
+	checksumSignature, err := os.ReadFile(signaturePath)
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(checksum, checksumSignature)
+}
+
+// verifyArtifact reads the downloaded artifact through the same checksum verification
+// nodeadm install uses.
+func verifyArtifact(artifactPath, checksumPath string, gzipped bool) error {
+	checksum, err := os.ReadFile(checksumPath)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(artifactPath)
+	if err != nil {
+		return err
+	}
+	var source artifact.Source
+	if gzipped {
+		source, err = artifact.GzippedWithChecksum(file, sha256.New(), checksum)
+	} else {
+		source, err = artifact.WithChecksum(file, sha256.New(), checksum)
+	}
+	if err != nil {
+		file.Close()
+		return err
+	}
+	defer source.Close()
+	if _, err := io.Copy(io.Discard, source); err != nil {
+		return err
+	}
+	if !source.VerifyChecksum() {
+		return artifact.NewChecksumError(source)
+	}
+	return nil
+}
+
+func writeYaml(dst string, obj interface{}) error {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0o644)
+}
+
+// writeTarGz writes the contents of dir to a gzipped tarball at dst.
+func writeTarGz(dst, dir string) error {
+	file, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	gzw := gzip.NewWriter(file)
+	tw := tar.NewWriter(gzw)
+
+	err = filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, filePath)
+		if err != nil || name == "." {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+		if entry.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		src, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "writing bundle")
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gzw.Close(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// Extract extracts the bundle at src to dst and returns its metadata.
+func Extract(dst, src string) (*Metadata, error) {
+	if err := artifact.ExtractTarGz(dst, src); err != nil {
+		return nil, errors.Wrap(err, "extracting bundle")
+	}
+	data, err := os.ReadFile(filepath.Join(dst, MetadataFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading bundle metadata")
+	}
+	var metadata Metadata
+	if err := yaml.Unmarshal(data, &metadata); err != nil {
+		return nil, errors.Wrap(err, "invalid yaml data in bundle metadata")
+	}
+	return &metadata, nil
+}
+
+// Validate checks the bundle can install kubernetesVersion with credentialProvider
+// on a node with arch.
+func (m *Metadata) Validate(kubernetesVersion string, credentialProvider creds.CredentialProvider, arch string) error {
+	if m.Arch != arch {
+		return fmt.Errorf("bundle was created for arch %s, but the node uses %s", m.Arch, arch)
+	}
+	if m.CredentialProvider != credentialProvider {
+		return fmt.Errorf("bundle was created for credential provider %s, not %s", m.CredentialProvider, credentialProvider)
+	}
+	if !matchesVersion(m.KubernetesVersion, kubernetesVersion) {
+		return fmt.Errorf("bundle was created for Kubernetes version %s, not %s", m.KubernetesVersion, kubernetesVersion)
+	}
+	return nil
+}
+
+// matchesVersion checks bundleVersion, like 1.31.2, is the major[.minor[.patch]] version.
+func matchesVersion(bundleVersion, version string) bool {
+	bundleVersion = strings.TrimPrefix(bundleVersion, "v")
+	version = strings.TrimPrefix(version, "v")
+	return bundleVersion == version || strings.HasPrefix(bundleVersion, version+".")
+}
@@ -0,0 +1,268 @@
+package bundle_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/bundle"
+	"github.com/aws/eks-hybrid/internal/creds"
+	"github.com/aws/eks-hybrid/internal/signature"
+	"github.com/aws/eks-hybrid/internal/test"
+)
+
+func checksum(content []byte) []byte {
+	return []byte(fmt.Sprintf("%x  file\n", sha256.Sum256(content)))
+}
+
+func gzipped(g *WithT, content []byte) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	_, err := gw.Write(content)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(gw.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+func testServer(g *WithT, key test.SigningKey, kubeletChecksum []byte) *httptest.Server {
+	kubelet := []byte("kubelet")
+	signingHelper := []byte("aws_signing_helper")
+	files := map[string][]byte{
+		"/kubelet":                       kubelet,
+		"/kubelet.sha256":                kubeletChecksum,
+		"/kubelet.sha256.sig":            key.Sign(g, kubeletChecksum),
+		"/aws_signing_helper.gz":         gzipped(g, signingHelper),
+		"/aws_signing_helper.sha256":     checksum(signingHelper),
+		"/aws_signing_helper.sha256.sig": key.Sign(g, checksum(signingHelper)),
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+}
+
+func testManifest(url string) *aws.Manifest {
+	return &aws.Manifest{
+		SupportedEksReleases: []aws.SupportedEksRelease{
+			{
+				MajorMinorVersion:  "1.31",
+				LatestPatchVersion: "2",
+				PatchReleases: []aws.EksPatchRelease{
+					{
+						Version:      "1.31.2",
+						PatchVersion: "2",
+						Artifacts: []aws.Artifact{
+							{Name: "kubelet", Arch: runtime.GOARCH, OS: "linux", URI: url + "/kubelet", ChecksumURI: url + "/kubelet.sha256", SignatureURI: url + "/kubelet.sha256.sig"},
+						},
+					},
+				},
+			},
+		},
+		IamRolesAnywhereReleases: []aws.IamRolesAnywhereRelease{
+			{
+				Version: "v1.2.0",
+				Artifacts: []aws.Artifact{
+					{
+						Name: "aws_signing_helper", Arch: runtime.GOARCH, OS: "linux",
+						URI: url + "/aws_signing_helper", GzipURI: url + "/aws_signing_helper.gz", ChecksumURI: url + "/aws_signing_helper.sha256",
+						SignatureURI: url + "/aws_signing_helper.sha256.sig",
+					},
+				},
+			},
+		},
+		RegionConfig: aws.RegionConfig{
+			"us-west-2": {EcrAccountID: "123456789012"},
+		},
+	}
+}
+
+func testVerifier(g *WithT, key test.SigningKey) *signature.Verifier {
+	verifier, err := signature.NewVerifier(key.PublicKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	return verifier
+}
+
+func TestCreateAndExtract(t *testing.T) {
+	g := NewWithT(t)
+	key := test.GenerateSigningKey(g)
+	server := testServer(g, key, checksum([]byte("kubelet")))
+	defer server.Close()
+	tmp := t.TempDir()
+	output := filepath.Join(tmp, "bundle.tgz")
+	verifier := testVerifier(g, key)
+
+	g.Expect(bundle.Create(context.Background(), output, bundle.CreateOptions{
+		Manifest:           testManifest(server.URL),
+		Arch:               runtime.GOARCH,
+		CredentialProvider: creds.IamRolesAnywhereCredentialProvider,
+		Verifier:           verifier,
+		Logger:             zap.NewNop(),
+	})).To(Succeed())
+
+	dir := filepath.Join(tmp, "extracted")
+	metadata, err := bundle.Extract(dir, output)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(metadata.KubernetesVersion).To(Equal("1.31.2"))
+	g.Expect(metadata.Arch).To(Equal(runtime.GOARCH))
+	g.Expect(metadata.CredentialProvider).To(Equal(creds.IamRolesAnywhereCredentialProvider))
+	g.Expect(metadata.PackageManager).To(BeEmpty())
+	g.Expect(metadata.Validate("1.31", creds.IamRolesAnywhereCredentialProvider, runtime.GOARCH)).To(Succeed())
+
+	// the bundle installs without the server
+	server.Close()
+	source, err := aws.GetBundleSource(dir, "1.31", "us-west-2", aws.WithVerifier(verifier))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(source.Eks.Artifacts[0].URI).To(Equal("artifacts/kubelet/kubelet"))
+	g.Expect(source.Eks.Artifacts[0].SignatureURI).To(Equal("artifacts/kubelet/kubelet.sha256.sig"))
+	g.Expect(source.Iam.Artifacts[0].GzipURI).To(Equal("artifacts/aws_signing_helper/aws_signing_helper.gz"))
+
+	kubelet, err := source.GetKubelet(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	content, err := io.ReadAll(kubelet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("kubelet"))
+	g.Expect(kubelet.VerifyChecksum()).To(BeTrue())
+	kubelet.Close()
+
+	signingHelper, err := source.GetSigningHelper(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	content, err = io.ReadAll(signingHelper)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("aws_signing_helper"))
+	g.Expect(signingHelper.VerifyChecksum()).To(BeTrue())
+	signingHelper.Close()
+}
+
+func TestCreateWithoutSignatures(t *testing.T) {
+	g := NewWithT(t)
+	server := testServer(g, test.GenerateSigningKey(g), checksum([]byte("kubelet")))
+	defer server.Close()
+	tmp := t.TempDir()
+	output := filepath.Join(tmp, "bundle.tgz")
+	manifest := testManifest(server.URL)
+	manifest.SupportedEksReleases[0].PatchReleases[0].Artifacts[0].SignatureURI = ""
+	createOpts := bundle.CreateOptions{
+		Manifest:           manifest,
+		Arch:               runtime.GOARCH,
+		CredentialProvider: creds.IamRolesAnywhereCredentialProvider,
+		Verifier:           testVerifier(g, test.GenerateSigningKey(g)),
+		Logger:             zap.NewNop(),
+	}
+	g.Expect(bundle.Create(context.Background(), output, createOpts)).To(MatchError("artifact kubelet has no signature"))
+
+	createOpts.Verifier = nil
+	g.Expect(bundle.Create(context.Background(), output, createOpts)).To(Succeed())
+
+	dir := filepath.Join(tmp, "extracted")
+	_, err := bundle.Extract(dir, output)
+	g.Expect(err).NotTo(HaveOccurred())
+	source, err := aws.GetBundleSource(dir, "1.31", "us-west-2", aws.WithoutSignatureVerification())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(source.Eks.Artifacts[0].SignatureURI).To(BeEmpty())
+	g.Expect(source.Iam.Artifacts[0].SignatureURI).To(Equal("artifacts/aws_signing_helper/aws_signing_helper.gz.sha256.sig"))
+	kubelet, err := source.GetKubelet(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	kubelet.Close()
+}
+
+func TestCreateChecksumMismatch(t *testing.T) {
+	g := NewWithT(t)
+	key := test.GenerateSigningKey(g)
+	server := testServer(g, key, checksum([]byte("not kubelet")))
+	defer server.Close()
+
+	err := bundle.Create(context.Background(), filepath.Join(t.TempDir(), "bundle.tgz"), bundle.CreateOptions{
+		Manifest:           testManifest(server.URL),
+		Arch:               runtime.GOARCH,
+		CredentialProvider: creds.IamRolesAnywhereCredentialProvider,
+		Verifier:           testVerifier(g, key),
+		Logger:             zap.NewNop(),
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("verifying kubelet")))
+}
+
+func TestCreateUntrustedSignature(t *testing.T) {
+	g := NewWithT(t)
+	server := testServer(g, test.GenerateSigningKey(g), checksum([]byte("kubelet")))
+	defer server.Close()
+
+	err := bundle.Create(context.Background(), filepath.Join(t.TempDir(), "bundle.tgz"), bundle.CreateOptions{
+		Manifest:           testManifest(server.URL),
+		Arch:               runtime.GOARCH,
+		CredentialProvider: creds.IamRolesAnywhereCredentialProvider,
+		Verifier:           testVerifier(g, test.GenerateSigningKey(g)),
+		Logger:             zap.NewNop(),
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("verifying kubelet signature")))
+}
+
+func TestCreateSsm(t *testing.T) {
+	g := NewWithT(t)
+	err := bundle.Create(context.Background(), filepath.Join(t.TempDir(), "bundle.tgz"), bundle.CreateOptions{
+		Manifest:           testManifest("https://example.com"),
+		CredentialProvider: creds.SsmCredentialProvider,
+		Logger:             zap.NewNop(),
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("credential provider ssm is not supported in bundles")))
+}
+
+func TestMetadataValidate(t *testing.T) {
+	metadata := &bundle.Metadata{
+		KubernetesVersion:  "1.31.2",
+		Arch:               "amd64",
+		CredentialProvider: creds.IamRolesAnywhereCredentialProvider,
+	}
+	testCases := []struct {
+		name               string
+		kubernetesVersion  string
+		credentialProvider creds.CredentialProvider
+		arch               string
+		wantErr            string
+	}{
+		{name: "major minor", kubernetesVersion: "1.31", credentialProvider: creds.IamRolesAnywhereCredentialProvider, arch: "amd64"},
+		{name: "patch", kubernetesVersion: "1.31.2", credentialProvider: creds.IamRolesAnywhereCredentialProvider, arch: "amd64"},
+		{
+			name: "other patch", kubernetesVersion: "1.31.1", credentialProvider: creds.IamRolesAnywhereCredentialProvider, arch: "amd64",
+			wantErr: "bundle was created for Kubernetes version 1.31.2, not 1.31.1",
+		},
+		{
+			name: "other minor", kubernetesVersion: "1.3", credentialProvider: creds.IamRolesAnywhereCredentialProvider, arch: "amd64",
+			wantErr: "bundle was created for Kubernetes version 1.31.2, not 1.3",
+		},
+		{
+			name: "other arch", kubernetesVersion: "1.31", credentialProvider: creds.IamRolesAnywhereCredentialProvider, arch: "arm64",
+			wantErr: "bundle was created for arch amd64, but the node uses arm64",
+		},
+		{
+			name: "other credential provider", kubernetesVersion: "1.31", credentialProvider: creds.SsmCredentialProvider, arch: "amd64",
+			wantErr: "bundle was created for credential provider iam-ra, not ssm",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := metadata.Validate(tc.kubernetesVersion, tc.credentialProvider, tc.arch)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
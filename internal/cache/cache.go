@@ -0,0 +1,289 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+const (
+	// DefaultDir is where nodeadm caches the downloaded artifacts.
+	DefaultDir = "/var/cache/nodeadm"
+
+	// DefaultMaxSize is the size the cache is kept under, evicting the least recently
+	// used artifacts when a new one is added.
+	DefaultMaxSize int64 = 2 << 30
+
+	// PartialDownloadsDir keeps the partial downloads of install and upgrade, so they are
+	// resumed when the command runs again.
+	PartialDownloadsDir = DefaultDir + "/partial"
+
+	// artifactsDir holds the artifacts named after the hex sha256 of their content,
+	// with a json file for their Entry.
+	artifactsDir = "sha256"
+	entrySuffix  = ".json"
+	tmpDir       = "tmp"
+)
+
+// Cache is a content-addressed store of artifacts, keyed by the sha256 checksum
+// in the release manifest. It stores the artifacts decompressed, since the manifest
+// checksums are for the decompressed content.
+type Cache struct {
+	dir     string
+	maxSize int64
+	now     func() time.Time
+}
+
+// New returns a cache in dir kept under maxSize bytes.
+func New(dir string, maxSize int64) *Cache {
+	return &Cache{
+		dir:     dir,
+		maxSize: maxSize,
+		now:     time.Now,
+	}
+}
+
+// Entry is an artifact in the cache.
+type Entry struct {
+	// Checksum is the hex sha256 of the artifact.
+	Checksum string    `json:"checksum"`
+	Name     string    `json:"name"`
+	URI      string    `json:"uri"`
+	Size     int64     `json:"size"`
+	LastUsed time.Time `json:"lastUsed"`
+}
+
+func (c *Cache) artifactPath(checksum string) string {
+	return filepath.Join(c.dir, artifactsDir, checksum)
+}
+
+// Open opens the cached artifact with checksum and marks it as used. It returns an
+// error matching fs.ErrNotExist when the artifact is not cached. The caller must
+// still verify the checksum of the content.
+func (c *Cache) Open(checksum []byte) (io.ReadCloser, error) {
+	key := hex.EncodeToString(checksum)
+	file, err := os.Open(c.artifactPath(key))
+	if err != nil {
+		return nil, err
+	}
+	entry, err := c.readEntry(key)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	entry.LastUsed = c.now()
+	if err := c.writeEntry(entry); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+// Store returns a Source that copies the content of src to the cache while it's read.
+// The artifact is only added to the cache when the Source is closed after its checksum
+// was verified, evicting the least recently used artifacts over the size cap.
+func (c *Cache) Store(src artifact.Source, name, uri string) (artifact.Source, error) {
+	if err := os.MkdirAll(filepath.Join(c.dir, tmpDir), 0o755); err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(filepath.Join(c.dir, tmpDir), name)
+	if err != nil {
+		return nil, err
+	}
+	return &storingSource{
+		Source: src,
+		reader: io.TeeReader(src, tmp),
+		tmp:    tmp,
+		cache:  c,
+		name:   name,
+		uri:    uri,
+	}, nil
+}
+
+type storingSource struct {
+	artifact.Source
+	reader io.Reader
+	tmp    *os.File
+	cache  *Cache
+	name   string
+	uri    string
+}
+
+func (s *storingSource) Read(p []byte) (int, error) {
+	return s.reader.Read(p)
+}
+
+func (s *storingSource) Close() error {
+	defer os.Remove(s.tmp.Name())
+	if err := s.tmp.Close(); err != nil {
+		return errors.Join(err, s.Source.Close())
+	}
+	// a source closed before it's read completely doesn't match its checksum
+	if s.Source.VerifyChecksum() && s.Source.ExpectedChecksum() != nil {
+		// the artifact installed fine without the cache, so cache errors are ignored
+		_ = s.cache.add(s.tmp.Name(), hex.EncodeToString(s.Source.ExpectedChecksum()), s.name, s.uri)
+	}
+	return s.Source.Close()
+}
+
+func (c *Cache) add(path, checksum, name, uri string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(c.dir, artifactsDir), 0o755); err != nil {
+		return err
+	}
+	entry := Entry{
+		Checksum: checksum,
+		Name:     name,
+		URI:      uri,
+		Size:     info.Size(),
+		LastUsed: c.now(),
+	}
+	if err := c.writeEntry(entry); err != nil {
+		return err
+	}
+	if err := os.Rename(path, c.artifactPath(checksum)); err != nil {
+		return err
+	}
+	_, err = c.Prune(c.maxSize, 0)
+	return err
+}
+
+func (c *Cache) readEntry(checksum string) (Entry, error) {
+	data, err := os.ReadFile(c.artifactPath(checksum) + entrySuffix)
+	if err != nil {
+		return Entry{}, err
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Entry{}, fmt.Errorf("reading cache entry %s: %w", checksum, err)
+	}
+	return entry, nil
+}
+
+func (c *Cache) writeEntry(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.artifactPath(entry.Checksum)+entrySuffix, data, 0o644)
+}
+
+// List returns the cached artifacts, the most recently used first.
+func (c *Cache) List() ([]Entry, error) {
+	files, err := os.ReadDir(filepath.Join(c.dir, artifactsDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	for _, file := range files {
+		checksum, ok := strings.CutSuffix(file.Name(), entrySuffix)
+		if !ok {
+			continue
+		}
+		entry, err := c.readEntry(checksum)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return b.LastUsed.Compare(a.LastUsed)
+	})
+	return entries, nil
+}
+
+// Remove removes an artifact from the cache.
+func (c *Cache) Remove(entry Entry) error {
+	if err := os.Remove(c.artifactPath(entry.Checksum)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return os.Remove(c.artifactPath(entry.Checksum) + entrySuffix)
+}
+
+// Prune removes the artifacts not used for longer than unusedFor, when it's not zero,
+// and then the least recently used artifacts until the cache is under maxSize bytes.
+// It returns the removed artifacts.
+func (c *Cache) Prune(maxSize int64, unusedFor time.Duration) ([]Entry, error) {
+	entries, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+	var size int64
+	for _, entry := range entries {
+		size += entry.Size
+	}
+	var removed []Entry
+	// least recently used first
+	for _, entry := range slices.Backward(entries) {
+		unused := unusedFor > 0 && c.now().Sub(entry.LastUsed) > unusedFor
+		if !unused && size <= maxSize {
+			continue
+		}
+		if err := c.Remove(entry); err != nil {
+			return removed, err
+		}
+		size -= entry.Size
+		removed = append(removed, entry)
+	}
+	return removed, nil
+}
+
+// Verify checks the content of every cached artifact matches its checksum, removing
+// the ones that don't. It returns the removed artifacts.
+func (c *Cache) Verify() ([]Entry, error) {
+	entries, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+	var corrupted []Entry
+	for _, entry := range entries {
+		ok, err := c.verifyEntry(entry)
+		if err != nil {
+			return corrupted, err
+		}
+		if ok {
+			continue
+		}
+		if err := c.Remove(entry); err != nil {
+			return corrupted, err
+		}
+		corrupted = append(corrupted, entry)
+	}
+	return corrupted, nil
+}
+
+func (c *Cache) verifyEntry(entry Entry) (bool, error) {
+	expected, err := hex.DecodeString(entry.Checksum)
+	if err != nil {
+		return false, nil
+	}
+	file, err := os.Open(c.artifactPath(entry.Checksum))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	digest := sha256.New()
+	if _, err := io.Copy(digest, file); err != nil {
+		return false, err
+	}
+	return bytes.Equal(digest.Sum(nil), expected), nil
+}
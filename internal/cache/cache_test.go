@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+func newTestCache(t *testing.T, maxSize int64, now *time.Time) *Cache {
+	c := New(t.TempDir(), maxSize)
+	c.now = func() time.Time { return *now }
+	return c
+}
+
+func storeArtifact(t *testing.T, c *Cache, name, content string) []byte {
+	g := NewWithT(t)
+	checksum := sha256.Sum256([]byte(content))
+	src, err := artifact.WithChecksum(io.NopCloser(strings.NewReader(content)), sha256.New(), []byte(fmt.Sprintf("%x  %s\n", checksum, name)))
+	g.Expect(err).NotTo(HaveOccurred())
+	stored, err := c.Store(src, name, "https://example.com/"+name)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = io.Copy(io.Discard, stored)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(stored.VerifyChecksum()).To(BeTrue())
+	g.Expect(stored.Close()).To(Succeed())
+	return checksum[:]
+}
+
+func TestStoreAndOpen(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	c := newTestCache(t, DefaultMaxSize, &now)
+
+	checksum := storeArtifact(t, c, "kubelet", "kubelet")
+
+	now = now.Add(time.Hour)
+	cached, err := c.Open(checksum)
+	g.Expect(err).NotTo(HaveOccurred())
+	content, err := io.ReadAll(cached)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cached.Close()).To(Succeed())
+	g.Expect(string(content)).To(Equal("kubelet"))
+
+	entries, err := c.List()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entries).To(Equal([]Entry{
+		{
+			Checksum: fmt.Sprintf("%x", checksum),
+			Name:     "kubelet",
+			URI:      "https://example.com/kubelet",
+			Size:     int64(len("kubelet")),
+			LastUsed: now,
+		},
+	}))
+}
+
+func TestOpenMiss(t *testing.T) {
+	g := NewWithT(t)
+	c := New(t.TempDir(), DefaultMaxSize)
+	checksum := sha256.Sum256([]byte("kubelet"))
+	_, err := c.Open(checksum[:])
+	g.Expect(err).To(MatchError(fs.ErrNotExist))
+}
+
+func TestStoreChecksumMismatch(t *testing.T) {
+	g := NewWithT(t)
+	c := New(t.TempDir(), DefaultMaxSize)
+	checksum := sha256.Sum256([]byte("kubelet"))
+	src, err := artifact.WithChecksum(io.NopCloser(strings.NewReader("corrupted")), sha256.New(), []byte(fmt.Sprintf("%x  kubelet\n", checksum)))
+	g.Expect(err).NotTo(HaveOccurred())
+	stored, err := c.Store(src, "kubelet", "https://example.com/kubelet")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = io.Copy(io.Discard, stored)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(stored.Close()).To(Succeed())
+
+	_, err = c.Open(checksum[:])
+	g.Expect(err).To(MatchError(fs.ErrNotExist))
+	g.Expect(c.List()).To(BeEmpty())
+	g.Expect(os.ReadDir(filepath.Join(c.dir, tmpDir))).To(BeEmpty())
+}
+
+func TestStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	c := newTestCache(t, 10, &now)
+
+	storeArtifact(t, c, "kubelet", "kubelet")
+	now = now.Add(time.Hour)
+	storeArtifact(t, c, "kubectl", "kubectl")
+
+	entries, err := c.List()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entries).To(HaveLen(1))
+	g.Expect(entries[0].Name).To(Equal("kubectl"))
+}
+
+func TestPruneUnused(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	c := newTestCache(t, DefaultMaxSize, &now)
+
+	storeArtifact(t, c, "kubelet", "kubelet")
+	now = now.Add(48 * time.Hour)
+	storeArtifact(t, c, "kubectl", "kubectl")
+
+	removed, err := c.Prune(DefaultMaxSize, 24*time.Hour)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(removed).To(HaveLen(1))
+	g.Expect(removed[0].Name).To(Equal("kubelet"))
+
+	removed, err = c.Prune(0, 0)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(removed).To(HaveLen(1))
+	g.Expect(removed[0].Name).To(Equal("kubectl"))
+	g.Expect(c.List()).To(BeEmpty())
+}
+
+func TestVerify(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	c := newTestCache(t, DefaultMaxSize, &now)
+
+	storeArtifact(t, c, "kubelet", "kubelet")
+	corruptedChecksum := storeArtifact(t, c, "kubectl", "kubectl")
+	g.Expect(os.WriteFile(c.artifactPath(fmt.Sprintf("%x", corruptedChecksum)), []byte("corrupted"), 0o644)).To(Succeed())
+
+	corrupted, err := c.Verify()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(corrupted).To(HaveLen(1))
+	g.Expect(corrupted[0].Name).To(Equal("kubectl"))
+
+	entries, err := c.List()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entries).To(HaveLen(1))
+	g.Expect(entries[0].Name).To(Equal("kubelet"))
+}
@@ -3,6 +3,8 @@ package cli
 import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/aws/eks-hybrid/internal/secret"
 )
 
 func NewLogger(opts *GlobalOptions) *zap.Logger {
@@ -20,6 +22,7 @@ func NewLogger(opts *GlobalOptions) *zap.Logger {
 	if err != nil {
 		panic(err)
 	}
+	logger = logger.WithOptions(zap.WrapCore(secret.NewRedactingCore))
 	zap.ReplaceGlobals(logger)
 	return logger
 }
@@ -38,23 +38,24 @@ type InstallOptions struct {
 }
 
 func Install(ctx context.Context, opts InstallOptions) error {
-	if err := installFromSource(ctx, opts); err != nil {
+	binPath := filepath.Join(opts.InstallRoot, BinPath)
+	if err := installFromSource(ctx, opts, binPath); err != nil {
 		return err
 	}
 
-	if err := opts.Tracker.Add(artifact.CniPlugins); err != nil {
+	if err := opts.Tracker.Add(artifact.CniPlugins, binPath); err != nil {
 		return errors.Wrap(err, "adding cni-plugins to tracker")
 	}
 
 	return nil
 }
 
-func installFromSource(ctx context.Context, opts InstallOptions) error {
+func installFromSource(ctx context.Context, opts InstallOptions, binPath string) error {
 	if err := downloadFileWithRetries(ctx, opts); err != nil {
 		return errors.Wrap(err, "installing cni-plugins")
 	}
 
-	if err := artifact.InstallTarGz(filepath.Join(opts.InstallRoot, BinPath), filepath.Join(opts.InstallRoot, TgzPath)); err != nil {
+	if err := artifact.InstallTarGz(binPath, filepath.Join(opts.InstallRoot, TgzPath)); err != nil {
 		return errors.Wrap(err, "extracting and installing cni-plugins")
 	}
 
@@ -92,19 +93,25 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 	return nil
 }
 
-func Uninstall() error {
-	return os.RemoveAll(rootDir)
+// Uninstall removes the cni plugins installed at binPath. /opt/cni, with the downloaded
+// archive, is only removed when binPath is the default BinPath, since the parent of
+// another path can hold files nodeadm didn't install.
+func Uninstall(binPath string) error {
+	if binPath == BinPath {
+		return os.RemoveAll(rootDir)
+	}
+	return os.RemoveAll(binPath)
 }
 
 // Upgrade re-installs the cni-plugins available from the source
 // Since cni-plugins is delivered as a tarball, its not possible to check if they are due for an upgrade
 // todo: (@vignesh-goutham) check if we can publish cni-plugins independently with their checksum on our manifest
-func Upgrade(ctx context.Context, src Source, log *zap.Logger) error {
+func Upgrade(ctx context.Context, src Source, binPath string, log *zap.Logger) error {
 	opts := InstallOptions{
 		Source: src,
 		Logger: log,
 	}
-	if err := installFromSource(ctx, opts); err != nil {
+	if err := installFromSource(ctx, opts, binPath); err != nil {
 		return errors.Wrapf(err, "upgrading cni-plugins")
 	}
 	log.Info("Upgraded", zap.String("artifact", artifactName))
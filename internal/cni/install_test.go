@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -63,3 +64,18 @@ func TestInstall(t *testing.T) {
 		VerifyFilePaths: []string{filepath.Join(cni.BinPath, "fake-plugin")},
 	})
 }
+
+func TestUninstallCustomPath(t *testing.T) {
+	g := NewGomegaWithT(t)
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "bin")
+	g.Expect(os.MkdirAll(binPath, 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(binPath, "bridge"), []byte("bridge"), 0o755)).To(Succeed())
+	// the parent of a custom path can hold files nodeadm didn't install
+	other := filepath.Join(dir, "other")
+	g.Expect(os.WriteFile(other, []byte("other"), 0o644)).To(Succeed())
+
+	g.Expect(cni.Uninstall(binPath)).To(Succeed())
+	g.Expect(binPath).NotTo(BeAnExistingFile())
+	g.Expect(other).To(BeAnExistingFile())
+}
@@ -9,6 +9,16 @@ import (
 // The source URL must have a scheme, and the supported schemes are:
 // - `file`. To use configuration from the filesystem: `file:///path/to/file/or/directory`.
 // - `imds`. To use configuration from the instance's user data: `imds://user-data`.
+// - `http` and `https`. To download configuration from a web server: `https://example.com/config.yaml`.
+// - `s3`. To download configuration from an S3 object: `s3://bucket/key`.
+// - `nocloud`. To use user data from a cloud-init NoCloud seed: `nocloud://` or `nocloud:///path/to/seed`.
+// - `guestinfo`. To use user data from the VMware guestinfo variables: `guestinfo://userdata`.
+// - `ovf`. To use user data from the OVF environment: `ovf://` or `ovf:///path/to/ovf-env.xml`.
+//
+// When a `file` source is a directory, every `*.yaml` file in it is merged in lexical order.
+// Remote sources accept options in the URL fragment: `sha256=<hex>` pins the digest of the
+// config document, `ca-bundle=<path>` sets the trusted CAs and, for s3, `region=<region>`
+// sets the bucket region.
 func BuildConfigProvider(rawConfigSourceURL string) (ConfigProvider, error) {
 	parsedURL, err := url.Parse(rawConfigSourceURL)
 	if err != nil {
@@ -20,6 +30,16 @@ func BuildConfigProvider(rawConfigSourceURL string) (ConfigProvider, error) {
 	case "file":
 		source := getURLWithoutScheme(parsedURL)
 		return NewFileConfigProvider(source), nil
+	case "http", "https":
+		return NewHTTPConfigProvider(parsedURL)
+	case "s3":
+		return NewS3ConfigProvider(parsedURL)
+	case "nocloud":
+		return NewNoCloudConfigProvider(getURLWithoutScheme(parsedURL)), nil
+	case "guestinfo":
+		return NewGuestInfoConfigProvider(), nil
+	case "ovf":
+		return NewOVFConfigProvider(getURLWithoutScheme(parsedURL)), nil
 	default:
 		return nil, fmt.Errorf("unsupported scheme: %s", parsedURL.Scheme)
 	}
@@ -4,13 +4,19 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
+
+	"sigs.k8s.io/yaml"
 
 	internalapi "github.com/aws/eks-hybrid/internal/api"
-	apibridge "github.com/aws/eks-hybrid/internal/api/bridge"
 )
 
+const dropInFilePattern = "*.yaml"
+
 type fileConfigProvider struct {
-	path string
+	path         string
+	fieldSources map[string][]string
 }
 
 func NewFileConfigProvider(path string) ConfigProvider {
@@ -30,15 +36,87 @@ func (fcs *fileConfigProvider) Provide() (*internalapi.NodeConfig, error) {
 		return nil, err
 	}
 	if info.IsDir() {
-		return nil, fmt.Errorf("path is a directory, which is not currently supported: %s", fcs.path)
+		return fcs.provideFromDirectory()
 	}
 	data, err := io.ReadAll(file)
 	if err != nil {
 		return nil, err
 	}
-	config, err := apibridge.DecodeStrictNodeConfig(data)
+	config, err := decodeNodeConfig(data, true)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// provideFromDirectory decodes every drop-in file in the directory in lexical order
+// and merges them together, with latter files taking precedence.
+func (fcs *fileConfigProvider) provideFromDirectory() (*internalapi.NodeConfig, error) {
+	paths, err := filepath.Glob(filepath.Join(fcs.path, dropInFilePattern))
 	if err != nil {
 		return nil, err
 	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no %s files found in config directory %s", dropInFilePattern, fcs.path)
+	}
+	sort.Strings(paths)
+
+	var config *internalapi.NodeConfig
+	fcs.fieldSources = map[string][]string{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		dropIn, err := decodeNodeConfig(data, true)
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", path, err)
+		}
+		if err := fcs.recordFieldSources(path, data); err != nil {
+			return nil, fmt.Errorf("reading fields from %s: %w", path, err)
+		}
+		if config == nil {
+			config = dropIn
+			continue
+		}
+		if err := config.Merge(dropIn); err != nil {
+			return nil, fmt.Errorf("merging %s: %w", path, err)
+		}
+	}
 	return config, nil
 }
+
+// FieldSources returns, for each field path set in a config directory, the files
+// that set it in merge order. The last file wins, except for fields merged
+// across files like the kubelet flags.
+func (fcs *fileConfigProvider) FieldSources() map[string][]string {
+	return fcs.fieldSources
+}
+
+func (fcs *fileConfigProvider) recordFieldSources(path string, data []byte) error {
+	var document map[string]interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return err
+	}
+	for _, field := range leafFieldPaths("", document) {
+		fcs.fieldSources[field] = append(fcs.fieldSources[field], path)
+	}
+	return nil
+}
+
+// leafFieldPaths returns the dot separated paths to every non object value in the document.
+func leafFieldPaths(prefix string, document map[string]interface{}) []string {
+	var paths []string
+	for key, value := range document {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			paths = append(paths, leafFieldPaths(path, nested)...)
+		} else {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,65 @@
+package configprovider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func writeDropIns(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestFileConfigProviderDirectory(t *testing.T) {
+	g := NewWithT(t)
+	dir := writeDropIns(t, map[string]string{
+		// written out of order to make sure files are merged lexically
+		"20-host.yaml": partialNodeConfig,
+		"10-base.yaml": completeNodeConfig,
+		"README.md":    "not a config",
+	})
+
+	provider, err := BuildConfigProvider("file://" + dir + "/")
+	g.Expect(err).NotTo(HaveOccurred())
+	config, err := provider.Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(config.Spec).To(Equal(completeMergedWithPartial.Spec))
+
+	reporter, ok := provider.(FieldSourceReporter)
+	g.Expect(ok).To(BeTrue())
+	base := filepath.Join(dir, "10-base.yaml")
+	host := filepath.Join(dir, "20-host.yaml")
+	g.Expect(reporter.FieldSources()).To(HaveKeyWithValue("spec.cluster.name", []string{base}))
+	g.Expect(reporter.FieldSources()).To(HaveKeyWithValue("spec.kubelet.config.port", []string{base}))
+	g.Expect(reporter.FieldSources()).To(HaveKeyWithValue("spec.kubelet.config.maxPods", []string{base, host}))
+	g.Expect(reporter.FieldSources()).To(HaveKeyWithValue("spec.kubelet.config.podsPerCore", []string{host}))
+	g.Expect(reporter.FieldSources()).To(HaveKeyWithValue("spec.kubelet.flags", []string{base, host}))
+}
+
+func TestFileConfigProviderEmptyDirectory(t *testing.T) {
+	g := NewWithT(t)
+	dir := writeDropIns(t, map[string]string{"README.md": "not a config"})
+
+	_, err := NewFileConfigProvider(dir).Provide()
+	g.Expect(err).To(MatchError(ContainSubstring("no *.yaml files found")))
+}
+
+func TestFileConfigProviderDirectoryInvalidFile(t *testing.T) {
+	g := NewWithT(t)
+	dir := writeDropIns(t, map[string]string{
+		"10-base.yaml": completeNodeConfig,
+		"20-bad.yaml":  "spec:\n  unknownField: true\n",
+	})
+
+	_, err := NewFileConfigProvider(dir).Provide()
+	g.Expect(err).To(MatchError(ContainSubstring("20-bad.yaml")))
+}
@@ -0,0 +1,90 @@
+package configprovider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+)
+
+const (
+	guestInfoUserDataKey         = "guestinfo.userdata"
+	guestInfoUserDataEncodingKey = "guestinfo.userdata.encoding"
+)
+
+// vmwareRPCTool is the open-vm-tools binary used to read guestinfo variables.
+var vmwareRPCTool = "vmware-rpctool"
+
+type guestInfoConfigProvider struct{}
+
+// NewGuestInfoConfigProvider returns a ConfigProvider that reads the user data from
+// the VMware guestinfo.userdata variable, decoded as set in guestinfo.userdata.encoding.
+func NewGuestInfoConfigProvider() ConfigProvider {
+	return &guestInfoConfigProvider{}
+}
+
+func (gcp *guestInfoConfigProvider) Provide() (*internalapi.NodeConfig, error) {
+	rawUserData, err := getGuestInfo(guestInfoUserDataKey)
+	if err != nil {
+		return nil, err
+	}
+	if rawUserData == "" {
+		return nil, fmt.Errorf("%s is empty", guestInfoUserDataKey)
+	}
+	// the encoding is optional, in which case the user data is not encoded
+	encoding, err := getGuestInfo(guestInfoUserDataEncodingKey)
+	if err != nil && !errors.Is(err, errGuestInfoNotSet) {
+		return nil, err
+	}
+	userData, err := decodeGuestInfo(rawUserData, encoding)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding %s", guestInfoUserDataKey)
+	}
+	return parseUserData(userData)
+}
+
+// errGuestInfoNotSet is returned by getGuestInfo for variables that are not set.
+var errGuestInfoNotSet = errors.New("guestinfo variable is not set")
+
+func getGuestInfo(key string) (string, error) {
+	out, err := exec.Command(vmwareRPCTool, "info-get "+key).Output()
+	if err != nil {
+		// vmware-rpctool exits with 1 and "No value found" for variables that are not set
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && strings.Contains(string(exitErr.Stderr), "No value found") {
+			return "", errors.Wrapf(errGuestInfoNotSet, "reading %s", key)
+		}
+		return "", errors.Wrapf(err, "reading %s with %s", key, vmwareRPCTool)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// decodeGuestInfo decodes a guestinfo value with the encodings supported by cloud-init.
+func decodeGuestInfo(value, encoding string) ([]byte, error) {
+	switch strings.ToLower(encoding) {
+	case "":
+		return []byte(value), nil
+	case "base64", "b64":
+		return base64.StdEncoding.DecodeString(value)
+	case "gzip+base64", "gz+b64":
+		compressed, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, err
+		}
+		reader, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", encoding)
+	}
+}
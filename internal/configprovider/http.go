@@ -0,0 +1,40 @@
+package configprovider
+
+import (
+	"context"
+	"net/url"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+type httpConfigProvider struct {
+	url  string
+	opts remoteSourceOptions
+}
+
+// NewHTTPConfigProvider returns a ConfigProvider that downloads the node config from
+// an http(s) URL. The URL fragment can pin the config digest with `sha256=<hex>` and
+// set the trusted CAs with `ca-bundle=<path>`.
+func NewHTTPConfigProvider(sourceURL *url.URL) (ConfigProvider, error) {
+	opts, err := parseRemoteSourceOptions(sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	return &httpConfigProvider{
+		url:  withoutFragment(sourceURL),
+		opts: opts,
+	}, nil
+}
+
+func (hcp *httpConfigProvider) Provide() (*internalapi.NodeConfig, error) {
+	httpOpts, err := hcp.opts.httpOptions()
+	if err != nil {
+		return nil, err
+	}
+	data, err := util.GetHttpFile(context.Background(), hcp.url, httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return hcp.opts.decode(data)
+}
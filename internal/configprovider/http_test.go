@@ -0,0 +1,143 @@
+package configprovider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/test"
+)
+
+func serveNodeConfig(t *testing.T, tls bool) test.TestServer {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write([]byte(completeNodeConfig)); err != nil {
+			t.Error(err)
+		}
+	}
+	if tls {
+		return test.NewHTTPSServer(t, handler)
+	}
+	return test.NewHTTPServer(t, handler)
+}
+
+func TestHTTPConfigProvider(t *testing.T) {
+	g := NewWithT(t)
+	server := serveNodeConfig(t, false)
+
+	provider, err := BuildConfigProvider(server.URL + "/config.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	config, err := provider.Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Cluster.Name).To(Equal("autofill"))
+}
+
+func TestHTTPConfigProviderSHA256(t *testing.T) {
+	server := serveNodeConfig(t, false)
+	digest := sha256.Sum256([]byte(completeNodeConfig))
+
+	testCases := []struct {
+		name        string
+		fragment    string
+		expectedErr string
+	}{
+		{
+			name:     "matching digest",
+			fragment: "sha256=" + hex.EncodeToString(digest[:]),
+		},
+		{
+			name:        "mismatched digest",
+			fragment:    "sha256=" + hex.EncodeToString(make([]byte, sha256.Size)),
+			expectedErr: "config source sha256 mismatch",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			provider, err := BuildConfigProvider(server.URL + "/config.yaml#" + tc.fragment)
+			g.Expect(err).NotTo(HaveOccurred())
+			_, err = provider.Provide()
+			if tc.expectedErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedErr)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestHTTPSConfigProviderCABundle(t *testing.T) {
+	g := NewWithT(t)
+	server := serveNodeConfig(t, true)
+
+	provider, err := BuildConfigProvider(server.URL + "/config.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = provider.Provide()
+	g.Expect(err).To(HaveOccurred(), "server certificate should not be trusted by default")
+
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	g.Expect(os.WriteFile(caBundle, server.CAPEM(), 0o644)).To(Succeed())
+	provider, err = BuildConfigProvider(server.URL + "/config.yaml#ca-bundle=" + caBundle)
+	g.Expect(err).NotTo(HaveOccurred())
+	config, err := provider.Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Cluster.Name).To(Equal("autofill"))
+}
+
+func TestParseRemoteSourceOptions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		source      string
+		expected    remoteSourceOptions
+		expectedErr string
+	}{
+		{
+			name:   "no options",
+			source: "https://example.com/config.yaml",
+		},
+		{
+			name:   "all options",
+			source: "s3://bucket/key#region=us-west-2&ca-bundle=/etc/ca.pem&sha256=" + hex.EncodeToString(make([]byte, sha256.Size)),
+			expected: remoteSourceOptions{
+				sha256:       hex.EncodeToString(make([]byte, sha256.Size)),
+				caBundlePath: "/etc/ca.pem",
+				region:       "us-west-2",
+			},
+		},
+		{
+			name:        "unknown option",
+			source:      "https://example.com/config.yaml#foo=bar",
+			expectedErr: "unsupported config source option: foo",
+		},
+		{
+			name:        "invalid digest",
+			source:      "https://example.com/config.yaml#sha256=abc",
+			expectedErr: "invalid sha256 option",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			sourceURL, err := url.Parse(tc.source)
+			g.Expect(err).NotTo(HaveOccurred())
+			opts, err := parseRemoteSourceOptions(sourceURL)
+			if tc.expectedErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(opts).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestS3ConfigProviderInvalidURL(t *testing.T) {
+	g := NewWithT(t)
+	_, err := BuildConfigProvider("s3://bucket")
+	g.Expect(err).To(MatchError(ContainSubstring("expected s3://bucket/key")))
+}
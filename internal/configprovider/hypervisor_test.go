@@ -0,0 +1,182 @@
+package configprovider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNoCloudConfigProvider(t *testing.T) {
+	g := NewWithT(t)
+	seedDir := t.TempDir()
+	userData := mimeifyNodeConfigs(completeNodeConfig, partialNodeConfig)
+	g.Expect(os.WriteFile(filepath.Join(seedDir, "user-data"), []byte(userData), 0o644)).To(Succeed())
+
+	provider, err := BuildConfigProvider("nocloud://" + seedDir)
+	g.Expect(err).NotTo(HaveOccurred())
+	config, err := provider.Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config).To(Equal(&completeMergedWithPartial))
+}
+
+func TestNoCloudConfigProviderSearchesSeedDirs(t *testing.T) {
+	g := NewWithT(t)
+	seedDir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(seedDir, "user-data"), []byte(completeNodeConfig), 0o644)).To(Succeed())
+
+	oldSeedDirs, oldDiskByLabelDir := nocloudSeedDirs, diskByLabelDir
+	t.Cleanup(func() { nocloudSeedDirs, diskByLabelDir = oldSeedDirs, oldDiskByLabelDir })
+	diskByLabelDir = t.TempDir()
+
+	nocloudSeedDirs = []string{filepath.Join(t.TempDir(), "missing"), seedDir}
+	config, err := NewNoCloudConfigProvider("").Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Cluster.Name).To(Equal("autofill"))
+
+	nocloudSeedDirs = []string{filepath.Join(t.TempDir(), "missing")}
+	_, err = NewNoCloudConfigProvider("").Provide()
+	g.Expect(err).To(MatchError(ContainSubstring("no NoCloud seed found")))
+}
+
+// fakeRPCTool replaces vmware-rpctool with a script that answers info-get
+// requests from the given guestinfo variables.
+func fakeRPCTool(t *testing.T, guestInfo map[string]string) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\ncase \"$1\" in\n"
+	for key, value := range guestInfo {
+		valueFile := filepath.Join(dir, key)
+		if err := os.WriteFile(valueFile, []byte(value), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		script += fmt.Sprintf("  \"info-get %s\") cat %q ;;\n", key, valueFile)
+	}
+	script += "  *) echo 'No value found' >&2; exit 1 ;;\nesac\n"
+	tool := filepath.Join(dir, "vmware-rpctool")
+	if err := os.WriteFile(tool, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	oldRPCTool := vmwareRPCTool
+	vmwareRPCTool = tool
+	t.Cleanup(func() { vmwareRPCTool = oldRPCTool })
+}
+
+func TestGuestInfoConfigProvider(t *testing.T) {
+	g := NewWithT(t)
+	fakeRPCTool(t, map[string]string{
+		guestInfoUserDataKey:         base64.StdEncoding.EncodeToString([]byte(mimeifyNodeConfigs(completeNodeConfig, partialNodeConfig))),
+		guestInfoUserDataEncodingKey: "base64",
+	})
+
+	provider, err := BuildConfigProvider("guestinfo://userdata")
+	g.Expect(err).NotTo(HaveOccurred())
+	config, err := provider.Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config).To(Equal(&completeMergedWithPartial))
+}
+
+func TestDecodeGuestInfo(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, _ = writer.Write([]byte(completeNodeConfig))
+	_ = writer.Close()
+
+	testCases := []struct {
+		name        string
+		value       string
+		encoding    string
+		expectedErr string
+	}{
+		{name: "no encoding", value: completeNodeConfig},
+		{name: "base64", value: base64.StdEncoding.EncodeToString([]byte(completeNodeConfig)), encoding: "base64"},
+		{name: "b64", value: base64.StdEncoding.EncodeToString([]byte(completeNodeConfig)), encoding: "b64"},
+		{name: "gzip+base64", value: base64.StdEncoding.EncodeToString(compressed.Bytes()), encoding: "gzip+base64"},
+		{name: "gz+b64", value: base64.StdEncoding.EncodeToString(compressed.Bytes()), encoding: "gz+b64"},
+		{name: "unsupported encoding", value: completeNodeConfig, encoding: "rot13", expectedErr: "unsupported encoding: rot13"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			decoded, err := decodeGuestInfo(tc.value, tc.encoding)
+			if tc.expectedErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(decoded)).To(Equal(completeNodeConfig))
+		})
+	}
+}
+
+func ovfEnvironmentDocument(userData string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<Environment xmlns="http://schemas.dmtf.org/ovf/environment/1"
+     xmlns:oe="http://schemas.dmtf.org/ovf/environment/1"
+     oe:id="">
+   <PropertySection>
+         <Property oe:key="hostname" oe:value="hybrid-node-1"/>
+         <Property oe:key="user-data" oe:value="%s"/>
+   </PropertySection>
+</Environment>`, base64.StdEncoding.EncodeToString([]byte(userData)))
+}
+
+func TestOVFConfigProvider(t *testing.T) {
+	g := NewWithT(t)
+	envPath := filepath.Join(t.TempDir(), "ovf-env.xml")
+	g.Expect(os.WriteFile(envPath, []byte(ovfEnvironmentDocument(mimeifyNodeConfigs(completeNodeConfig, partialNodeConfig))), 0o644)).To(Succeed())
+
+	provider, err := BuildConfigProvider("ovf://" + envPath)
+	g.Expect(err).NotTo(HaveOccurred())
+	config, err := provider.Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config).To(Equal(&completeMergedWithPartial))
+}
+
+func TestOVFConfigProviderFromGuestInfo(t *testing.T) {
+	g := NewWithT(t)
+	fakeRPCTool(t, map[string]string{
+		guestInfoOVFEnvKey: ovfEnvironmentDocument(completeNodeConfig),
+	})
+
+	config, err := NewOVFConfigProvider("").Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Cluster.Name).To(Equal("autofill"))
+}
+
+func TestGetOVFUserDataMissingProperty(t *testing.T) {
+	g := NewWithT(t)
+	_, err := getOVFUserData([]byte(`<Environment><PropertySection><Property key="hostname" value="node"/></PropertySection></Environment>`))
+	g.Expect(err).To(MatchError(ContainSubstring("does not have a user-data property")))
+}
+
+func TestGuestInfoConfigProviderWithoutEncoding(t *testing.T) {
+	g := NewWithT(t)
+	fakeRPCTool(t, map[string]string{
+		guestInfoUserDataKey: completeNodeConfig,
+	})
+
+	config, err := NewGuestInfoConfigProvider().Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Cluster.Name).NotTo(BeEmpty())
+}
+
+func TestGuestInfoConfigProviderEncodingError(t *testing.T) {
+	g := NewWithT(t)
+	fakeRPCTool(t, map[string]string{
+		guestInfoUserDataKey: completeNodeConfig,
+	})
+	// the tool fails for the encoding without reporting it's not set
+	script, err := os.ReadFile(vmwareRPCTool)
+	g.Expect(err).NotTo(HaveOccurred())
+	script = []byte(strings.Replace(string(script), "case \"$1\" in\n", "case \"$1\" in\n  \"info-get "+guestInfoUserDataEncodingKey+"\") echo 'permission denied' >&2; exit 1 ;;\n", 1))
+	g.Expect(os.WriteFile(vmwareRPCTool, script, 0o755)).To(Succeed())
+
+	_, err = NewGuestInfoConfigProvider().Provide()
+	g.Expect(err).To(MatchError(ContainSubstring("reading " + guestInfoUserDataEncodingKey + " with")))
+}
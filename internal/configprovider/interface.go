@@ -7,3 +7,26 @@ type ConfigProvider interface {
 	// Provide returns the internal version of the source configuration
 	Provide() (*internalapi.NodeConfig, error)
 }
+
+// FieldSourceReporter is implemented by providers that assemble the configuration
+// from several documents and can report which document set each field.
+type FieldSourceReporter interface {
+	// FieldSources maps the dot separated path of every field set by the
+	// source documents to the documents that set it, in merge order.
+	FieldSources() map[string][]string
+}
+
+// FieldLocation is the position of a field in a config file.
+type FieldLocation struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// FieldLocator is implemented by providers that read the configuration from files
+// and can point to where a field is set.
+type FieldLocator interface {
+	// LocateField returns the location of the field with the given dot separated path.
+	// If the field is not set, it returns the location of its closest parent that is.
+	LocateField(path string) (FieldLocation, bool)
+}
@@ -0,0 +1,174 @@
+package configprovider
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/api"
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+	apibridge "github.com/aws/eks-hybrid/internal/api/bridge"
+)
+
+// Annotations on the items of a NodeConfigList that select the machine an item
+// applies to. An item with several selectors only matches when all of them match.
+// An item without selectors is the base that the matching item is merged over.
+const (
+	HostnameSelectorAnnotation   = api.GroupName + "/hostname"
+	MACAddressSelectorAnnotation = api.GroupName + "/mac-address"
+	MachineIDSelectorAnnotation  = api.GroupName + "/machine-id"
+	DMISerialSelectorAnnotation  = api.GroupName + "/dmi-serial"
+)
+
+var (
+	machineIDPath = "/etc/machine-id"
+	dmiSerialPath = "/sys/class/dmi/id/product_serial"
+
+	// getHostIdentity is a variable so tests can fake the machine identity.
+	getHostIdentity = readHostIdentity
+)
+
+// hostIdentity holds the attributes used to select an item from a NodeConfigList.
+type hostIdentity struct {
+	hostname     string
+	macAddresses []string
+	machineID    string
+	dmiSerial    string
+}
+
+// decodeNodeConfig decodes data holding either a NodeConfig or a NodeConfigList.
+// For a list, the item matching the current machine is merged over the base item.
+func decodeNodeConfig(data []byte, strict bool) (*internalapi.NodeConfig, error) {
+	if !apibridge.IsNodeConfigList(data) {
+		if strict {
+			return apibridge.DecodeStrictNodeConfig(data)
+		}
+		return apibridge.DecodeNodeConfig(data)
+	}
+	var list *internalapi.NodeConfigList
+	var err error
+	if strict {
+		list, err = apibridge.DecodeStrictNodeConfigList(data)
+	} else {
+		list, err = apibridge.DecodeNodeConfigList(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	identity, err := getHostIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return selectNodeConfig(list, identity)
+}
+
+func selectNodeConfig(list *internalapi.NodeConfigList, identity hostIdentity) (*internalapi.NodeConfig, error) {
+	var base, match *internalapi.NodeConfig
+	var matchIndex int
+	for i := range list.Items {
+		item := &list.Items[i]
+		selectors := itemSelectors(item)
+		if len(selectors) == 0 {
+			if base != nil {
+				return nil, fmt.Errorf("NodeConfigList has more than one item without selectors, only one base item is allowed")
+			}
+			base = item
+			continue
+		}
+		if !identity.matches(selectors) {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("NodeConfigList has more than one item matching this machine: %s and %s", describeItem(match, matchIndex), describeItem(item, i))
+		}
+		match = item
+		matchIndex = i
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no NodeConfigList item matches this machine (hostname: %s, mac addresses: %v, machine-id: %s, dmi serial: %s)",
+			identity.hostname, identity.macAddresses, identity.machineID, identity.dmiSerial)
+	}
+	zap.L().Info("Selected NodeConfigList item for this machine", zap.Any("selectors", itemSelectors(match)))
+	if base == nil {
+		return match, nil
+	}
+	config := base.DeepCopy()
+	if err := config.Merge(match); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func itemSelectors(item *internalapi.NodeConfig) map[string]string {
+	selectors := map[string]string{}
+	for _, key := range []string{HostnameSelectorAnnotation, MACAddressSelectorAnnotation, MachineIDSelectorAnnotation, DMISerialSelectorAnnotation} {
+		if value, ok := item.Annotations[key]; ok {
+			selectors[key] = value
+		}
+	}
+	return selectors
+}
+
+func describeItem(item *internalapi.NodeConfig, index int) string {
+	if item.Name != "" {
+		return item.Name
+	}
+	return fmt.Sprintf("item %d", index)
+}
+
+func (h hostIdentity) matches(selectors map[string]string) bool {
+	for key, value := range selectors {
+		var matched bool
+		switch key {
+		case HostnameSelectorAnnotation:
+			matched = strings.EqualFold(h.hostname, value)
+		case MACAddressSelectorAnnotation:
+			for _, mac := range h.macAddresses {
+				if strings.EqualFold(mac, value) {
+					matched = true
+					break
+				}
+			}
+		case MachineIDSelectorAnnotation:
+			matched = h.machineID != "" && h.machineID == value
+		case DMISerialSelectorAnnotation:
+			matched = h.dmiSerial != "" && h.dmiSerial == value
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func readHostIdentity() (hostIdentity, error) {
+	var identity hostIdentity
+	hostname, err := os.Hostname()
+	if err != nil {
+		return identity, fmt.Errorf("reading hostname: %w", err)
+	}
+	identity.hostname = hostname
+
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return identity, fmt.Errorf("listing network interfaces: %w", err)
+	}
+	for _, iface := range interfaces {
+		if len(iface.HardwareAddr) > 0 {
+			identity.macAddresses = append(identity.macAddresses, iface.HardwareAddr.String())
+		}
+	}
+
+	// machine-id and the DMI serial are not available on every machine, and the DMI
+	// serial is only readable by root, so these are best effort.
+	if machineID, err := os.ReadFile(machineIDPath); err == nil {
+		identity.machineID = strings.TrimSpace(string(machineID))
+	}
+	if dmiSerial, err := os.ReadFile(dmiSerialPath); err == nil {
+		identity.dmiSerial = strings.TrimSpace(string(dmiSerial))
+	}
+	return identity, nil
+}
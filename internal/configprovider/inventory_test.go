@@ -0,0 +1,127 @@
+package configprovider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const nodeConfigInventory = `---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfigList
+items:
+  - apiVersion: node.eks.aws/v1alpha1
+    kind: NodeConfig
+    spec:
+      cluster:
+        name: my-cluster
+        region: us-west-2
+      hybrid:
+        iamRolesAnywhere:
+          trustAnchorArn: arn:aws:rolesanywhere:us-west-2:123456789010:trust-anchor/anchor
+          profileArn: arn:aws:rolesanywhere:us-west-2:123456789010:profile/profile
+          roleArn: arn:aws:iam::123456789010:role/role
+      kubelet:
+        flags:
+          - --v=2
+  - apiVersion: node.eks.aws/v1alpha1
+    kind: NodeConfig
+    metadata:
+      name: rack1-node1
+      annotations:
+        node.eks.aws/hostname: rack1-node1
+    spec:
+      hybrid:
+        iamRolesAnywhere:
+          nodeName: rack1-node1
+          certificatePath: /etc/iam/pki/rack1-node1.pem
+      kubelet:
+        flags:
+          - --node-labels=rack=rack1
+  - apiVersion: node.eks.aws/v1alpha1
+    kind: NodeConfig
+    metadata:
+      name: rack2-node1
+      annotations:
+        node.eks.aws/mac-address: 0A:1B:2C:3D:4E:5F
+        node.eks.aws/machine-id: 2a8d0c4c5b2f4c7f9b1e6d3a0f1e2d3c
+    spec:
+      hybrid:
+        iamRolesAnywhere:
+          nodeName: rack2-node1
+`
+
+func fakeHostIdentity(t *testing.T, identity hostIdentity) {
+	oldGetHostIdentity := getHostIdentity
+	getHostIdentity = func() (hostIdentity, error) { return identity, nil }
+	t.Cleanup(func() { getHostIdentity = oldGetHostIdentity })
+}
+
+func TestNodeConfigListSelectsByHostname(t *testing.T) {
+	g := NewWithT(t)
+	fakeHostIdentity(t, hostIdentity{hostname: "rack1-node1"})
+	path := filepath.Join(t.TempDir(), "inventory.yaml")
+	g.Expect(os.WriteFile(path, []byte(nodeConfigInventory), 0o644)).To(Succeed())
+
+	config, err := NewFileConfigProvider(path).Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Cluster.Name).To(Equal("my-cluster"))
+	g.Expect(config.Spec.Hybrid.IAMRolesAnywhere.NodeName).To(Equal("rack1-node1"))
+	g.Expect(config.Spec.Hybrid.IAMRolesAnywhere.CertificatePath).To(Equal("/etc/iam/pki/rack1-node1.pem"))
+	g.Expect(config.Spec.Hybrid.IAMRolesAnywhere.RoleARN).To(Equal("arn:aws:iam::123456789010:role/role"))
+	g.Expect(config.Spec.Kubelet.Flags).To(Equal([]string{"--v=2", "--node-labels=rack=rack1"}))
+}
+
+func TestNodeConfigListSelectsByAllSelectors(t *testing.T) {
+	g := NewWithT(t)
+	fakeHostIdentity(t, hostIdentity{
+		hostname:     "localhost",
+		macAddresses: []string{"00:00:00:00:00:01", "0a:1b:2c:3d:4e:5f"},
+		machineID:    "2a8d0c4c5b2f4c7f9b1e6d3a0f1e2d3c",
+	})
+
+	config, err := decodeNodeConfig([]byte(nodeConfigInventory), false)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Hybrid.IAMRolesAnywhere.NodeName).To(Equal("rack2-node1"))
+	g.Expect(config.Spec.Cluster.Name).To(Equal("my-cluster"))
+
+	fakeHostIdentity(t, hostIdentity{
+		hostname:     "localhost",
+		macAddresses: []string{"0a:1b:2c:3d:4e:5f"},
+		machineID:    "another-machine",
+	})
+	_, err = decodeNodeConfig([]byte(nodeConfigInventory), true)
+	g.Expect(err).To(MatchError(ContainSubstring("no NodeConfigList item matches this machine")))
+}
+
+func TestNodeConfigListRejectsAmbiguousItems(t *testing.T) {
+	g := NewWithT(t)
+	fakeHostIdentity(t, hostIdentity{hostname: "node"})
+	inventory := `---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfigList
+items:
+  - metadata:
+      name: first
+      annotations:
+        node.eks.aws/hostname: node
+  - metadata:
+      name: second
+      annotations:
+        node.eks.aws/hostname: NODE
+`
+	_, err := decodeNodeConfig([]byte(inventory), true)
+	g.Expect(err).To(MatchError(ContainSubstring("more than one item matching this machine: first and second")))
+
+	inventory = `---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfigList
+items:
+  - spec: {}
+  - spec: {}
+`
+	_, err = decodeNodeConfig([]byte(inventory), true)
+	g.Expect(err).To(MatchError(ContainSubstring("only one base item is allowed")))
+}
@@ -0,0 +1,147 @@
+package configprovider
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aws/eks-hybrid/api"
+)
+
+// LocateField searches the config files, latest in merge order first, for the field.
+func (fcs *fileConfigProvider) LocateField(path string) (FieldLocation, bool) {
+	files, err := fcs.files()
+	if err != nil {
+		return FieldLocation{}, false
+	}
+	documents := make([]*yaml.Node, len(files))
+	for i, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var document yaml.Node
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			continue
+		}
+		documents[i] = &document
+	}
+
+	// look for the field itself first and only then for its parents, so a
+	// parent set in a later drop-in doesn't hide the field set in an earlier one
+	segments := strings.Split(path, ".")
+	for depth := len(segments); depth > 0; depth-- {
+		for i := len(files) - 1; i >= 0; i-- {
+			if documents[i] == nil {
+				continue
+			}
+			if node := findField(documents[i], segments[:depth]); node != nil {
+				return FieldLocation{File: files[i], Line: node.Line, Column: node.Column}, true
+			}
+		}
+	}
+	return FieldLocation{}, false
+}
+
+// files returns the config files read by the provider in merge order.
+func (fcs *fileConfigProvider) files() ([]string, error) {
+	info, err := os.Stat(fcs.path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{fcs.path}, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(fcs.path, dropInFilePattern))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// findField returns the key node of the field at path in a NodeConfig document.
+// For a NodeConfigList it searches the item selected for this machine first and
+// then the base item, since the selected item overrides the base one.
+func findField(document *yaml.Node, path []string) *yaml.Node {
+	root := document
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if kind := mappingValue(root, "kind"); kind == nil || kind.Value != api.KindNodeConfigList {
+		return findKey(root, path)
+	}
+	items := mappingValue(root, "items")
+	if items == nil || items.Kind != yaml.SequenceNode {
+		return nil
+	}
+	identity, err := getHostIdentity()
+	if err != nil {
+		return nil
+	}
+	var base []*yaml.Node
+	for _, item := range items.Content {
+		selectors := itemNodeSelectors(item)
+		if len(selectors) == 0 {
+			base = append(base, item)
+			continue
+		}
+		if !identity.matches(selectors) {
+			continue
+		}
+		if key := findKey(item, path); key != nil {
+			return key
+		}
+	}
+	for _, item := range base {
+		if key := findKey(item, path); key != nil {
+			return key
+		}
+	}
+	return nil
+}
+
+// itemNodeSelectors returns the selector annotations of a NodeConfigList item.
+func itemNodeSelectors(item *yaml.Node) map[string]string {
+	selectors := map[string]string{}
+	annotations := mappingValue(mappingValue(item, "metadata"), "annotations")
+	for _, key := range []string{HostnameSelectorAnnotation, MACAddressSelectorAnnotation, MachineIDSelectorAnnotation, DMISerialSelectorAnnotation} {
+		if value := mappingValue(annotations, key); value != nil {
+			selectors[key] = value.Value
+		}
+	}
+	return selectors
+}
+
+func findKey(node *yaml.Node, path []string) *yaml.Node {
+	var key *yaml.Node
+	for _, segment := range path {
+		key, node = mappingEntry(node, segment)
+		if key == nil {
+			return nil
+		}
+	}
+	return key
+}
+
+// mappingValue returns the value of key in a mapping node.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	_, value := mappingEntry(node, key)
+	return value
+}
+
+// mappingEntry returns the key and value nodes of key in a mapping node.
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
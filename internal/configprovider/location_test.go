@@ -0,0 +1,80 @@
+package configprovider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLocateField(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	g.Expect(os.WriteFile(path, []byte(`---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: my-cluster
+  hybrid:
+    iamRolesAnywhere:
+      roleArn: arn:aws:iam::123456789010:role/role
+`), 0o644)).To(Succeed())
+	provider := &fileConfigProvider{path: path}
+
+	location, ok := provider.LocateField("spec.hybrid.iamRolesAnywhere.roleArn")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(location).To(Equal(FieldLocation{File: path, Line: 9, Column: 7}))
+
+	// a missing field points to its closest parent
+	location, ok = provider.LocateField("spec.hybrid.iamRolesAnywhere.nodeName")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(location).To(Equal(FieldLocation{File: path, Line: 8, Column: 5}))
+
+	_, ok = provider.LocateField("metadata.name")
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestLocateFieldInDirectory(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "00-base.yaml"), []byte(`apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: my-cluster
+    region: us-west-2
+`), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "10-override.yaml"), []byte(`apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    region: us-east-1
+`), 0o644)).To(Succeed())
+	provider := &fileConfigProvider{path: dir}
+
+	location, ok := provider.LocateField("spec.cluster.region")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(location).To(Equal(FieldLocation{File: filepath.Join(dir, "10-override.yaml"), Line: 5, Column: 5}))
+
+	location, ok = provider.LocateField("spec.cluster.name")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(location).To(Equal(FieldLocation{File: filepath.Join(dir, "00-base.yaml"), Line: 5, Column: 5}))
+}
+
+func TestLocateFieldInNodeConfigList(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "inventory.yaml")
+	g.Expect(os.WriteFile(path, []byte(nodeConfigInventory), 0o644)).To(Succeed())
+	provider := &fileConfigProvider{path: path}
+	fakeHostIdentity(t, hostIdentity{hostname: "rack1-node1"})
+
+	location, ok := provider.LocateField("spec.hybrid.iamRolesAnywhere.nodeName")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(location).To(Equal(FieldLocation{File: path, Line: 28, Column: 11}))
+
+	location, ok = provider.LocateField("spec.hybrid.iamRolesAnywhere.roleArn")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(location).To(Equal(FieldLocation{File: path, Line: 15, Column: 11}))
+}
@@ -0,0 +1,97 @@
+package configprovider
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+)
+
+const nocloudUserDataFile = "user-data"
+
+var (
+	// nocloudSeedDirs are the directories where cloud-init looks for a local NoCloud seed.
+	nocloudSeedDirs = []string{
+		"/var/lib/cloud/seed/nocloud",
+		"/var/lib/cloud/seed/nocloud-net",
+	}
+	// nocloudVolumeLabels are the filesystem labels of a NoCloud seed volume.
+	nocloudVolumeLabels = []string{"cidata", "CIDATA"}
+	diskByLabelDir      = "/dev/disk/by-label"
+)
+
+type nocloudConfigProvider struct {
+	seedDir string
+}
+
+// NewNoCloudConfigProvider returns a ConfigProvider that reads the user data from a
+// cloud-init NoCloud seed. If seedDir is empty, the local seed directories are searched
+// first and then a volume labeled cidata is mounted read-only.
+func NewNoCloudConfigProvider(seedDir string) ConfigProvider {
+	return &nocloudConfigProvider{
+		seedDir: seedDir,
+	}
+}
+
+func (ncp *nocloudConfigProvider) Provide() (*internalapi.NodeConfig, error) {
+	userData, err := ncp.readUserData()
+	if err != nil {
+		return nil, err
+	}
+	return parseUserData(userData)
+}
+
+func (ncp *nocloudConfigProvider) readUserData() ([]byte, error) {
+	if ncp.seedDir != "" {
+		return os.ReadFile(filepath.Join(ncp.seedDir, nocloudUserDataFile))
+	}
+
+	for _, seedDir := range nocloudSeedDirs {
+		userDataPath := filepath.Join(seedDir, nocloudUserDataFile)
+		userData, err := os.ReadFile(userDataPath)
+		if err == nil {
+			zap.L().Info("Using NoCloud seed directory", zap.String("path", seedDir))
+			return userData, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	for _, label := range nocloudVolumeLabels {
+		device := filepath.Join(diskByLabelDir, label)
+		if _, err := os.Stat(device); err != nil {
+			continue
+		}
+		zap.L().Info("Using NoCloud seed volume", zap.String("device", device))
+		return readUserDataFromVolume(device)
+	}
+
+	return nil, fmt.Errorf("no NoCloud seed found in %v or on a volume labeled %v", nocloudSeedDirs, nocloudVolumeLabels)
+}
+
+// readUserDataFromVolume mounts the seed volume read-only in a temporary directory
+// and reads the user data file from it.
+func readUserDataFromVolume(device string) ([]byte, error) {
+	mountDir, err := os.MkdirTemp("", "nodeadm-cidata")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(mountDir)
+
+	if out, err := exec.Command("mount", "-o", "ro", device, mountDir).CombinedOutput(); err != nil {
+		return nil, errors.Wrapf(err, "mounting %s: %s", device, out)
+	}
+	defer func() {
+		if out, err := exec.Command("umount", mountDir).CombinedOutput(); err != nil {
+			zap.L().Warn("Failed to unmount NoCloud seed volume", zap.String("device", device), zap.ByteString("output", out), zap.Error(err))
+		}
+	}()
+
+	return os.ReadFile(filepath.Join(mountDir, nocloudUserDataFile))
+}
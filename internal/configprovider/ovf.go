@@ -0,0 +1,77 @@
+package configprovider
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"os"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+)
+
+const (
+	guestInfoOVFEnvKey = "guestinfo.ovfEnv"
+	ovfUserDataKey     = "user-data"
+)
+
+type ovfConfigProvider struct {
+	path string
+}
+
+// ovfEnvironment is the subset of the OVF environment document holding the properties.
+type ovfEnvironment struct {
+	Properties []ovfProperty `xml:"PropertySection>Property"`
+}
+
+type ovfProperty struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// NewOVFConfigProvider returns a ConfigProvider that reads the base64 encoded user-data
+// property from an OVF environment document. If path is empty, the document is read
+// from the VMware guestinfo.ovfEnv variable.
+func NewOVFConfigProvider(path string) ConfigProvider {
+	return &ovfConfigProvider{
+		path: path,
+	}
+}
+
+func (ocp *ovfConfigProvider) Provide() (*internalapi.NodeConfig, error) {
+	var envDocument []byte
+	if ocp.path != "" {
+		data, err := os.ReadFile(ocp.path)
+		if err != nil {
+			return nil, err
+		}
+		envDocument = data
+	} else {
+		data, err := getGuestInfo(guestInfoOVFEnvKey)
+		if err != nil {
+			return nil, err
+		}
+		envDocument = []byte(data)
+	}
+	userData, err := getOVFUserData(envDocument)
+	if err != nil {
+		return nil, err
+	}
+	return parseUserData(userData)
+}
+
+func getOVFUserData(envDocument []byte) ([]byte, error) {
+	var env ovfEnvironment
+	if err := xml.Unmarshal(envDocument, &env); err != nil {
+		return nil, fmt.Errorf("parsing OVF environment: %w", err)
+	}
+	for _, property := range env.Properties {
+		if property.Key == ovfUserDataKey {
+			userData, err := base64.StdEncoding.DecodeString(property.Value)
+			if err != nil {
+				return nil, fmt.Errorf("decoding OVF %s property: %w", ovfUserDataKey, err)
+			}
+			return userData, nil
+		}
+	}
+	return nil, fmt.Errorf("OVF environment does not have a %s property", ovfUserDataKey)
+}
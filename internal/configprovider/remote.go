@@ -0,0 +1,101 @@
+package configprovider
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+const (
+	sha256Param   = "sha256"
+	caBundleParam = "ca-bundle"
+	regionParam   = "region"
+)
+
+// remoteSourceOptions are the settings for remote config sources. They are
+// passed in the fragment of the source URL so they are never sent to the
+// server, e.g. `https://example.com/config.yaml#sha256=<hex>&ca-bundle=/etc/ca.pem`.
+type remoteSourceOptions struct {
+	// sha256 is the expected hex encoded sha256 digest of the config document.
+	sha256 string
+	// caBundlePath is a path to a PEM file with the CAs trusted to serve the config.
+	caBundlePath string
+	// region is the AWS region used to build S3 requests.
+	region string
+}
+
+func parseRemoteSourceOptions(sourceURL *url.URL) (remoteSourceOptions, error) {
+	var opts remoteSourceOptions
+	if sourceURL.Fragment == "" {
+		return opts, nil
+	}
+	values, err := url.ParseQuery(sourceURL.Fragment)
+	if err != nil {
+		return opts, fmt.Errorf("parsing config source options %q: %w", sourceURL.Fragment, err)
+	}
+	for key := range values {
+		switch key {
+		case sha256Param, caBundleParam, regionParam:
+		default:
+			return opts, fmt.Errorf("unsupported config source option: %s", key)
+		}
+	}
+	opts.sha256 = strings.ToLower(values.Get(sha256Param))
+	opts.caBundlePath = values.Get(caBundleParam)
+	opts.region = values.Get(regionParam)
+	if opts.sha256 != "" {
+		if digest, err := hex.DecodeString(opts.sha256); err != nil || len(digest) != sha256.Size {
+			return opts, fmt.Errorf("invalid %s option, must be a hex encoded sha256 digest: %s", sha256Param, opts.sha256)
+		}
+	}
+	return opts, nil
+}
+
+// httpOptions builds the options for the http client from the source options.
+func (o remoteSourceOptions) httpOptions() ([]util.HttpOption, error) {
+	if o.caBundlePath == "" {
+		return nil, nil
+	}
+	caBundle, err := os.ReadFile(o.caBundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA bundle: %w", err)
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("no valid certificates found in CA bundle %s", o.caBundlePath)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    certPool,
+		MinVersion: tls.VersionTLS12,
+	}
+	return []util.HttpOption{util.WithHttpClient(&http.Client{Transport: transport})}, nil
+}
+
+// decode verifies the pinned digest, if any, and decodes the config document.
+func (o remoteSourceOptions) decode(data []byte) (*internalapi.NodeConfig, error) {
+	if o.sha256 != "" {
+		digest := sha256.Sum256(data)
+		if actual := hex.EncodeToString(digest[:]); actual != o.sha256 {
+			return nil, fmt.Errorf("config source sha256 mismatch: expected %s, got %s", o.sha256, actual)
+		}
+	}
+	return decodeNodeConfig(data, true)
+}
+
+// withoutFragment returns the URL as a string without the fragment that holds the source options.
+func withoutFragment(sourceURL *url.URL) string {
+	u := *sourceURL
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
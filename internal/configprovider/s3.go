@@ -0,0 +1,73 @@
+package configprovider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+type s3ConfigProvider struct {
+	bucket string
+	key    string
+	opts   remoteSourceOptions
+}
+
+// NewS3ConfigProvider returns a ConfigProvider that downloads the node config from
+// an S3 object, addressed as `s3://bucket/key`. Credentials are resolved from the
+// default AWS credential chain. The request is presigned and fetched with the same
+// retrying http client used for http(s) sources, so the `sha256` and `ca-bundle`
+// fragment options apply too, plus `region=<region>` to set the bucket region.
+func NewS3ConfigProvider(sourceURL *url.URL) (ConfigProvider, error) {
+	opts, err := parseRemoteSourceOptions(sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	key := strings.TrimPrefix(sourceURL.Path, "/")
+	if sourceURL.Host == "" || key == "" {
+		return nil, fmt.Errorf("invalid s3 config source, expected s3://bucket/key: %s", withoutFragment(sourceURL))
+	}
+	return &s3ConfigProvider{
+		bucket: sourceURL.Host,
+		key:    key,
+		opts:   opts,
+	}, nil
+}
+
+func (scp *s3ConfigProvider) Provide() (*internalapi.NodeConfig, error) {
+	ctx := context.Background()
+	var loadOpts []func(*config.LoadOptions) error
+	if scp.opts.region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(scp.opts.region))
+	}
+	awsConfig, err := config.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config for s3 config source: %w", err)
+	}
+	if awsConfig.Region == "" {
+		return nil, fmt.Errorf("could not determine region for s3 config source, set it with the %s option: s3://%s/%s#%s=<region>", regionParam, scp.bucket, scp.key, regionParam)
+	}
+	presigned, err := s3.NewPresignClient(s3.NewFromConfig(awsConfig)).PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(scp.bucket),
+		Key:    aws.String(scp.key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("presigning s3 config source request: %w", err)
+	}
+	httpOpts, err := scp.opts.httpOptions()
+	if err != nil {
+		return nil, err
+	}
+	data, err := util.GetHttpFile(ctx, presigned.URL, httpOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("reading config from s3://%s/%s: %w", scp.bucket, scp.key, err)
+	}
+	return scp.opts.decode(data)
+}
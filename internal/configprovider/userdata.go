@@ -11,7 +11,6 @@ import (
 
 	"github.com/aws/eks-hybrid/api"
 	internalapi "github.com/aws/eks-hybrid/internal/api"
-	apibridge "github.com/aws/eks-hybrid/internal/api/bridge"
 	imds "github.com/aws/eks-hybrid/internal/aws/imds"
 )
 
@@ -33,6 +32,12 @@ func (ics *userDataConfigProvider) Provide() (*internalapi.NodeConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	return parseUserData(userData)
+}
+
+// parseUserData reads the node config from user data, which is either a MIME
+// multipart document with NodeConfig parts or a NodeConfig document on its own.
+func parseUserData(userData []byte) (*internalapi.NodeConfig, error) {
 	// if the MIME data fails to parse as a multipart document, then fall back
 	// to parsing the entire userdata as the node config.
 	if multipartReader, err := getMIMEMultipartReader(userData); err == nil {
@@ -42,7 +47,7 @@ func (ics *userDataConfigProvider) Provide() (*internalapi.NodeConfig, error) {
 		}
 		return config, nil
 	} else {
-		config, err := apibridge.DecodeNodeConfig(userData)
+		config, err := decodeNodeConfig(userData, false)
 		if err != nil {
 			return nil, err
 		}
@@ -85,7 +90,7 @@ func parseMultipart(userDataReader *multipart.Reader) (*internalapi.NodeConfig,
 				if err != nil {
 					return nil, err
 				}
-				decodedConfig, err := apibridge.DecodeNodeConfig(nodeConfigPart)
+				decodedConfig, err := decodeNodeConfig(nodeConfigPart, false)
 				if err != nil {
 					return nil, err
 				}
@@ -9,7 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/api"
-	"github.com/aws/eks-hybrid/internal/util"
+	"github.com/aws/eks-hybrid/internal/render"
 )
 
 const ContainerRuntimeEndpoint = "unix:///run/containerd/containerd.sock"
@@ -20,6 +20,7 @@ const (
 	containerdConfigImportDir         = "/etc/containerd/config.d"
 	containerdKernelModulesConfigFile = "/etc/modules-load.d/containerd.conf"
 	containerdConfigPerm              = 0o644
+	containerdUserConfigPerm          = 0o600 // the user config can hold registry credentials
 )
 
 var (
@@ -27,43 +28,54 @@ var (
 	containerdConfigTemplateData string
 	containerdConfigTemplate     = template.Must(template.New(containerdConfigFile).Parse(containerdConfigTemplateData))
 
+	// containerd 2.x uses config version 3, with the CRI plugin split into images and runtime plugins
+	//go:embed config.v3.template.toml
+	containerdConfigV3TemplateData string
+	containerdConfigV3Template     = template.Must(template.New(containerdConfigFile).Parse(containerdConfigV3TemplateData))
+
 	//go:embed kernel-modules.conf
 	containerdKernelModulesFileData string
 )
 
 type containerdTemplateVars struct {
 	SandboxImage string
+	Runtimes     []containerdRuntimeTemplateVars
 }
 
-func writeContainerdConfig(cfg *api.NodeConfig) error {
+func writeContainerdConfig(cfg *api.NodeConfig, containerdVersion string, writeFile render.WriteFileFunc) error {
 	// write nodeadm's generated containerd config to the default path
-	containerdConfig, err := generateContainerdConfig(cfg)
+	containerdConfig, err := generateContainerdConfig(cfg, containerdVersion)
 	if err != nil {
 		return err
 	}
 	zap.L().Info("Writing containerd config to file...", zap.String("path", containerdConfigFile))
-	if err := util.WriteFileWithDir(containerdConfigFile, containerdConfig, containerdConfigPerm); err != nil {
+	if err := writeFile(containerdConfigFile, containerdConfig, containerdConfigPerm); err != nil {
 		return err
 	}
 	if len(cfg.Spec.Containerd.Config) > 0 {
 		containerConfigImportPath := filepath.Join(containerdConfigImportDir, "00-nodeadm.toml")
 		zap.L().Info("Writing user containerd config to drop-in file...", zap.String("path", containerConfigImportPath))
-		return util.WriteFileWithDir(containerConfigImportPath, []byte(cfg.Spec.Containerd.Config), containerdConfigPerm)
+		return writeFile(containerConfigImportPath, []byte(cfg.Spec.Containerd.Config), containerdUserConfigPerm)
 	}
 	return nil
 }
 
-func generateContainerdConfig(cfg *api.NodeConfig) ([]byte, error) {
+func generateContainerdConfig(cfg *api.NodeConfig, containerdVersion string) ([]byte, error) {
 	configVars := containerdTemplateVars{
 		SandboxImage: cfg.Status.Defaults.SandboxImage,
+		Runtimes:     runtimeTemplateVars(cfg.Spec.Containerd.Runtimes),
+	}
+	configTemplate := containerdConfigTemplate
+	if containerdConfigVersion(containerdVersion) >= 3 {
+		configTemplate = containerdConfigV3Template
 	}
 	var buf bytes.Buffer
-	if err := containerdConfigTemplate.Execute(&buf, configVars); err != nil {
+	if err := configTemplate.Execute(&buf, configVars); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
 }
 
-func writeContainerdKernelModulesConfig() error {
-	return util.WriteFileWithDir(containerdKernelModulesConfigFile, []byte(containerdKernelModulesFileData), containerdConfigPerm)
+func writeContainerdKernelModulesConfig(writeFile render.WriteFileFunc) error {
+	return writeFile(containerdKernelModulesConfigFile, []byte(containerdKernelModulesFileData), containerdConfigPerm)
 }
@@ -0,0 +1,43 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/eks-hybrid/internal/api"
+)
+
+func sandboxImageNodeConfig() *api.NodeConfig {
+	return &api.NodeConfig{
+		Status: api.NodeConfigStatus{
+			Defaults: api.DefaultOptions{
+				SandboxImage: "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.5",
+			},
+		},
+	}
+}
+
+func TestGenerateContainerdConfigVersion2(t *testing.T) {
+	config, err := generateContainerdConfig(sandboxImageNodeConfig(), "v1.7.20")
+	assert.NoError(t, err)
+	assert.Contains(t, string(config), "version = 2\n")
+	assert.Contains(t, string(config), `sandbox_image = "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.5"`)
+	assert.Contains(t, string(config), `[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc.options]
+    SystemdCgroup = true`)
+}
+
+func TestGenerateContainerdConfigVersion3(t *testing.T) {
+	config, err := generateContainerdConfig(sandboxImageNodeConfig(), "v2.0.0")
+	assert.NoError(t, err)
+	assert.Contains(t, string(config), "version = 3\n")
+	assert.Contains(t, string(config), `imports = ["/etc/containerd/config.d/*.toml"]`)
+	assert.Contains(t, string(config), `[plugins."io.containerd.cri.v1.images".pinned_images]
+    sandbox = "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.5"`)
+	assert.Contains(t, string(config), `[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.runc.options]
+    SystemdCgroup = true`)
+	assert.NotContains(t, string(config), "io.containerd.grpc.v1.cri")
+	// the sandbox image must be found again once containerd dumps the config
+	matches := containerdSandboxImageRegex.FindStringSubmatch(string(config))
+	assert.Equal(t, "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.5", matches[1])
+}
@@ -3,6 +3,7 @@ package containerd
 import (
 	"context"
 	"fmt"
+	"os/exec"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -10,6 +11,9 @@ import (
 
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/render"
+	"github.com/aws/eks-hybrid/internal/secret"
+	"github.com/aws/eks-hybrid/internal/util"
 )
 
 const (
@@ -17,13 +21,18 @@ const (
 	kernelModulesSystemdUnit = "systemd-modules-load"
 )
 
-var _ daemon.Daemon = &containerd{}
+var (
+	_ daemon.Daemon   = &containerd{}
+	_ daemon.Renderer = &containerd{}
+)
 
 type containerd struct {
 	daemonManager daemon.DaemonManager
 	nodeConfig    *api.NodeConfig
 	awsConfig     *aws.Config
 	logger        *zap.Logger
+	// getVersion returns the version of the installed containerd.
+	getVersion func() (string, error)
 }
 
 func NewContainerdDaemon(daemonManager daemon.DaemonManager, cfg *api.NodeConfig, awsConfig *aws.Config, logger *zap.Logger) daemon.Daemon {
@@ -32,14 +41,57 @@ func NewContainerdDaemon(daemonManager daemon.DaemonManager, cfg *api.NodeConfig
 		nodeConfig:    cfg,
 		awsConfig:     awsConfig,
 		logger:        logger,
+		getVersion:    GetContainerdVersion,
 	}
 }
 
 func (cd *containerd) Configure(ctx context.Context) error {
-	if err := writeContainerdConfig(cd.nodeConfig); err != nil {
+	// registries removed from the node config shouldn't keep applying
+	if err := RemoveRegistryConfig(); err != nil {
+		return err
+	}
+	if err := verifyRuntimeBinaries(cd.nodeConfig.Spec.Containerd.Runtimes, exec.LookPath); err != nil {
+		return err
+	}
+	return cd.writeConfigFiles(util.WriteFileWithDir)
+}
+
+// Render returns the files Configure would write without writing them.
+func (cd *containerd) Render(ctx context.Context) ([]render.File, error) {
+	recorder := &render.Recorder{}
+	if err := cd.writeConfigFiles(recorder.WriteFile); err != nil {
+		return nil, err
+	}
+	return recorder.Files(), nil
+}
+
+func (cd *containerd) writeConfigFiles(writeFile render.WriteFileFunc) error {
+	// without containerd, like on build hosts rendering the config, the config for
+	// containerd 1.x is generated
+	containerdVersion, err := cd.getVersion()
+	if err != nil {
+		cd.logger.Warn("Couldn't detect the containerd version, generating config version 2", zap.Error(err))
+		containerdVersion = ""
+	} else {
+		cd.logger.Info("Detected containerd version", zap.String("version", containerdVersion))
+	}
+	if cd.nodeConfig.Spec.Containerd.Config != "" {
+		for _, warning := range UserConfigWarnings(cd.nodeConfig.Spec.Containerd.Config, containerdVersion) {
+			cd.logger.Warn("Problem found in user containerd config", zap.String("warning", warning))
+		}
+		mergedConfig, err := MergedConfig(cd.nodeConfig, containerdVersion)
+		if err != nil {
+			return err
+		}
+		cd.logger.Debug("Effective containerd config", zap.String("config", secret.Redact(mergedConfig)))
+	}
+	if err := writeContainerdConfig(cd.nodeConfig, containerdVersion, writeFile); err != nil {
+		return err
+	}
+	if err := writeRegistryConfig(cd.nodeConfig, writeFile); err != nil {
 		return err
 	}
-	return writeContainerdKernelModulesConfig()
+	return writeContainerdKernelModulesConfig(writeFile)
 }
 
 // EnsureRunning ensures containerd is running with the written configuration
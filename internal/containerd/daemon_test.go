@@ -0,0 +1,30 @@
+package containerd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/api"
+)
+
+func TestRenderWithoutContainerd(t *testing.T) {
+	g := NewWithT(t)
+	cd := &containerd{
+		nodeConfig: &api.NodeConfig{},
+		logger:     zap.NewNop(),
+		getVersion: func() (string, error) {
+			return "", errors.New("containerd: executable file not found in $PATH")
+		},
+	}
+
+	files, err := cd.Render(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(files).NotTo(BeEmpty())
+	g.Expect(files[0].Path).To(Equal(containerdConfigFile))
+	g.Expect(strings.HasPrefix(string(files[0].Content), "version = 2")).To(BeTrue(), string(files[0].Content))
+}
@@ -49,7 +49,7 @@ func Install(ctx context.Context, artifactsTracker *tracker.Tracker, source Sour
 		return errors.Wrap(err, "installing containerd")
 	}
 	artifactsTracker.Artifacts.Containerd = containerdSource
-	return nil
+	return artifactsTracker.Add(artifact.Containerd)
 }
 
 func Uninstall(ctx context.Context, source Source) error {
@@ -0,0 +1,300 @@
+package containerd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/render"
+	"github.com/aws/eks-hybrid/internal/secret"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+const (
+	// containerdCertsDir is the first directory of the registry config_path in the containerd config.
+	containerdCertsDir    = "/etc/containerd/certs.d"
+	registryHostsFile     = "hosts.toml"
+	registryHostsPerm     = 0o644
+	registryClientKeyPerm = 0o600
+
+	// defaultRegistryName is the name containerd looks up for registries without their own directory.
+	defaultRegistryName = "_default"
+
+	// registryHostsHeader marks the hosts.toml files nodeadm writes, so they can be told
+	// apart from the ones written by users when cleaning up.
+	registryHostsHeader = "# Generated by nodeadm from spec.containerd.registries, do not edit."
+)
+
+var (
+	registryCapabilities        = []api.RegistryCapability{api.RegistryCapabilityPull, api.RegistryCapabilityResolve, api.RegistryCapabilityPush}
+	defaultRegistryCapabilities = []api.RegistryCapability{api.RegistryCapabilityPull, api.RegistryCapabilityResolve}
+)
+
+// writeRegistryConfig writes a hosts.toml file for each registry in the node config,
+// along with the certificates it references. Registries with a hosts.toml written by
+// users are refused instead of overwritten.
+func writeRegistryConfig(cfg *api.NodeConfig, writeFile render.WriteFileFunc) error {
+	for _, registry := range cfg.Spec.Containerd.Registries {
+		dir := filepath.Join(containerdCertsDir, registry.Name)
+		if err := checkRegistryHostsOwner(filepath.Join(dir, registryHostsFile)); err != nil {
+			return err
+		}
+		hosts, err := generateRegistryHosts(dir, registry, writeFile)
+		if err != nil {
+			return fmt.Errorf("generating registry config for %s: %w", registry.Name, err)
+		}
+		hostsPath := filepath.Join(dir, registryHostsFile)
+		zap.L().Info("Writing containerd registry config to file...", zap.String("path", hostsPath))
+		if err := writeFile(hostsPath, hosts, registryHostsPerm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateRegistryHosts returns the hosts.toml content for registry, writing the
+// certificates of the upstream registry and its mirrors under dir.
+func generateRegistryHosts(dir string, registry api.ContainerdRegistry, writeFile render.WriteFileFunc) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(registryHostsHeader + "\n")
+	if registry.Server != "" {
+		fmt.Fprintf(&buf, "server = %s\n", strconv.Quote(registry.Server))
+	}
+	if err := writeRegistryTLS(&buf, "", dir, "", registry.TLS, writeFile); err != nil {
+		return nil, err
+	}
+
+	for i, mirror := range registry.Mirrors {
+		fmt.Fprintf(&buf, "\n[host.%s]\n", strconv.Quote(mirror.URL))
+		capabilities := mirror.Capabilities
+		if len(capabilities) == 0 {
+			capabilities = defaultRegistryCapabilities
+		}
+		quoted := make([]string, 0, len(capabilities))
+		for _, capability := range capabilities {
+			quoted = append(quoted, strconv.Quote(string(capability)))
+		}
+		fmt.Fprintf(&buf, "  capabilities = [%s]\n", strings.Join(quoted, ", "))
+		if mirror.OverridePath {
+			buf.WriteString("  override_path = true\n")
+		}
+		if err := writeRegistryTLS(&buf, "  ", dir, fmt.Sprintf("mirror-%d-", i), mirror.TLS, writeFile); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// writeRegistryTLS writes the certificates in tlsConfig to dir, with names starting
+// with prefix, and adds the settings referencing them to buf.
+func writeRegistryTLS(buf *bytes.Buffer, indent, dir, prefix string, tlsConfig *api.RegistryTLS, writeFile render.WriteFileFunc) error {
+	if tlsConfig == nil {
+		return nil
+	}
+	if tlsConfig.CACert != "" {
+		caPath := filepath.Join(dir, prefix+"ca.crt")
+		if err := writeFile(caPath, []byte(tlsConfig.CACert), registryHostsPerm); err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "%sca = %s\n", indent, strconv.Quote(caPath))
+	}
+	if tlsConfig.ClientCert != "" {
+		certPath := filepath.Join(dir, prefix+"client.cert")
+		keyPath := filepath.Join(dir, prefix+"client.key")
+		// keep the key out of rendered files and logs
+		secret.Register(tlsConfig.ClientKey)
+		if err := writeFile(certPath, []byte(tlsConfig.ClientCert), registryHostsPerm); err != nil {
+			return err
+		}
+		if err := writeFile(keyPath, []byte(tlsConfig.ClientKey), registryClientKeyPerm); err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "%sclient = [[%s, %s]]\n", indent, strconv.Quote(certPath), strconv.Quote(keyPath))
+	}
+	if tlsConfig.SkipVerify {
+		fmt.Fprintf(buf, "%sskip_verify = true\n", indent)
+	}
+	return nil
+}
+
+// RemoveRegistryConfig removes the registry directories written by nodeadm
+// under the containerd certs directory.
+func RemoveRegistryConfig() error {
+	return removeRegistryConfig(containerdCertsDir)
+}
+
+// removeRegistryConfig removes the registry directories in certsDir whose hosts.toml
+// was written by nodeadm, leaving the ones configured by users untouched.
+func removeRegistryConfig(certsDir string) error {
+	entries, err := os.ReadDir(certsDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(certsDir, entry.Name())
+		generated, err := isGeneratedRegistryHosts(filepath.Join(dir, registryHostsFile))
+		if err != nil {
+			return err
+		}
+		if !generated {
+			continue
+		}
+		zap.L().Info("Removing containerd registry config...", zap.String("path", dir))
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRegistryHostsOwner returns a field error when the hosts.toml at path exists
+// and wasn't written by nodeadm.
+func checkRegistryHostsOwner(path string) error {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	generated, err := isGeneratedRegistryHosts(path)
+	if err != nil {
+		return err
+	}
+	if !generated {
+		return validation.NewFieldError("spec.containerd.registries", fmt.Sprintf("%s exists and wasn't written by nodeadm. Remove it or the registry from the config", path))
+	}
+	return nil
+}
+
+func isGeneratedRegistryHosts(path string) (bool, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		return false, scanner.Err()
+	}
+	return scanner.Text() == registryHostsHeader, nil
+}
+
+// ValidateRegistries checks the containerd registries config, reporting each
+// problem as a [validation.FieldError] under path.
+func ValidateRegistries(registries []api.ContainerdRegistry, path string) []error {
+	var errs []error
+	seen := map[string]bool{}
+	for i, registry := range registries {
+		if err := validateRegistryName(registry.Name); err != nil {
+			errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("invalid name %q for registry %d: %s", registry.Name, i, err)))
+		}
+		if seen[registry.Name] {
+			errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("duplicate registry %d with name %q", i, registry.Name)))
+		}
+		seen[registry.Name] = true
+		if registry.Server != "" {
+			if err := validateRegistryURL(registry.Server); err != nil {
+				errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("invalid server %q for registry %q: %s", registry.Server, registry.Name, err)))
+			}
+		}
+		if err := validateRegistryTLS(registry.TLS); err != nil {
+			errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("invalid tls for registry %q: %s", registry.Name, err)))
+		}
+
+		seenMirrors := map[string]bool{}
+		for j, mirror := range registry.Mirrors {
+			if err := validateRegistryURL(mirror.URL); err != nil {
+				errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("invalid url %q for mirror %d of registry %q: %s", mirror.URL, j, registry.Name, err)))
+			}
+			if seenMirrors[mirror.URL] {
+				errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("duplicate mirror %d with url %q for registry %q", j, mirror.URL, registry.Name)))
+			}
+			seenMirrors[mirror.URL] = true
+			for _, capability := range mirror.Capabilities {
+				if !slices.Contains(registryCapabilities, capability) {
+					errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("invalid capability %q for mirror %d of registry %q. Must be one of: %v", capability, j, registry.Name, registryCapabilities)))
+				}
+			}
+			if err := validateRegistryTLS(mirror.TLS); err != nil {
+				errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("invalid tls for mirror %d of registry %q: %s", j, registry.Name, err)))
+			}
+		}
+	}
+	return errs
+}
+
+// validateRegistryName checks name is a registry host, with an optional port, that
+// can be used as a directory name under the containerd certs directory.
+func validateRegistryName(name string) error {
+	if name == defaultRegistryName {
+		return nil
+	}
+	host := name
+	if h, port, err := net.SplitHostPort(name); err == nil {
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return fmt.Errorf("invalid port %q", port)
+		}
+		host = h
+	}
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+	if msgs := k8svalidation.IsDNS1123Subdomain(host); len(msgs) > 0 {
+		return fmt.Errorf("must be a registry host or %s: %s", defaultRegistryName, strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+func validateRegistryURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("host is missing")
+	}
+	return nil
+}
+
+func validateRegistryTLS(tlsConfig *api.RegistryTLS) error {
+	if tlsConfig == nil {
+		return nil
+	}
+	if tlsConfig.CACert != "" {
+		if block, _ := pem.Decode([]byte(tlsConfig.CACert)); block == nil || block.Type != "CERTIFICATE" {
+			return fmt.Errorf("caCert must be a PEM encoded certificate")
+		}
+	}
+	if (tlsConfig.ClientCert == "") != (tlsConfig.ClientKey == "") {
+		return fmt.Errorf("clientCert and clientKey must be set together")
+	}
+	if tlsConfig.ClientCert != "" {
+		if _, err := tls.X509KeyPair([]byte(tlsConfig.ClientCert), []byte(tlsConfig.ClientKey)); err != nil {
+			return fmt.Errorf("clientCert and clientKey must be a PEM encoded key pair: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package containerd
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/render"
+	"github.com/aws/eks-hybrid/internal/test"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+// generateKeyPair returns a PEM encoded self-signed certificate and its private key.
+func generateKeyPair(g *WithT) (string, string) {
+	certPEM, _, key := test.GenerateCA(g)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	g.Expect(err).NotTo(HaveOccurred())
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
+
+func TestWriteRegistryConfig(t *testing.T) {
+	g := NewWithT(t)
+	cert, key := generateKeyPair(g)
+	cfg := &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Containerd: api.ContainerdOptions{
+				Registries: []api.ContainerdRegistry{
+					{
+						Name:   "docker.io",
+						Server: "https://registry-1.docker.io",
+						Mirrors: []api.RegistryMirror{
+							{
+								URL: "https://mirror.example.com:5000",
+								TLS: &api.RegistryTLS{CACert: cert, ClientCert: cert, ClientKey: key},
+							},
+							{
+								URL:          "http://10.0.0.10/v2/ecr",
+								Capabilities: []api.RegistryCapability{api.RegistryCapabilityPull},
+								OverridePath: true,
+								TLS:          &api.RegistryTLS{SkipVerify: true},
+							},
+						},
+					},
+					{
+						Name: "registry.example.com",
+						TLS:  &api.RegistryTLS{CACert: cert},
+					},
+				},
+			},
+		},
+	}
+
+	recorder := &render.Recorder{}
+	g.Expect(writeRegistryConfig(cfg, recorder.WriteFile)).To(Succeed())
+	g.Expect(recorder.Files()).To(Equal([]render.File{
+		{Path: "/etc/containerd/certs.d/docker.io/mirror-0-ca.crt", Content: []byte(cert), Perm: 0o644},
+		{Path: "/etc/containerd/certs.d/docker.io/mirror-0-client.cert", Content: []byte(cert), Perm: 0o644},
+		{Path: "/etc/containerd/certs.d/docker.io/mirror-0-client.key", Content: []byte(key), Perm: 0o600},
+		{
+			Path: "/etc/containerd/certs.d/docker.io/hosts.toml",
+			Content: []byte(`# Generated by nodeadm from spec.containerd.registries, do not edit.
+server = "https://registry-1.docker.io"
+
+[host."https://mirror.example.com:5000"]
+  capabilities = ["pull", "resolve"]
+  ca = "/etc/containerd/certs.d/docker.io/mirror-0-ca.crt"
+  client = [["/etc/containerd/certs.d/docker.io/mirror-0-client.cert", "/etc/containerd/certs.d/docker.io/mirror-0-client.key"]]
+
+[host."http://10.0.0.10/v2/ecr"]
+  capabilities = ["pull"]
+  override_path = true
+  skip_verify = true
+`),
+			Perm: 0o644,
+		},
+		{Path: "/etc/containerd/certs.d/registry.example.com/ca.crt", Content: []byte(cert), Perm: 0o644},
+		{
+			Path: "/etc/containerd/certs.d/registry.example.com/hosts.toml",
+			Content: []byte(`# Generated by nodeadm from spec.containerd.registries, do not edit.
+ca = "/etc/containerd/certs.d/registry.example.com/ca.crt"
+`),
+			Perm: 0o644,
+		},
+	}))
+}
+
+func TestRemoveRegistryConfig(t *testing.T) {
+	g := NewWithT(t)
+	certsDir := t.TempDir()
+	generated := filepath.Join(certsDir, "docker.io")
+	userConfigured := filepath.Join(certsDir, "registry.example.com")
+	g.Expect(os.MkdirAll(generated, 0o755)).To(Succeed())
+	g.Expect(os.MkdirAll(userConfigured, 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(generated, registryHostsFile), []byte(registryHostsHeader+"\nserver = \"https://registry-1.docker.io\"\n"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(generated, "ca.crt"), []byte("ca"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(userConfigured, registryHostsFile), []byte("server = \"https://registry.example.com\"\n"), 0o644)).To(Succeed())
+
+	g.Expect(removeRegistryConfig(certsDir)).To(Succeed())
+	g.Expect(generated).NotTo(BeADirectory())
+	g.Expect(filepath.Join(userConfigured, registryHostsFile)).To(BeARegularFile())
+}
+
+func TestCheckRegistryHostsOwner(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	generated := filepath.Join(dir, "generated.toml")
+	userConfigured := filepath.Join(dir, "user.toml")
+	g.Expect(os.WriteFile(generated, []byte(registryHostsHeader+"\n"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(userConfigured, []byte("server = \"https://registry.example.com\"\n"), 0o644)).To(Succeed())
+
+	g.Expect(checkRegistryHostsOwner(filepath.Join(dir, "missing.toml"))).To(Succeed())
+	g.Expect(checkRegistryHostsOwner(generated)).To(Succeed())
+	err := checkRegistryHostsOwner(userConfigured)
+	g.Expect(err).To(MatchError(userConfigured + " exists and wasn't written by nodeadm. Remove it or the registry from the config"))
+	g.Expect(validation.Field(err)).To(Equal("spec.containerd.registries"))
+}
+
+func TestRemoveRegistryConfigMissingDir(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(removeRegistryConfig(filepath.Join(t.TempDir(), "certs.d"))).To(Succeed())
+}
+
+func TestValidateRegistries(t *testing.T) {
+	g := NewWithT(t)
+	cert, key := generateKeyPair(g)
+	testCases := []struct {
+		name       string
+		registries []api.ContainerdRegistry
+		wantErrs   []string
+	}{
+		{
+			name: "valid",
+			registries: []api.ContainerdRegistry{
+				{
+					Name: "docker.io",
+					Mirrors: []api.RegistryMirror{
+						{URL: "https://mirror.example.com", Capabilities: []api.RegistryCapability{api.RegistryCapabilityPull}},
+					},
+				},
+				{Name: "10.0.0.10:5000", TLS: &api.RegistryTLS{CACert: cert, ClientCert: cert, ClientKey: key}},
+				{Name: "_default", Server: "https://mirror.example.com"},
+			},
+		},
+		{
+			name: "invalid names",
+			registries: []api.ContainerdRegistry{
+				{Name: "../etc"},
+				{Name: "docker.io"},
+				{Name: "docker.io"},
+			},
+			wantErrs: []string{
+				`invalid name "../etc" for registry 0: must be a registry host or _default`,
+				`duplicate registry 2 with name "docker.io"`,
+			},
+		},
+		{
+			name: "invalid urls",
+			registries: []api.ContainerdRegistry{
+				{
+					Name:   "docker.io",
+					Server: "registry-1.docker.io",
+					Mirrors: []api.RegistryMirror{
+						{URL: "https://mirror.example.com", Capabilities: []api.RegistryCapability{"delete"}},
+						{URL: "https://mirror.example.com"},
+					},
+				},
+			},
+			wantErrs: []string{
+				`invalid server "registry-1.docker.io" for registry "docker.io": scheme must be http or https`,
+				`invalid capability "delete" for mirror 0 of registry "docker.io". Must be one of: [pull resolve push]`,
+				`duplicate mirror 1 with url "https://mirror.example.com" for registry "docker.io"`,
+			},
+		},
+		{
+			name: "invalid tls",
+			registries: []api.ContainerdRegistry{
+				{
+					Name: "docker.io",
+					TLS:  &api.RegistryTLS{CACert: "not a certificate"},
+					Mirrors: []api.RegistryMirror{
+						{URL: "https://mirror.example.com", TLS: &api.RegistryTLS{ClientCert: cert}},
+					},
+				},
+			},
+			wantErrs: []string{
+				`invalid tls for registry "docker.io": caCert must be a PEM encoded certificate`,
+				`invalid tls for mirror 0 of registry "docker.io": clientCert and clientKey must be set together`,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := ValidateRegistries(tc.registries, "spec.containerd.registries")
+			g.Expect(errs).To(HaveLen(len(tc.wantErrs)))
+			for i, err := range errs {
+				g.Expect(err.Error()).To(HavePrefix(tc.wantErrs[i]))
+			}
+		})
+	}
+}
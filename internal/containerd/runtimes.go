@@ -0,0 +1,201 @@
+package containerd
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+const (
+	// defaultRuntimeName is the runtime nodeadm configures as containerd's default.
+	defaultRuntimeName = "runc"
+
+	// runtimeBinaryNameOption is the runc shim option with the path of the OCI runtime
+	// binary, like the NVIDIA container runtime.
+	runtimeBinaryNameOption = "BinaryName"
+
+	// RuntimeNodeLabelPrefix is the prefix of the node label added for the runtimes with nodeLabel set.
+	RuntimeNodeLabelPrefix = "runtime.hybrid.eks.amazonaws.com/"
+)
+
+var (
+	// runtimeTypeRegex matches shim names like io.containerd.runsc.v1, which containerd
+	// turns into a binary name from the last two parts.
+	runtimeTypeRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)+$`)
+
+	// runtimeOptionKeyRegex matches the keys that can be written without quotes in TOML.
+	runtimeOptionKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+	// tomlIntegerRegex matches the decimal integers of TOML, which don't allow leading zeros.
+	tomlIntegerRegex = regexp.MustCompile(`^[+-]?(0|[1-9][0-9]*)$`)
+)
+
+type containerdRuntimeTemplateVars struct {
+	Name        string
+	RuntimeType string
+	// RuntimePath is formatted as a TOML string.
+	RuntimePath                  string
+	PrivilegedWithoutHostDevices bool
+	Options                      []containerdRuntimeOption
+}
+
+type containerdRuntimeOption struct {
+	Key string
+	// Value is formatted as a TOML value.
+	Value string
+}
+
+func runtimeTemplateVars(runtimes []api.ContainerdRuntime) []containerdRuntimeTemplateVars {
+	var vars []containerdRuntimeTemplateVars
+	for _, runtime := range runtimes {
+		runtimeVars := containerdRuntimeTemplateVars{
+			Name:                         runtime.Name,
+			RuntimeType:                  runtime.RuntimeType,
+			PrivilegedWithoutHostDevices: runtime.PrivilegedWithoutHostDevices,
+		}
+		if runtime.BinaryPath != "" {
+			runtimeVars.RuntimePath = tomlString(runtime.BinaryPath)
+		}
+		// sorted, so the config is stable across runs
+		for _, key := range slices.Sorted(maps.Keys(runtime.Options)) {
+			runtimeVars.Options = append(runtimeVars.Options, containerdRuntimeOption{
+				Key:   key,
+				Value: runtimeOptionValue(runtime.Options[key]),
+			})
+		}
+		vars = append(vars, runtimeVars)
+	}
+	return vars
+}
+
+// runtimeOptionValue formats value as a TOML boolean when it's true or false and as
+// an integer when it's a whole number, since shim options like SystemdCgroup and IoUid
+// are booleans and integers, and as a string otherwise.
+func runtimeOptionValue(value string) string {
+	if value == "true" || value == "false" {
+		return value
+	}
+	if tomlIntegerRegex.MatchString(value) {
+		if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return value
+		}
+	}
+	return tomlString(value)
+}
+
+// tomlString formats value as a TOML basic string. Unlike Go strings, TOML only
+// supports a few short escapes and \uXXXX for the other control characters.
+func tomlString(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// shimBinaryName returns the binary containerd runs for runtimeType, like
+// containerd-shim-runsc-v1 for io.containerd.runsc.v1.
+func shimBinaryName(runtimeType string) string {
+	parts := strings.Split(runtimeType, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return fmt.Sprintf("containerd-shim-%s-%s", parts[len(parts)-2], parts[len(parts)-1])
+}
+
+// RuntimeNodeLabels returns the node labels for the runtimes with nodeLabel set.
+func RuntimeNodeLabels(runtimes []api.ContainerdRuntime) []string {
+	var labels []string
+	for _, runtime := range runtimes {
+		if runtime.NodeLabel {
+			labels = append(labels, RuntimeNodeLabelPrefix+runtime.Name+"=true")
+		}
+	}
+	return labels
+}
+
+// verifyRuntimeBinaries checks the shim and OCI runtime binaries of the runtimes
+// are installed, since containerd only looks for them when a pod uses the runtime.
+func verifyRuntimeBinaries(runtimes []api.ContainerdRuntime, lookPath func(string) (string, error)) error {
+	var errs []error
+	for _, runtime := range runtimes {
+		shim := runtime.BinaryPath
+		if shim == "" {
+			shim = shimBinaryName(runtime.RuntimeType)
+		}
+		if _, err := lookPath(shim); err != nil {
+			errs = append(errs, fmt.Errorf("shim binary for containerd runtime %s not found: %w", runtime.Name, err))
+		}
+		if binary := runtime.Options[runtimeBinaryNameOption]; binary != "" {
+			if _, err := lookPath(binary); err != nil {
+				errs = append(errs, fmt.Errorf("runtime binary for containerd runtime %s not found: %w", runtime.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateRuntimes checks the containerd runtimes config, reporting each
+// problem as a [validation.FieldError] under path.
+func ValidateRuntimes(runtimes []api.ContainerdRuntime, path string) []error {
+	var errs []error
+	seen := map[string]bool{}
+	for i, runtime := range runtimes {
+		// the name is the RuntimeClass handler and part of the node label
+		if msgs := k8svalidation.IsDNS1123Label(runtime.Name); len(msgs) > 0 {
+			errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("invalid name %q for runtime %d: %s", runtime.Name, i, strings.Join(msgs, "; "))))
+		}
+		if runtime.Name == defaultRuntimeName {
+			errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("invalid name %q for runtime %d: %s is configured by nodeadm", runtime.Name, i, defaultRuntimeName)))
+		}
+		if seen[runtime.Name] {
+			errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("duplicate runtime %d with name %q", i, runtime.Name)))
+		}
+		seen[runtime.Name] = true
+		if !runtimeTypeRegex.MatchString(runtime.RuntimeType) {
+			errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("invalid runtimeType %q for runtime %q: must be a shim name like io.containerd.runsc.v1", runtime.RuntimeType, runtime.Name)))
+		}
+		if runtime.BinaryPath != "" && !filepath.IsAbs(runtime.BinaryPath) {
+			errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("invalid binaryPath %q for runtime %q: must be an absolute path", runtime.BinaryPath, runtime.Name)))
+		}
+		for _, key := range slices.Sorted(maps.Keys(runtime.Options)) {
+			if !runtimeOptionKeyRegex.MatchString(key) {
+				errs = append(errs, validation.NewFieldError(path, fmt.Sprintf("invalid option %q for runtime %q: must only contain letters, digits, '_' and '-'", key, runtime.Name)))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,193 @@
+package containerd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/eks-hybrid/internal/api"
+)
+
+func runtimesNodeConfig() *api.NodeConfig {
+	cfg := sandboxImageNodeConfig()
+	cfg.Spec.Containerd.Runtimes = []api.ContainerdRuntime{
+		{
+			Name:        "gvisor",
+			RuntimeType: "io.containerd.runsc.v1",
+			Options:     map[string]string{"TypeUrl": "io.containerd.runsc.v1.options", "ConfigPath": "/etc/containerd/runsc.toml"},
+		},
+		{
+			Name:                         "nvidia",
+			RuntimeType:                  "io.containerd.runc.v2",
+			BinaryPath:                   "/usr/local/bin/containerd-shim-runc-v2",
+			PrivilegedWithoutHostDevices: true,
+			Options:                      map[string]string{"BinaryName": "/usr/bin/nvidia-container-runtime", "SystemdCgroup": "true"},
+		},
+	}
+	return cfg
+}
+
+func TestGenerateContainerdConfigRuntimes(t *testing.T) {
+	config, err := generateContainerdConfig(runtimesNodeConfig(), "v1.7.20")
+	assert.NoError(t, err)
+	assert.Contains(t, string(config), `  [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc.options]
+    SystemdCgroup = true
+  [plugins."io.containerd.grpc.v1.cri".containerd.runtimes."gvisor"]
+    runtime_type = "io.containerd.runsc.v1"
+  [plugins."io.containerd.grpc.v1.cri".containerd.runtimes."gvisor".options]
+    ConfigPath = "/etc/containerd/runsc.toml"
+    TypeUrl = "io.containerd.runsc.v1.options"
+  [plugins."io.containerd.grpc.v1.cri".containerd.runtimes."nvidia"]
+    runtime_type = "io.containerd.runc.v2"
+    runtime_path = "/usr/local/bin/containerd-shim-runc-v2"
+    privileged_without_host_devices = true
+  [plugins."io.containerd.grpc.v1.cri".containerd.runtimes."nvidia".options]
+    BinaryName = "/usr/bin/nvidia-container-runtime"
+    SystemdCgroup = true
+  [plugins."io.containerd.grpc.v1.cri".cni]
+`)
+	_, err = loadUserConfig(string(config))
+	assert.NoError(t, err)
+}
+
+func TestGenerateContainerdConfigRuntimesVersion3(t *testing.T) {
+	config, err := generateContainerdConfig(runtimesNodeConfig(), "v2.0.0")
+	assert.NoError(t, err)
+	assert.Contains(t, string(config), `  [plugins."io.containerd.cri.v1.runtime".containerd.runtimes."gvisor"]
+    runtime_type = "io.containerd.runsc.v1"
+`)
+	assert.Contains(t, string(config), `  [plugins."io.containerd.cri.v1.runtime".containerd.runtimes."nvidia".options]
+    BinaryName = "/usr/bin/nvidia-container-runtime"
+`)
+	_, err = loadUserConfig(string(config))
+	assert.NoError(t, err)
+}
+
+func TestRuntimeOptionValue(t *testing.T) {
+	assert.Equal(t, "true", runtimeOptionValue("true"))
+	assert.Equal(t, "0", runtimeOptionValue("0"))
+	assert.Equal(t, "-1000", runtimeOptionValue("-1000"))
+	assert.Equal(t, `"007"`, runtimeOptionValue("007"))
+	assert.Equal(t, `"99999999999999999999"`, runtimeOptionValue("99999999999999999999"))
+	assert.Equal(t, `"1.5"`, runtimeOptionValue("1.5"))
+	assert.Equal(t, `"/usr/bin/runc"`, runtimeOptionValue("/usr/bin/runc"))
+	assert.Equal(t, `"say \"hi\"\\\t\u001B\u007F é"`, runtimeOptionValue("say \"hi\"\\\t\x1b\x7f é"))
+}
+
+func TestGenerateContainerdConfigRuntimeOptionTypes(t *testing.T) {
+	cfg := sandboxImageNodeConfig()
+	cfg.Spec.Containerd.Runtimes = []api.ContainerdRuntime{
+		{
+			Name:        "kata",
+			RuntimeType: "io.containerd.kata.v2",
+			BinaryPath:  "/opt/kata/bin/containerd-shim-kata-v2\x00",
+			Options:     map[string]string{"IoUid": "0", "ConfigPath": "/etc/kata\x01/configuration.toml"},
+		},
+	}
+	config, err := generateContainerdConfig(cfg, "v1.7.20")
+	assert.NoError(t, err)
+	user, err := loadUserConfig(string(config))
+	if assert.NoError(t, err) {
+		runtime, _ := user.get([]string{"plugins", criPluginV2, "containerd", "runtimes", "kata"})
+		assert.Equal(t, "/opt/kata/bin/containerd-shim-kata-v2\x00", runtime.(map[string]any)["runtime_path"])
+		options, _ := user.get([]string{"plugins", criPluginV2, "containerd", "runtimes", "kata", "options"})
+		assert.Equal(t, map[string]any{"IoUid": int64(0), "ConfigPath": "/etc/kata\x01/configuration.toml"}, options)
+	}
+}
+
+func TestGenerateContainerdConfigWithoutRuntimes(t *testing.T) {
+	config, err := generateContainerdConfig(sandboxImageNodeConfig(), "v1.7.20")
+	assert.NoError(t, err)
+	assert.Contains(t, string(config), `  [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc.options]
+    SystemdCgroup = true
+  [plugins."io.containerd.grpc.v1.cri".cni]
+`)
+}
+
+func TestShimBinaryName(t *testing.T) {
+	assert.Equal(t, "containerd-shim-runsc-v1", shimBinaryName("io.containerd.runsc.v1"))
+	assert.Equal(t, "containerd-shim-kata-v2", shimBinaryName("io.containerd.kata.v2"))
+	assert.Equal(t, "", shimBinaryName("runsc"))
+}
+
+func TestRuntimeNodeLabels(t *testing.T) {
+	runtimes := []api.ContainerdRuntime{
+		{Name: "gvisor", NodeLabel: true},
+		{Name: "kata"},
+		{Name: "nvidia", NodeLabel: true},
+	}
+	assert.Equal(t, []string{"runtime.hybrid.eks.amazonaws.com/gvisor=true", "runtime.hybrid.eks.amazonaws.com/nvidia=true"}, RuntimeNodeLabels(runtimes))
+}
+
+func TestVerifyRuntimeBinaries(t *testing.T) {
+	installed := map[string]bool{
+		"containerd-shim-runsc-v1":               true,
+		"/usr/local/bin/containerd-shim-runc-v2": true,
+	}
+	lookPath := func(file string) (string, error) {
+		if !installed[file] {
+			return "", errors.New("executable file not found in $PATH")
+		}
+		return file, nil
+	}
+
+	err := verifyRuntimeBinaries(runtimesNodeConfig().Spec.Containerd.Runtimes, lookPath)
+	assert.EqualError(t, err, "runtime binary for containerd runtime nvidia not found: executable file not found in $PATH")
+
+	installed["/usr/bin/nvidia-container-runtime"] = true
+	assert.NoError(t, verifyRuntimeBinaries(runtimesNodeConfig().Spec.Containerd.Runtimes, lookPath))
+}
+
+func TestValidateRuntimes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		runtimes []api.ContainerdRuntime
+		wantErrs []string
+	}{
+		{
+			name:     "valid",
+			runtimes: runtimesNodeConfig().Spec.Containerd.Runtimes,
+		},
+		{
+			name: "invalid names",
+			runtimes: []api.ContainerdRuntime{
+				{Name: "gVisor", RuntimeType: "io.containerd.runsc.v1"},
+				{Name: "runc", RuntimeType: "io.containerd.runc.v2"},
+				{Name: "kata", RuntimeType: "io.containerd.kata.v2"},
+				{Name: "kata", RuntimeType: "io.containerd.kata.v2"},
+			},
+			wantErrs: []string{
+				`invalid name "gVisor" for runtime 0: a lowercase RFC 1123 label`,
+				`invalid name "runc" for runtime 1: runc is configured by nodeadm`,
+				`duplicate runtime 3 with name "kata"`,
+			},
+		},
+		{
+			name: "invalid fields",
+			runtimes: []api.ContainerdRuntime{
+				{
+					Name:        "nvidia",
+					RuntimeType: "runc",
+					BinaryPath:  "bin/containerd-shim-runc-v2",
+					Options:     map[string]string{"Binary Name": "nvidia-container-runtime"},
+				},
+			},
+			wantErrs: []string{
+				`invalid runtimeType "runc" for runtime "nvidia": must be a shim name like io.containerd.runsc.v1`,
+				`invalid binaryPath "bin/containerd-shim-runc-v2" for runtime "nvidia": must be an absolute path`,
+				`invalid option "Binary Name" for runtime "nvidia": must only contain letters, digits, '_' and '-'`,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateRuntimes(tc.runtimes, "spec.containerd.runtimes")
+			if assert.Len(t, errs, len(tc.wantErrs)) {
+				for i, err := range errs {
+					assert.Contains(t, err.Error(), tc.wantErrs[i])
+				}
+			}
+		})
+	}
+}
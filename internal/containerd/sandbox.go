@@ -15,7 +15,9 @@ import (
 	"github.com/aws/eks-hybrid/internal/util"
 )
 
-var containerdSandboxImageRegex = regexp.MustCompile(`sandbox_image = "(.*)"`)
+// containerdSandboxImageRegex matches the sandbox image in the config dump of containerd 1.x,
+// `sandbox_image = "..."`, and of containerd 2.x, `sandbox = '...'` in the pinned images.
+var containerdSandboxImageRegex = regexp.MustCompile(`sandbox(?:_image)? = ["'](.*)["']`)
 
 func cacheSandboxImage(awsConfig *aws.Config) error {
 	zap.L().Info("Looking up current sandbox image in containerd config...")
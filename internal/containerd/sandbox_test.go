@@ -55,3 +55,26 @@ func TestSandboxImageRegex(t *testing.T) {
 	sandboxImage := matches[1]
 	assert.Equal(t, sandboxImage, "registry.k8s.io/pause:3.8")
 }
+
+// containerdV2ConfigDumpFragment is the pinned images of a containerd 2.x config dump,
+// which uses literal strings.
+const containerdV2ConfigDumpFragment = `
+  [plugins.'io.containerd.cri.v1.images']
+    discard_unpacked_layers = true
+    image_pull_progress_timeout = '5m0s'
+    snapshotter = 'overlayfs'
+
+    [plugins.'io.containerd.cri.v1.images'.pinned_images]
+      sandbox = 'registry.k8s.io/pause:3.10'
+
+  [plugins.'io.containerd.cri.v1.runtime']
+    sandboxer = 'podsandbox'
+`
+
+func TestSandboxImageRegexContainerdV2(t *testing.T) {
+	matches := containerdSandboxImageRegex.FindStringSubmatch(containerdV2ConfigDumpFragment)
+	if matches == nil {
+		t.Fatalf("sandbox image could not be found in containerd config")
+	}
+	assert.Equal(t, "registry.k8s.io/pause:3.10", matches[1])
+}
@@ -0,0 +1,267 @@
+package containerd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+	"golang.org/x/mod/semver"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/secret"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+const (
+	// criPluginV2 is the CRI plugin of config version 2. In version 3 it only configures
+	// the CRI service, its image and runtime settings moved to the criImagesPluginV3 and
+	// criRuntimePluginV3 plugins.
+	criPluginV2        = "io.containerd.grpc.v1.cri"
+	criImagesPluginV3  = "io.containerd.cri.v1.images"
+	criRuntimePluginV3 = "io.containerd.cri.v1.runtime"
+
+	// containerdDefaultConfigVersion is the version containerd assumes for configs
+	// that don't set one.
+	containerdDefaultConfigVersion = 1
+)
+
+var (
+	// containerdConfigKeys are the top level keys of config version 2.
+	containerdConfigKeys = []string{
+		"cgroup", "debug", "disabled_plugins", "grpc", "imports", "metrics", "oom_score", "plugin_dir", "plugins",
+		"proxy_plugins", "required_plugins", "root", "state", "stream_processors", "temp", "timeouts", "ttrpc", "version",
+	}
+	// containerdConfigV3RemovedKeys are the top level keys of config version 2 that containerd 2.x dropped.
+	containerdConfigV3RemovedKeys = []string{"plugin_dir"}
+
+	// criPluginV2MovedKeys are the settings of criPluginV2 that belong to criImagesPluginV3
+	// or criRuntimePluginV3 in config version 3.
+	criPluginV2MovedKeys = []string{"cni", "containerd", "registry", "sandbox_image"}
+
+	// userConfigSecretKeys are the registry credential keys of the CRI plugin.
+	userConfigSecretKeys = []string{"auth", "identitytoken", "password"}
+)
+
+// containerdConfigVersion returns the config version nodeadm generates for containerdVersion.
+func containerdConfigVersion(containerdVersion string) int {
+	if semver.Compare(containerdVersion, "v2.0.0") >= 0 {
+		return 3
+	}
+	return 2
+}
+
+// ValidateUserConfig checks the user containerd config is valid TOML, reporting a
+// syntax error as a [validation.FieldError] under path that points to the line in the config.
+func ValidateUserConfig(config, path string) []error {
+	if config == "" {
+		return nil
+	}
+	if _, err := loadUserConfig(config); err != nil {
+		return []error{validation.NewFieldError(path, err.Error())}
+	}
+	return nil
+}
+
+// userConfig is a parsed user containerd config.
+type userConfig struct {
+	values map[string]any
+	// lines are the lines the keys are first defined on, by their path joined with keyPathSeparator.
+	lines map[string]int
+}
+
+// keyPathSeparator joins key paths, keys can contain dots like the plugin names.
+const keyPathSeparator = "\x00"
+
+func loadUserConfig(config string) (*userConfig, error) {
+	values := map[string]any{}
+	if err := toml.Unmarshal([]byte(config), &values); err != nil {
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			line, column := decodeErr.Position()
+			return nil, fmt.Errorf("containerd config is not valid TOML: line %d, column %d: %s", line, column, strings.TrimPrefix(decodeErr.Error(), "toml: "))
+		}
+		return nil, fmt.Errorf("containerd config is not valid TOML: %w", err)
+	}
+	return &userConfig{values: values, lines: keyLines(config)}, nil
+}
+
+// keyLines returns the lines the keys and tables of a valid config are first defined on.
+func keyLines(config string) map[string]int {
+	lines := map[string]int{}
+	record := func(p *unstable.Parser, table []string, node *unstable.Node) []string {
+		path := slices.Clone(table)
+		for key := node.Key(); key.Next(); {
+			path = append(path, string(key.Node().Data))
+			id := strings.Join(path, keyPathSeparator)
+			if _, ok := lines[id]; !ok {
+				lines[id] = p.Shape(key.Node().Raw).Start.Line
+			}
+		}
+		return path
+	}
+	p := &unstable.Parser{}
+	p.Reset([]byte(config))
+	var table []string
+	for p.NextExpression() {
+		switch node := p.Expression(); node.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			table = record(p, nil, node)
+		case unstable.KeyValue:
+			record(p, table, node)
+		}
+	}
+	return lines
+}
+
+// get returns the value at path and whether the config sets it.
+func (c *userConfig) get(path []string) (any, bool) {
+	var value any = c.values
+	for _, key := range path {
+		table, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = table[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// UserConfigWarnings returns the problems with the user containerd config that don't
+// stop containerd from parsing it, like keys that don't belong to its config version.
+// The checks that depend on the installed containerd are skipped when containerdVersion is empty.
+func UserConfigWarnings(config, containerdVersion string) []string {
+	if config == "" {
+		return nil
+	}
+	user, err := loadUserConfig(config)
+	if err != nil {
+		// reported by ValidateUserConfig
+		return nil
+	}
+
+	var warnings []string
+	userVersion := containerdDefaultConfigVersion
+	if version, ok := user.values["version"].(int64); ok {
+		userVersion = int(version)
+	}
+	supportedVersion := 0
+	if containerdVersion != "" {
+		supportedVersion = containerdConfigVersion(containerdVersion)
+		if userVersion > supportedVersion {
+			warnings = append(warnings, user.warning([]string{"version"},
+				fmt.Sprintf("config version %d is not supported by containerd %s, which uses version %d", userVersion, containerdVersion, supportedVersion)))
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(user.values)) {
+		switch {
+		case !slices.Contains(containerdConfigKeys, key):
+			warnings = append(warnings, user.warning([]string{key}, fmt.Sprintf("unknown key %q", key)))
+		case supportedVersion >= 3 && slices.Contains(containerdConfigV3RemovedKeys, key):
+			warnings = append(warnings, user.warning([]string{key},
+				fmt.Sprintf("key %q is not part of config version %d used by containerd %s", key, supportedVersion, containerdVersion)))
+		}
+	}
+
+	if userVersion >= 3 {
+		for _, key := range criPluginV2MovedKeys {
+			path := []string{"plugins", criPluginV2, key}
+			if _, ok := user.get(path); ok {
+				warnings = append(warnings, user.warning(path,
+					fmt.Sprintf("key %q is not part of config version %d, it moved to the %s and %s plugins", strings.Join(path, "."), userVersion, criImagesPluginV3, criRuntimePluginV3)))
+			}
+		}
+	} else {
+		for _, plugin := range []string{criImagesPluginV3, criRuntimePluginV3} {
+			path := []string{"plugins", plugin}
+			if _, ok := user.get(path); ok {
+				warnings = append(warnings, user.warning(path,
+					fmt.Sprintf("plugin %q is only read from config version 3, but the config uses version %d", plugin, userVersion)))
+			}
+		}
+	}
+	return warnings
+}
+
+func (c *userConfig) warning(path []string, msg string) string {
+	if line, ok := c.lines[strings.Join(path, keyPathSeparator)]; ok {
+		return fmt.Sprintf("line %d: %s", line, msg)
+	}
+	return msg
+}
+
+// MergedConfig returns the containerd config nodeadm generates for containerdVersion with
+// the user config imported on top of it. Like containerd, tables are merged key by key and
+// any other value in the user config replaces the generated one. The user config is not
+// migrated, so settings of an older config version appear as written. Registry credentials
+// in the user config are registered as secrets, so they can be redacted.
+func MergedConfig(cfg *api.NodeConfig, containerdVersion string) (string, error) {
+	generated, err := generateContainerdConfig(cfg, containerdVersion)
+	if err != nil {
+		return "", err
+	}
+	merged := map[string]any{}
+	if err := toml.Unmarshal(generated, &merged); err != nil {
+		return "", err
+	}
+	if cfg.Spec.Containerd.Config != "" {
+		user, err := loadUserConfig(cfg.Spec.Containerd.Config)
+		if err != nil {
+			return "", err
+		}
+		registerUserConfigSecrets(user.values)
+		for key, value := range user.values {
+			// the generated config decides the version and imports the user config
+			if key == "version" || key == "imports" {
+				continue
+			}
+			mergeConfigValue(merged, key, value)
+		}
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).SetIndentTables(true).Encode(merged); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func mergeConfigValue(dst map[string]any, key string, value any) {
+	src, ok := value.(map[string]any)
+	if !ok {
+		dst[key] = value
+		return
+	}
+	table, ok := dst[key].(map[string]any)
+	if !ok {
+		dst[key] = src
+		return
+	}
+	for k, v := range src {
+		mergeConfigValue(table, k, v)
+	}
+}
+
+func registerUserConfigSecrets(table map[string]any) {
+	for key, value := range table {
+		switch value := value.(type) {
+		case map[string]any:
+			registerUserConfigSecrets(value)
+		case []any:
+			for _, item := range value {
+				if t, ok := item.(map[string]any); ok {
+					registerUserConfigSecrets(t)
+				}
+			}
+		case string:
+			if slices.Contains(userConfigSecretKeys, key) {
+				secret.Register(value)
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/secret"
+)
+
+func TestValidateUserConfig(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			config: `[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = false
+`,
+		},
+		{
+			name: "empty",
+		},
+		{
+			name: "invalid value",
+			config: `[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = flase
+`,
+			wantErr: "containerd config is not valid TOML: line 2, column 27: expected keyword \"false\"",
+		},
+		{
+			name: "duplicate key",
+			config: `root = "/var/lib/containerd"
+root = "/data/containerd"
+`,
+			wantErr: "containerd config is not valid TOML: line 2, column 1: key root is already defined",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateUserConfig(tc.config, "spec.containerd.config")
+			if tc.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.Len(t, errs, 1) {
+				assert.EqualError(t, errs[0], tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserConfigWarnings(t *testing.T) {
+	testCases := []struct {
+		name              string
+		config            string
+		containerdVersion string
+		want              []string
+	}{
+		{
+			name: "version 2 config on containerd 1.x",
+			config: `version = 2
+[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = false
+`,
+			containerdVersion: "v1.7.20",
+		},
+		{
+			name: "version 2 config on containerd 2.x",
+			config: `version = 2
+[plugins."io.containerd.grpc.v1.cri".registry]
+config_path = "/etc/containerd/certs.d"
+`,
+			containerdVersion: "v2.0.0",
+		},
+		{
+			name: "version 3 config on containerd 1.x",
+			config: `version = 3
+[plugins."io.containerd.cri.v1.images"]
+discard_unpacked_layers = false
+`,
+			containerdVersion: "v1.7.20",
+			want: []string{
+				"line 1: config version 3 is not supported by containerd v1.7.20, which uses version 2",
+			},
+		},
+		{
+			name: "version 2 keys in a version 3 config",
+			config: `version = 3
+plugin_dir = "/opt/containerd/plugins"
+[plugins."io.containerd.grpc.v1.cri"]
+sandbox_image = "registry.k8s.io/pause:3.10"
+[plugins."io.containerd.grpc.v1.cri".registry]
+config_path = "/etc/containerd/certs.d"
+`,
+			containerdVersion: "v2.0.0",
+			want: []string{
+				`line 2: key "plugin_dir" is not part of config version 3 used by containerd v2.0.0`,
+				`line 5: key "plugins.io.containerd.grpc.v1.cri.registry" is not part of config version 3, it moved to the io.containerd.cri.v1.images and io.containerd.cri.v1.runtime plugins`,
+				`line 4: key "plugins.io.containerd.grpc.v1.cri.sandbox_image" is not part of config version 3, it moved to the io.containerd.cri.v1.images and io.containerd.cri.v1.runtime plugins`,
+			},
+		},
+		{
+			name: "version 3 plugins without version",
+			config: `[plugins."io.containerd.cri.v1.runtime".containerd]
+default_runtime_name = "crun"
+`,
+			want: []string{
+				`line 1: plugin "io.containerd.cri.v1.runtime" is only read from config version 3, but the config uses version 1`,
+			},
+		},
+		{
+			name: "unknown key",
+			config: `[plugin."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = false
+`,
+			want: []string{
+				`line 1: unknown key "plugin"`,
+			},
+		},
+		{
+			name:   "invalid toml",
+			config: `version =`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, UserConfigWarnings(tc.config, tc.containerdVersion))
+		})
+	}
+}
+
+func TestMergedConfig(t *testing.T) {
+	cfg := sandboxImageNodeConfig()
+	cfg.Spec.Containerd.Config = `version = 2
+imports = ["/etc/containerd/other.toml"]
+[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = false
+snapshotter = "overlayfs"
+[plugins."io.containerd.grpc.v1.cri".registry.configs."registry.example.com".auth]
+password = "hunter2"
+`
+
+	merged, err := MergedConfig(cfg, "v1.7.20")
+	assert.NoError(t, err)
+	assert.Contains(t, merged, "version = 2\n")
+	assert.Contains(t, merged, `imports = ['/etc/containerd/config.d/*.toml']`)
+	assert.NotContains(t, merged, "/etc/containerd/other.toml")
+	assert.Contains(t, merged, `    [plugins.'io.containerd.grpc.v1.cri'.containerd]
+      default_runtime_name = 'runc'
+      discard_unpacked_layers = false
+      snapshotter = 'overlayfs'
+`)
+	assert.Contains(t, merged, `sandbox_image = '602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.5'`)
+	assert.Contains(t, secret.Redact(merged), `password = '<redacted>'`)
+}
+
+func TestMergedConfigWithoutUserConfig(t *testing.T) {
+	merged, err := MergedConfig(&api.NodeConfig{}, "v2.0.0")
+	assert.NoError(t, err)
+	assert.Contains(t, merged, "version = 3\n")
+	assert.Contains(t, merged, `[plugins.'io.containerd.cri.v1.images'.pinned_images]`)
+}
@@ -0,0 +1,30 @@
+package containerd
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// containerdVersionRegex matches the version in the `containerd --version` output of
+// upstream and distro builds, like `containerd containerd.io 1.7.20 8fc6bcff` or
+// `containerd github.com/containerd/containerd/v2 v2.0.0 207ad711`.
+var containerdVersionRegex = regexp.MustCompile(`\sv?([0-9]+\.[0-9]+\.[0-9]+)`)
+
+// GetContainerdVersion returns the semantic version of the installed containerd, like v1.7.20.
+func GetContainerdVersion() (string, error) {
+	output, err := exec.Command("containerd", "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("getting containerd version: %w", err)
+	}
+	return parseContainerdVersion(string(output))
+}
+
+func parseContainerdVersion(rawVersion string) (string, error) {
+	matches := containerdVersionRegex.FindStringSubmatch(rawVersion)
+	if matches == nil {
+		return "", fmt.Errorf("parsing containerd version from %q", strings.TrimSpace(rawVersion))
+	}
+	return "v" + matches[1], nil
+}
@@ -0,0 +1,48 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseContainerdVersion(t *testing.T) {
+	testCases := []struct {
+		name       string
+		rawVersion string
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "docker package",
+			rawVersion: "containerd containerd.io 1.7.19 2bf793ef6dc9a18e00cb12efb64355c2c9d5eb41\n",
+			want:       "v1.7.19",
+		},
+		{
+			name:       "distro package",
+			rawVersion: "containerd github.com/containerd/containerd 1.7.20 \n",
+			want:       "v1.7.20",
+		},
+		{
+			name:       "containerd 2",
+			rawVersion: "containerd github.com/containerd/containerd/v2 v2.0.0 207ad711eabd375a01713109a8a197d197ff6542\n",
+			want:       "v2.0.0",
+		},
+		{
+			name:       "no version",
+			rawVersion: "containerd github.com/containerd/containerd/v2\n",
+			wantErr:    `parsing containerd version from "containerd github.com/containerd/containerd/v2"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseContainerdVersion(tc.rawVersion)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package daemon
 
-import "context"
+import (
+	"context"
+
+	"github.com/aws/eks-hybrid/internal/render"
+)
 
 type Daemon interface {
 	// Configure configures the daemon.
@@ -22,3 +26,10 @@ type Daemon interface {
 	// Name returns the name of the daemon.
 	Name() string
 }
+
+// Renderer is implemented by daemons that can generate the files written by
+// Configure without modifying the host.
+type Renderer interface {
+	// Render returns the files Configure would write.
+	Render(ctx context.Context) ([]render.File, error)
+}
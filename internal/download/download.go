@@ -0,0 +1,135 @@
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+const (
+	// DefaultParallelism is how many artifacts are downloaded at the same time.
+	DefaultParallelism = 3
+
+	downloadAttempts = 3
+)
+
+// Artifact is an artifact to download before it's installed.
+type Artifact struct {
+	Name string
+	// Get returns the source of the artifact, which is downloaded with ctx.
+	Get func(ctx context.Context) (artifact.Source, error)
+}
+
+// Staged holds the downloaded artifacts, verified against their checksums.
+type Staged struct {
+	dir       string
+	checksums map[string][]byte
+}
+
+// Stage downloads artifacts to dir, at most parallelism of them at the same time, and
+// verifies their checksums. Each download is retried on failure. When an artifact can't
+// be downloaded the other downloads are cancelled and the error is returned. The progress
+// of the downloads is logged periodically.
+func Stage(ctx context.Context, dir string, artifacts []Artifact, parallelism int, log *zap.Logger) (*Staged, error) {
+	staged := &Staged{
+		dir:       dir,
+		checksums: map[string][]byte{},
+	}
+	var mu sync.Mutex
+	progress := newProgressReporter(log)
+	stopReporting := progress.start(progressInterval)
+	defer stopReporting()
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(parallelism)
+	for _, a := range artifacts {
+		group.Go(func() error {
+			// downloads queued behind the limit don't start once another one failed
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			checksum, err := stageWithRetries(ctx, staged.path(a.Name), a, progress, log)
+			if err != nil {
+				return fmt.Errorf("downloading %s: %w", a.Name, err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			staged.checksums[a.Name] = checksum
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return staged, nil
+}
+
+func stageWithRetries(ctx context.Context, path string, a Artifact, progress *progressReporter, log *zap.Logger) ([]byte, error) {
+	var err error
+	for range downloadAttempts {
+		var checksum []byte
+		checksum, err = stageArtifact(ctx, path, a, progress)
+		if err == nil {
+			return checksum, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		log.Error("Downloading artifact failed. Retrying...", zap.String("artifact", a.Name), zap.Error(err))
+	}
+	return nil, err
+}
+
+func stageArtifact(ctx context.Context, path string, a Artifact, progress *progressReporter) ([]byte, error) {
+	src, err := a.Get(util.WithProgress(ctx, progress.track(a.Name)))
+	if err != nil {
+		progress.untrack(a.Name)
+		return nil, err
+	}
+	defer src.Close()
+	if err := artifact.InstallFile(path, src, 0o600); err != nil {
+		progress.untrack(a.Name)
+		return nil, err
+	}
+	progress.finish(a.Name)
+	if !src.VerifyChecksum() {
+		return nil, artifact.NewChecksumError(src)
+	}
+	return src.ExpectedChecksum(), nil
+}
+
+func (s *Staged) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+// Open returns the source of a staged artifact. Its sha256 checksum is verified again
+// while it's read, so it can't be changed on disk between staging and installing.
+func (s *Staged) Open(name string) (artifact.Source, error) {
+	checksum, ok := s.checksums[name]
+	if !ok {
+		return nil, fmt.Errorf("artifact %s was not downloaded", name)
+	}
+	file, err := os.Open(s.path(name))
+	if err != nil {
+		return nil, err
+	}
+	if checksum == nil {
+		return artifact.WithNopChecksum(file), nil
+	}
+	source, err := artifact.WithChecksum(file, sha256.New(), []byte(hex.EncodeToString(checksum)+"  "+name))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return source, nil
+}
@@ -0,0 +1,181 @@
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+func sourceFor(content string) func(context.Context) (artifact.Source, error) {
+	return func(context.Context) (artifact.Source, error) {
+		checksum := fmt.Sprintf("%x  file", sha256.Sum256([]byte(content)))
+		return artifact.WithChecksum(io.NopCloser(strings.NewReader(content)), sha256.New(), []byte(checksum))
+	}
+}
+
+func TestStage(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+
+	staged, err := Stage(context.Background(), dir, []Artifact{
+		{Name: "kubelet", Get: sourceFor("kubelet binary")},
+		{Name: "kubectl", Get: sourceFor("kubectl binary")},
+		{Name: "cni-plugins", Get: sourceFor("cni plugins")},
+	}, 2, zap.NewNop())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for name, content := range map[string]string{"kubelet": "kubelet binary", "kubectl": "kubectl binary", "cni-plugins": "cni plugins"} {
+		source, err := staged.Open(name)
+		g.Expect(err).NotTo(HaveOccurred())
+		data, err := io.ReadAll(source)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(string(data)).To(Equal(content))
+		g.Expect(source.VerifyChecksum()).To(BeTrue())
+		g.Expect(source.Close()).To(Succeed())
+	}
+
+	_, err = staged.Open("aws-iam-authenticator")
+	g.Expect(err).To(MatchError(ContainSubstring("was not downloaded")))
+}
+
+func TestStageLimitsParallelism(t *testing.T) {
+	g := NewWithT(t)
+	var running, maxRunning atomic.Int32
+	get := func(ctx context.Context) (artifact.Source, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return sourceFor("content")(ctx)
+	}
+
+	var artifacts []Artifact
+	for i := range 6 {
+		artifacts = append(artifacts, Artifact{Name: fmt.Sprintf("artifact-%d", i), Get: get})
+	}
+	_, err := Stage(context.Background(), t.TempDir(), artifacts, 2, zap.NewNop())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(maxRunning.Load()).To(BeNumerically("<=", 2))
+}
+
+func TestStageFailureCancelsOtherDownloads(t *testing.T) {
+	g := NewWithT(t)
+	var cancelled atomic.Bool
+	started := make(chan struct{})
+	blocking := func(ctx context.Context) (artifact.Source, error) {
+		close(started)
+		<-ctx.Done()
+		cancelled.Store(true)
+		return nil, ctx.Err()
+	}
+	failing := func(context.Context) (artifact.Source, error) {
+		<-started
+		return nil, errors.New("not found")
+	}
+
+	_, err := Stage(context.Background(), t.TempDir(), []Artifact{
+		{Name: "kubelet", Get: blocking},
+		{Name: "kubectl", Get: failing},
+	}, 2, zap.NewNop())
+	g.Expect(err).To(MatchError(ContainSubstring("downloading kubectl: not found")))
+	g.Expect(cancelled.Load()).To(BeTrue())
+}
+
+func TestStageChecksumMismatch(t *testing.T) {
+	g := NewWithT(t)
+	var attempts atomic.Int32
+	get := func(context.Context) (artifact.Source, error) {
+		attempts.Add(1)
+		checksum := fmt.Sprintf("%x  file", sha256.Sum256([]byte("expected")))
+		return artifact.WithChecksum(io.NopCloser(strings.NewReader("actual")), sha256.New(), []byte(checksum))
+	}
+
+	_, err := Stage(context.Background(), t.TempDir(), []Artifact{{Name: "kubelet", Get: get}}, 1, zap.NewNop())
+	g.Expect(err).To(MatchError(artifact.ChecksumError{}))
+	g.Expect(attempts.Load()).To(BeEquivalentTo(downloadAttempts))
+}
+
+func TestStagedOpenVerifiesChecksum(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	staged, err := Stage(context.Background(), dir, []Artifact{{Name: "kubelet", Get: sourceFor("kubelet binary")}}, 1, zap.NewNop())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(os.WriteFile(filepath.Join(dir, "kubelet"), []byte("tampered"), 0o600)).To(Succeed())
+	source, err := staged.Open("kubelet")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer source.Close()
+	_, err = io.ReadAll(source)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(source.VerifyChecksum()).To(BeFalse())
+}
+
+func TestProgressReport(t *testing.T) {
+	g := NewWithT(t)
+	core, logs := observer.New(zap.InfoLevel)
+	progress := newProgressReporter(zap.New(core))
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	progress.now = func() time.Time { return start }
+
+	report := progress.track("kubelet")
+	report(10<<20, 40<<20)
+	progress.track("kubectl")
+	progress.now = func() time.Time { return start.Add(10 * time.Second) }
+	progress.report()
+
+	entries := logs.TakeAll()
+	g.Expect(entries).To(HaveLen(2))
+	g.Expect(entries[0].ContextMap()).To(Equal(map[string]interface{}{
+		"artifact":   "kubectl",
+		"downloaded": "0 B",
+		"rate":       "0 B/s",
+	}))
+	g.Expect(entries[1].ContextMap()).To(Equal(map[string]interface{}{
+		"artifact":   "kubelet",
+		"downloaded": "10.0 MiB",
+		"rate":       "1.0 MiB/s",
+		"total":      "40.0 MiB",
+		"eta":        30 * time.Second,
+	}))
+
+	progress.finish("kubelet")
+	entries = logs.TakeAll()
+	g.Expect(entries).To(HaveLen(1))
+	g.Expect(entries[0].Message).To(Equal("Downloaded artifact"))
+	g.Expect(entries[0].ContextMap()).To(HaveKeyWithValue("size", "10.0 MiB"))
+}
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[int64]string{
+		0:             "0 B",
+		1023:          "1023 B",
+		1024:          "1.0 KiB",
+		1536:          "1.5 KiB",
+		5 << 20:       "5.0 MiB",
+		3 << 30:       "3.0 GiB",
+		(3 << 30) / 2: "1.5 GiB",
+	} {
+		t.Run(want, func(t *testing.T) {
+			NewWithT(t).Expect(formatBytes(n)).To(Equal(want))
+		})
+	}
+}
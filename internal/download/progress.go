@@ -0,0 +1,139 @@
+package download
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+const progressInterval = 5 * time.Second
+
+// progressReporter logs the progress of the artifacts being downloaded.
+type progressReporter struct {
+	log       *zap.Logger
+	now       func() time.Time
+	mu        sync.Mutex
+	downloads map[string]*downloadProgress
+}
+
+type downloadProgress struct {
+	start time.Time
+	read  int64
+	total int64
+}
+
+func newProgressReporter(log *zap.Logger) *progressReporter {
+	return &progressReporter{
+		log:       log,
+		now:       time.Now,
+		downloads: map[string]*downloadProgress{},
+	}
+}
+
+// track starts tracking the download of name, returning the func it reports its
+// progress with.
+func (p *progressReporter) track(name string) util.ProgressFunc {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	download := &downloadProgress{start: p.now(), total: -1}
+	p.downloads[name] = download
+	return func(read, total int64) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		download.read, download.total = read, total
+	}
+}
+
+// untrack stops tracking the download of name without logging it.
+func (p *progressReporter) untrack(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.downloads, name)
+}
+
+// finish stops tracking the download of name and logs its size and duration.
+func (p *progressReporter) finish(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	download, ok := p.downloads[name]
+	if !ok {
+		return
+	}
+	delete(p.downloads, name)
+	p.log.Info("Downloaded artifact",
+		zap.String("artifact", name),
+		zap.String("size", formatBytes(download.read)),
+		zap.Duration("duration", p.now().Sub(download.start).Round(time.Millisecond)),
+	)
+}
+
+// report logs the progress of every download in flight.
+func (p *progressReporter) report() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	for _, name := range slices.Sorted(maps.Keys(p.downloads)) {
+		download := p.downloads[name]
+		fields := []zap.Field{
+			zap.String("artifact", name),
+			zap.String("downloaded", formatBytes(download.read)),
+		}
+		elapsed := now.Sub(download.start).Seconds()
+		var rate float64
+		if elapsed > 0 {
+			rate = float64(download.read) / elapsed
+			fields = append(fields, zap.String("rate", formatBytes(int64(rate))+"/s"))
+		}
+		if download.total > 0 {
+			fields = append(fields, zap.String("total", formatBytes(download.total)))
+			if rate > 0 {
+				eta := time.Duration(float64(download.total-download.read) / rate * float64(time.Second))
+				fields = append(fields, zap.Duration("eta", eta.Round(time.Second)))
+			}
+		}
+		p.log.Info("Downloading artifact...", fields...)
+	}
+}
+
+// start logs the progress every interval until the returned func is called.
+func (p *progressReporter) start(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				p.report()
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// formatBytes formats n with binary units, like 1.5 MiB.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
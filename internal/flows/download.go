@@ -0,0 +1,85 @@
+package flows
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/download"
+)
+
+const (
+	kubeletArtifact                 = "kubelet"
+	kubectlArtifact                 = "kubectl"
+	cniPluginsArtifact              = "cni-plugins"
+	imageCredentialProviderArtifact = "ecr-credential-provider"
+	iamAuthenticatorArtifact        = "aws-iam-authenticator"
+)
+
+// eksComponentArtifacts maps the tracker names of the EKS components to their artifacts
+// in the release manifest.
+var eksComponentArtifacts = map[string]string{
+	artifact.Kubelet:                 kubeletArtifact,
+	artifact.Kubectl:                 kubectlArtifact,
+	artifact.CniPlugins:              cniPluginsArtifact,
+	artifact.ImageCredentialProvider: imageCredentialProviderArtifact,
+	artifact.IamAuthenticator:        iamAuthenticatorArtifact,
+}
+
+// stagedEksSource serves the EKS artifacts downloaded by downloadEksArtifacts.
+type stagedEksSource struct {
+	staged *download.Staged
+}
+
+func (s stagedEksSource) GetKubelet(context.Context) (artifact.Source, error) {
+	return s.staged.Open(kubeletArtifact)
+}
+
+func (s stagedEksSource) GetKubectl(context.Context) (artifact.Source, error) {
+	return s.staged.Open(kubectlArtifact)
+}
+
+func (s stagedEksSource) GetCniPlugins(context.Context) (artifact.Source, error) {
+	return s.staged.Open(cniPluginsArtifact)
+}
+
+func (s stagedEksSource) GetImageCredentialProvider(context.Context) (artifact.Source, error) {
+	return s.staged.Open(imageCredentialProviderArtifact)
+}
+
+func (s stagedEksSource) GetIAMAuthenticator(context.Context) (artifact.Source, error) {
+	return s.staged.Open(iamAuthenticatorArtifact)
+}
+
+// downloadEksArtifacts downloads the EKS artifacts in parallel to a temporary directory
+// and verifies their checksums, so they are only installed once all of them are available.
+// The returned func removes the directory.
+func downloadEksArtifacts(ctx context.Context, source aws.Source, log *zap.Logger) (stagedEksSource, func(), error) {
+	dir, err := os.MkdirTemp("", "nodeadm-artifacts")
+	if err != nil {
+		return stagedEksSource{}, nil, fmt.Errorf("creating artifacts download directory: %w", err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Warn("Failed to remove artifacts download directory", zap.String("dir", dir), zap.Error(err))
+		}
+	}
+
+	log.Info("Downloading EKS artifacts...")
+	staged, err := download.Stage(ctx, dir, []download.Artifact{
+		{Name: kubeletArtifact, Get: source.GetKubelet},
+		{Name: kubectlArtifact, Get: source.GetKubectl},
+		{Name: cniPluginsArtifact, Get: source.GetCniPlugins},
+		{Name: imageCredentialProviderArtifact, Get: source.GetImageCredentialProvider},
+		{Name: iamAuthenticatorArtifact, Get: source.GetIAMAuthenticator},
+	}, download.DefaultParallelism, log)
+	if err != nil {
+		cleanup()
+		return stagedEksSource{}, nil, err
+	}
+	return stagedEksSource{staged: staged}, cleanup, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/aws/eks-hybrid/internal/aws"
 	"github.com/aws/eks-hybrid/internal/configenricher"
 	"github.com/aws/eks-hybrid/internal/nodeprovider"
+	"github.com/aws/eks-hybrid/internal/util"
 )
 
 const (
@@ -36,8 +37,17 @@ func (i *Initer) Run(ctx context.Context) error {
 	}
 
 	// Get region config from manifest for ECR registry lookup
-	region := i.NodeProvider.GetNodeConfig().Spec.Cluster.Region
-	regionConfig, err := aws.GetRegionConfig(ctx, region)
+	nodeConfig := i.NodeProvider.GetNodeConfig()
+	mirrorOpts, err := aws.MirrorOptions("", nil, "", nodeConfig.GetArtifactsOptions())
+	if err != nil {
+		return err
+	}
+	maxDownloadRate, err := aws.MaxDownloadRate("", nodeConfig.GetArtifactsOptions())
+	if err != nil {
+		return err
+	}
+	ctx = util.WithDownloadOptions(ctx, util.DownloadOptions{MaxRate: maxDownloadRate})
+	regionConfig, err := aws.GetRegionConfig(ctx, nodeConfig.Spec.Cluster.Region, mirrorOpts...)
 	if err != nil {
 		i.Logger.Warn("Failed to get region config from manifest", zap.Error(err))
 	}
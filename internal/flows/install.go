@@ -6,6 +6,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/artifact"
 	"github.com/aws/eks-hybrid/internal/aws"
 	"github.com/aws/eks-hybrid/internal/cni"
 	"github.com/aws/eks-hybrid/internal/containerd"
@@ -82,8 +83,9 @@ func (i *Installer) installCredentialProcess(ctx context.Context) error {
 		}); err != nil {
 			return err
 		}
+		i.Tracker.SetSource(artifact.IamRolesAnywhere, i.AwsSource.Iam.Version, i.AwsSource.SigningHelperURI())
 	case creds.SsmCredentialProvider:
-		ssmInstaller := ssm.NewSSMInstaller(i.Logger, i.SsmRegion)
+		ssmInstaller := newSSMInstaller(i.Logger, i.SsmRegion, i.AwsSource)
 
 		i.Logger.Info("Installing SSM agent installer...")
 		if err := ssm.Install(ctx, ssm.InstallOptions{
@@ -100,11 +102,29 @@ func (i *Installer) installCredentialProcess(ctx context.Context) error {
 	return nil
 }
 
+// newSSMInstaller returns the SSM installer source, downloading the installer from the
+// mirror when the aws source rewrites URLs.
+func newSSMInstaller(logger *zap.Logger, region string, awsSource aws.Source) ssm.Source {
+	return ssm.NewSSMInstaller(logger, region, ssm.WithURLBuilder(func() (string, error) {
+		url, err := ssm.InstallerURL(region)
+		if err != nil {
+			return "", err
+		}
+		return awsSource.RewriteURL(url), nil
+	}))
+}
+
 func (i *Installer) installEksArtifacts(ctx context.Context) error {
+	source, cleanup, err := downloadEksArtifacts(ctx, i.AwsSource, i.Logger)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
 	i.Logger.Info("Installing kubelet...")
 	if err := kubelet.Install(ctx, kubelet.InstallOptions{
 		Tracker: i.Tracker,
-		Source:  i.AwsSource,
+		Source:  source,
 		Logger:  i.Logger,
 	}); err != nil {
 		return err
@@ -113,7 +133,7 @@ func (i *Installer) installEksArtifacts(ctx context.Context) error {
 	i.Logger.Info("Installing kubectl...")
 	if err := kubectl.Install(ctx, kubectl.InstallOptions{
 		Tracker: i.Tracker,
-		Source:  i.AwsSource,
+		Source:  source,
 		Logger:  i.Logger,
 	}); err != nil {
 		return err
@@ -122,7 +142,7 @@ func (i *Installer) installEksArtifacts(ctx context.Context) error {
 	i.Logger.Info("Installing cni-plugins...")
 	if err := cni.Install(ctx, cni.InstallOptions{
 		Tracker: i.Tracker,
-		Source:  i.AwsSource,
+		Source:  source,
 		Logger:  i.Logger,
 	}); err != nil {
 		return err
@@ -131,16 +151,23 @@ func (i *Installer) installEksArtifacts(ctx context.Context) error {
 	i.Logger.Info("Installing image credential provider...")
 	if err := imagecredentialprovider.Install(ctx, imagecredentialprovider.InstallOptions{
 		Tracker: i.Tracker,
-		Source:  i.AwsSource,
+		Source:  source,
 		Logger:  i.Logger,
 	}); err != nil {
 		return err
 	}
 
 	i.Logger.Info("Installing IAM authenticator...")
-	return iamauthenticator.Install(ctx, iamauthenticator.InstallOptions{
+	if err := iamauthenticator.Install(ctx, iamauthenticator.InstallOptions{
 		Tracker: i.Tracker,
-		Source:  i.AwsSource,
+		Source:  source,
 		Logger:  i.Logger,
-	})
+	}); err != nil {
+		return err
+	}
+
+	for component, artifactName := range eksComponentArtifacts {
+		i.Tracker.SetSource(component, i.AwsSource.Eks.Version, i.AwsSource.EksArtifactURI(artifactName))
+	}
+	return nil
 }
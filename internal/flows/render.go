@@ -0,0 +1,97 @@
+package flows
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/configenricher"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/nodeprovider"
+	"github.com/aws/eks-hybrid/internal/render"
+	"github.com/aws/eks-hybrid/internal/system"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+// Renderer generates the files init would write to configure the node,
+// without modifying the host.
+type Renderer struct {
+	NodeProvider nodeprovider.NodeProvider
+	Logger       *zap.Logger
+}
+
+func (r *Renderer) Run(ctx context.Context) ([]render.File, error) {
+	nodeRenderer, ok := r.NodeProvider.(nodeprovider.Renderer)
+	if !ok {
+		return nil, fmt.Errorf("node provider %T does not support rendering", r.NodeProvider)
+	}
+
+	r.NodeProvider.PopulateNodeConfigDefaults()
+
+	if err := r.NodeProvider.ValidateConfig(); err != nil {
+		return nil, err
+	}
+
+	r.Logger.Info("Loading Aws config...")
+	if err := nodeRenderer.LoadAws(ctx); err != nil {
+		return nil, err
+	}
+
+	nodeConfig := r.NodeProvider.GetNodeConfig()
+	mirrorOpts, err := aws.MirrorOptions("", nil, "", nodeConfig.GetArtifactsOptions())
+	if err != nil {
+		return nil, err
+	}
+	maxDownloadRate, err := aws.MaxDownloadRate("", nodeConfig.GetArtifactsOptions())
+	if err != nil {
+		return nil, err
+	}
+	ctx = util.WithDownloadOptions(ctx, util.DownloadOptions{MaxRate: maxDownloadRate})
+	regionConfig, err := aws.GetRegionConfig(ctx, nodeConfig.Spec.Cluster.Region, mirrorOpts...)
+	if err != nil {
+		r.Logger.Warn("Failed to get region config from manifest", zap.Error(err))
+	}
+
+	if err := r.NodeProvider.Enrich(ctx, configenricher.WithRegionConfig(regionConfig)); err != nil {
+		return nil, err
+	}
+
+	files, err := nodeRenderer.RenderAWSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, aspect := range r.NodeProvider.GetAspects() {
+		renderer, ok := aspect.(system.AspectRenderer)
+		if !ok {
+			continue
+		}
+		r.Logger.Info("Rendering system aspect configuration...", zap.String("name", aspect.Name()))
+		aspectFiles, err := renderer.Render()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, aspectFiles...)
+	}
+
+	daemons, err := r.NodeProvider.GetDaemons()
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range daemons {
+		renderer, ok := d.(daemon.Renderer)
+		if !ok {
+			continue
+		}
+		r.Logger.Info("Rendering daemon configuration...", zap.String("name", d.Name()))
+		daemonFiles, err := renderer.Render(ctx)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, daemonFiles...)
+	}
+
+	return files, nil
+}
@@ -11,6 +11,8 @@ import (
 	awsSsm "github.com/aws/aws-sdk-go-v2/service/ssm"
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/cni"
 	"github.com/aws/eks-hybrid/internal/containerd"
 	"github.com/aws/eks-hybrid/internal/daemon"
 	"github.com/aws/eks-hybrid/internal/iamauthenticator"
@@ -21,17 +23,18 @@ import (
 	"github.com/aws/eks-hybrid/internal/kubelet"
 	"github.com/aws/eks-hybrid/internal/packagemanager"
 	"github.com/aws/eks-hybrid/internal/ssm"
+	"github.com/aws/eks-hybrid/internal/system"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
 
 const eksConfigDir = "/etc/eks"
 
 type (
-	CNIUninstall func() error
+	CNIUninstall func(binPath string) error
 )
 
 type Uninstaller struct {
-	Artifacts      *tracker.InstalledArtifacts
+	Tracker        *tracker.Tracker
 	DaemonManager  daemon.DaemonManager
 	PackageManager *packagemanager.DistroPackageManager
 	Logger         *zap.Logger
@@ -47,6 +50,10 @@ func (u *Uninstaller) Run(ctx context.Context) error {
 		return err
 	}
 
+	if err := u.removeKernelArgs(); err != nil {
+		return err
+	}
+
 	if err := u.cleanup(); err != nil {
 		return err
 	}
@@ -57,16 +64,18 @@ func (u *Uninstaller) Run(ctx context.Context) error {
 }
 
 func (u *Uninstaller) uninstallDaemons(ctx context.Context) error {
-	if u.Artifacts.Kubelet {
+	if u.Tracker.Artifacts.Kubelet {
 		u.Logger.Info("Uninstalling kubelet...")
 		if err := u.DaemonManager.StopDaemon(kubelet.KubeletDaemonName); err != nil {
 			return err
 		}
-		if err := kubelet.Uninstall(kubelet.UninstallOptions{}); err != nil {
+		if err := kubelet.Uninstall(kubelet.UninstallOptions{
+			BinPath: u.Tracker.InstallPath(artifact.Kubelet, kubelet.BinPath),
+		}); err != nil {
 			return err
 		}
 	}
-	if u.Artifacts.Ssm {
+	if u.Tracker.Artifacts.Ssm {
 		u.Logger.Info("Stopping SSM daemon...")
 		if err := u.DaemonManager.StopDaemon(ssm.SsmDaemonName); err != nil {
 			return err
@@ -100,7 +109,7 @@ func (u *Uninstaller) uninstallDaemons(ctx context.Context) error {
 			return fmt.Errorf("uninstalling SSM: %w", err)
 		}
 	}
-	if u.Artifacts.IamRolesAnywhere {
+	if u.Tracker.Artifacts.IamRolesAnywhere {
 		u.Logger.Info("Removing aws_signing_helper_update daemon...")
 		if status, err := u.DaemonManager.GetDaemonStatus(iamrolesanywhere.DaemonName); err == nil || status != daemon.DaemonStatusUnknown {
 			if err = u.DaemonManager.StopDaemon(iamrolesanywhere.DaemonName); err != nil {
@@ -109,7 +118,7 @@ func (u *Uninstaller) uninstallDaemons(ctx context.Context) error {
 			}
 		}
 	}
-	if u.Artifacts.Containerd != tracker.ContainerdSourceNone {
+	if u.Tracker.Artifacts.Containerd != tracker.ContainerdSourceNone {
 		u.Logger.Info("Uninstalling containerd...")
 		if err := u.DaemonManager.StopDaemon(containerd.ContainerdDaemonName); err != nil {
 			return err
@@ -118,41 +127,45 @@ func (u *Uninstaller) uninstallDaemons(ctx context.Context) error {
 			return err
 		}
 	}
+	// containerd might not be owned by nodeadm, but its registry config is
+	if err := containerd.RemoveRegistryConfig(); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (u *Uninstaller) uninstallBinaries(ctx context.Context) error {
-	if u.Artifacts.Kubectl {
+	if u.Tracker.Artifacts.Kubectl {
 		u.Logger.Info("Uninstalling kubectl...")
-		if err := kubectl.Uninstall(); err != nil {
+		if err := kubectl.Uninstall(u.Tracker.InstallPath(artifact.Kubectl, kubectl.BinPath)); err != nil {
 			return err
 		}
 	}
-	if u.Artifacts.CniPlugins {
+	if u.Tracker.Artifacts.CniPlugins {
 		u.Logger.Info("Uninstalling cni-plugins...")
-		if err := u.CNIUninstall(); err != nil {
+		if err := u.CNIUninstall(u.Tracker.InstallPath(artifact.CniPlugins, cni.BinPath)); err != nil {
 			return err
 		}
 	}
-	if u.Artifacts.IamAuthenticator {
+	if u.Tracker.Artifacts.IamAuthenticator {
 		u.Logger.Info("Uninstalling IAM authenticator...")
-		if err := iamauthenticator.Uninstall(); err != nil {
+		if err := iamauthenticator.Uninstall(u.Tracker.InstallPath(artifact.IamAuthenticator, iamauthenticator.IAMAuthenticatorBinPath)); err != nil {
 			return err
 		}
 	}
-	if u.Artifacts.IamRolesAnywhere {
+	if u.Tracker.Artifacts.IamRolesAnywhere {
 		u.Logger.Info("Uninstalling AWS signing helper...")
-		if err := iamrolesanywhere.Uninstall(); err != nil {
+		if err := iamrolesanywhere.Uninstall(u.Tracker.InstallPath(artifact.IamRolesAnywhere, iamrolesanywhere.SigningHelperBinPath)); err != nil {
 			return err
 		}
 	}
-	if u.Artifacts.ImageCredentialProvider {
+	if u.Tracker.Artifacts.ImageCredentialProvider {
 		u.Logger.Info("Uninstalling image credential provider...")
-		if err := imagecredentialprovider.Uninstall(); err != nil {
+		if err := imagecredentialprovider.Uninstall(u.Tracker.InstallPath(artifact.ImageCredentialProvider, imagecredentialprovider.BinPath)); err != nil {
 			return err
 		}
 	}
-	if u.Artifacts.Iptables {
+	if u.Tracker.Artifacts.Iptables {
 		u.Logger.Info("Uninstalling iptables...")
 		if err := iptables.Uninstall(ctx, u.PackageManager); err != nil {
 			return err
@@ -161,6 +174,20 @@ func (u *Uninstaller) uninstallBinaries(ctx context.Context) error {
 	return nil
 }
 
+// removeKernelArgs removes the args nodeadm added to the kernel command line, like
+// the boot time allocation of 1Gi hugepages.
+func (u *Uninstaller) removeKernelArgs() error {
+	if u.Tracker.KernelArgs == "" {
+		return nil
+	}
+	u.Logger.Info("Removing kernel command line args...", zap.String("args", u.Tracker.KernelArgs))
+	if err := system.RemoveKernelCmdlineArgs(u.Tracker.KernelArgs); err != nil {
+		return fmt.Errorf("removing kernel command line args: %w", err)
+	}
+	u.Logger.Warn("Removed args from the kernel command line, reboot the node to apply it", zap.String("args", u.Tracker.KernelArgs))
+	return nil
+}
+
 // cleanup removes directories or files that are not individually owned by single component
 func (u *Uninstaller) cleanup() error {
 	if err := u.PackageManager.Cleanup(); err != nil {
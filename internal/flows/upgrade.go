@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/artifact"
 	"github.com/aws/eks-hybrid/internal/aws"
 	"github.com/aws/eks-hybrid/internal/cni"
 	"github.com/aws/eks-hybrid/internal/configenricher"
@@ -30,7 +31,7 @@ type Upgrader struct {
 	AwsSource          aws.Source
 	PackageManager     *packagemanager.DistroPackageManager
 	CredentialProvider creds.CredentialProvider
-	Artifacts          *tracker.InstalledArtifacts
+	Tracker            *tracker.Tracker
 	DaemonManager      daemon.DaemonManager
 	SkipPhases         []string
 	Logger             *zap.Logger
@@ -60,7 +61,11 @@ func (u *Upgrader) Run(ctx context.Context) error {
 		return err
 	}
 
-	return u.NodeProvider.Cleanup()
+	if err := u.NodeProvider.Cleanup(); err != nil {
+		return err
+	}
+
+	return u.Tracker.Save()
 }
 
 func (u *Upgrader) upgradeDistroPackages(ctx context.Context) error {
@@ -68,18 +73,24 @@ func (u *Upgrader) upgradeDistroPackages(ctx context.Context) error {
 	if err := u.PackageManager.RefreshMetadataCache(ctx); err != nil {
 		return err
 	}
-	if u.Artifacts.Containerd != tracker.ContainerdSourceNone {
+	if u.Tracker.Artifacts.Containerd != tracker.ContainerdSourceNone {
 		u.Logger.Info("Upgrading containerd...")
 		if err := containerd.Upgrade(ctx, u.PackageManager); err != nil {
 			return err
 		}
+		if err := u.Tracker.Upgraded(artifact.Containerd, "", ""); err != nil {
+			return err
+		}
 	}
 
-	if u.Artifacts.Iptables {
+	if u.Tracker.Artifacts.Iptables {
 		u.Logger.Info("Upgrading iptables...")
 		if err := iptables.Upgrade(ctx, u.PackageManager); err != nil {
 			return err
 		}
+		if err := u.Tracker.Upgraded(artifact.Iptables, "", ""); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -88,12 +99,19 @@ func (u *Upgrader) upgradeCredentialProvider(ctx context.Context) error {
 	switch u.CredentialProvider {
 	case creds.IamRolesAnywhereCredentialProvider:
 		u.Logger.Info("Upgrading AWS signing helper...")
-		if err := iamrolesanywhere.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
+		binPath := u.Tracker.InstallPath(artifact.IamRolesAnywhere, iamrolesanywhere.SigningHelperBinPath)
+		replaced, err := iamrolesanywhere.Upgrade(ctx, u.AwsSource, binPath, u.Logger)
+		if err != nil {
 			return err
 		}
+		if replaced {
+			if err := u.Tracker.Upgraded(artifact.IamRolesAnywhere, u.AwsSource.Iam.Version, u.AwsSource.SigningHelperURI(), binPath); err != nil {
+				return err
+			}
+		}
 	case creds.SsmCredentialProvider:
 		nodeConfig := u.NodeProvider.GetNodeConfig()
-		ssmInstaller := ssm.NewSSMInstaller(u.Logger, nodeConfig.Spec.Cluster.Region)
+		ssmInstaller := newSSMInstaller(u.Logger, nodeConfig.Spec.Cluster.Region, u.AwsSource)
 
 		u.Logger.Info("Upgrading SSM agent installer...")
 		if err := ssm.Upgrade(ctx, ssm.InstallOptions{
@@ -103,6 +121,9 @@ func (u *Upgrader) upgradeCredentialProvider(ctx context.Context) error {
 		}); err != nil {
 			return err
 		}
+		if err := u.Tracker.Upgraded(artifact.Ssm, "", ""); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("installed credential provider %s is not supported for upgrade", u.CredentialProvider)
 	}
@@ -110,26 +131,67 @@ func (u *Upgrader) upgradeCredentialProvider(ctx context.Context) error {
 }
 
 func (u *Upgrader) upgradeEksArtifacts(ctx context.Context) error {
+	source, cleanup, err := downloadEksArtifacts(ctx, u.AwsSource, u.Logger)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	kubeletBinPath := u.Tracker.InstallPath(artifact.Kubelet, kubelet.BinPath)
 	u.Logger.Info("Upgrading kubelet...")
-	if err := kubelet.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
+	replaced, err := kubelet.Upgrade(ctx, source, kubeletBinPath, u.Logger)
+	if err != nil {
 		return errors.Wrap(err, "failed to upgrade kubelet")
 	}
+	if err := u.upgraded(replaced, artifact.Kubelet, kubeletBinPath, kubelet.UnitPath); err != nil {
+		return err
+	}
 
+	kubectlBinPath := u.Tracker.InstallPath(artifact.Kubectl, kubectl.BinPath)
 	u.Logger.Info("Upgrading kubectl...")
-	if err := kubectl.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
+	replaced, err = kubectl.Upgrade(ctx, source, kubectlBinPath, u.Logger)
+	if err != nil {
+		return err
+	}
+	if err := u.upgraded(replaced, artifact.Kubectl, kubectlBinPath); err != nil {
 		return err
 	}
 
+	imageCredentialProviderBinPath := u.Tracker.InstallPath(artifact.ImageCredentialProvider, imagecredentialprovider.BinPath)
 	u.Logger.Info("Upgrading image credential provider...")
-	if err := imagecredentialprovider.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
+	replaced, err = imagecredentialprovider.Upgrade(ctx, source, imageCredentialProviderBinPath, u.Logger)
+	if err != nil {
+		return err
+	}
+	if err := u.upgraded(replaced, artifact.ImageCredentialProvider, imageCredentialProviderBinPath); err != nil {
 		return err
 	}
 
+	iamAuthenticatorBinPath := u.Tracker.InstallPath(artifact.IamAuthenticator, iamauthenticator.IAMAuthenticatorBinPath)
 	u.Logger.Info("Upgrading IAM authenticator...")
-	if err := iamauthenticator.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
+	replaced, err = iamauthenticator.Upgrade(ctx, source, iamAuthenticatorBinPath, u.Logger)
+	if err != nil {
+		return err
+	}
+	if err := u.upgraded(replaced, artifact.IamAuthenticator, iamAuthenticatorBinPath); err != nil {
 		return err
 	}
 
+	cniBinPath := u.Tracker.InstallPath(artifact.CniPlugins, cni.BinPath)
 	u.Logger.Info("Upgrading cni-plugins...")
-	return cni.Upgrade(ctx, u.AwsSource, u.Logger)
+	if err := cni.Upgrade(ctx, source, cniBinPath, u.Logger); err != nil {
+		return err
+	}
+	// cni-plugins is a tarball without a checksum to compare, so it's always reinstalled
+	return u.upgraded(true, artifact.CniPlugins, cniBinPath)
+}
+
+// upgraded records an EKS component was upgraded to the release of the aws source, when
+// its files were replaced.
+func (u *Upgrader) upgraded(replaced bool, component string, paths ...string) error {
+	if !replaced {
+		return nil
+	}
+	artifactName := eksComponentArtifacts[component]
+	return u.Tracker.Upgraded(component, u.AwsSource.Eks.Version, u.AwsSource.EksArtifactURI(artifactName), paths...)
 }
@@ -39,7 +39,7 @@ func Install(ctx context.Context, opts InstallOptions) error {
 		return errors.Wrap(err, "installing aws-iam-authenticator")
 	}
 
-	if err := opts.Tracker.Add(artifact.IamAuthenticator); err != nil {
+	if err := opts.Tracker.Add(artifact.IamAuthenticator, filepath.Join(opts.InstallRoot, IAMAuthenticatorBinPath)); err != nil {
 		return errors.Wrap(err, "adding aws-iam-authenticator to tracker")
 	}
 
@@ -85,16 +85,18 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 	return nil
 }
 
-func Uninstall() error {
-	return os.RemoveAll(IAMAuthenticatorBinPath)
+// Uninstall removes the IAM Authenticator installed at binPath.
+func Uninstall(binPath string) error {
+	return os.RemoveAll(binPath)
 }
 
-func Upgrade(ctx context.Context, src IAMAuthenticatorSource, log *zap.Logger) error {
+// Upgrade upgrades the IAM Authenticator installed at binPath, returning whether the binary was replaced.
+func Upgrade(ctx context.Context, src IAMAuthenticatorSource, binPath string, log *zap.Logger) (bool, error) {
 	authenticator, err := src.GetIAMAuthenticator(ctx)
 	if err != nil {
-		return errors.Wrap(err, "getting aws-iam-authenticator source")
+		return false, errors.Wrap(err, "getting aws-iam-authenticator source")
 	}
 	defer authenticator.Close()
 
-	return artifact.Upgrade(artifactName, IAMAuthenticatorBinPath, authenticator, artifactFilePerms, log)
+	return artifact.Upgrade(artifactName, binPath, authenticator, artifactFilePerms, log)
 }
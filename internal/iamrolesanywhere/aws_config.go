@@ -66,13 +66,27 @@ func WriteAWSConfig(cfg AWSConfig) error {
 		cfg.ConfigPath = DefaultAWSConfigPath
 	}
 
+	data, err := GenerateAWSConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	return writeConfigFile(cfg.ConfigPath, data)
+}
+
+// GenerateAWSConfig validates the configuration and returns the contents of the AWS configuration file.
+func GenerateAWSConfig(cfg AWSConfig) ([]byte, error) {
 	cfg.ProxyEnabled = network.IsProxyEnabled()
 
 	if err := validateAWSConfig(cfg); err != nil {
-		return err
+		return nil, err
 	}
 
-	return writeConfigFile(cfg)
+	var buf bytes.Buffer
+	if err := awsConfigTpl.Execute(&buf, cfg); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 func validateAWSConfig(cfg AWSConfig) error {
@@ -113,17 +127,12 @@ func validateAWSConfig(cfg AWSConfig) error {
 	return errors.Join(errs...)
 }
 
-func writeConfigFile(cfg AWSConfig) error {
-	var buf bytes.Buffer
-	if err := awsConfigTpl.Execute(&buf, cfg); err != nil {
-		return err
-	}
-
-	if err := os.MkdirAll(path.Dir(cfg.ConfigPath), os.ModeDir); err != nil {
+func writeConfigFile(configPath string, data []byte) error {
+	if err := os.MkdirAll(path.Dir(configPath), os.ModeDir); err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(cfg.ConfigPath, buf.Bytes(), 0o644); err != nil {
+	if err := os.WriteFile(configPath, data, 0o644); err != nil {
 		return fmt.Errorf("writing AWS config file: %w", err)
 	}
 
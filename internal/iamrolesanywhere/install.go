@@ -38,7 +38,7 @@ func Install(ctx context.Context, opts InstallOptions) error {
 		return errors.Wrap(err, "installing aws_signing_helper")
 	}
 
-	if err := opts.Tracker.Add(artifact.IamRolesAnywhere); err != nil {
+	if err := opts.Tracker.Add(artifact.IamRolesAnywhere, filepath.Join(opts.InstallRoot, SigningHelperBinPath)); err != nil {
 		return errors.Wrap(err, "adding aws_signing_helper to tracker")
 	}
 
@@ -84,22 +84,24 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 	return nil
 }
 
-func Uninstall() error {
+// Uninstall removes the signing helper installed at binPath, with its service and credentials.
+func Uninstall(binPath string) error {
 	if err := os.RemoveAll(SigningHelperServiceFilePath); err != nil {
 		return err
 	}
 	if err := os.RemoveAll(path.Dir(EksHybridAwsCredentialsPath)); err != nil {
 		return err
 	}
-	return os.RemoveAll(SigningHelperBinPath)
+	return os.RemoveAll(binPath)
 }
 
-func Upgrade(ctx context.Context, signingHelperSrc SigningHelperSource, log *zap.Logger) error {
+// Upgrade upgrades the signing helper installed at binPath, returning whether the binary was replaced.
+func Upgrade(ctx context.Context, signingHelperSrc SigningHelperSource, binPath string, log *zap.Logger) (bool, error) {
 	signingHelper, err := signingHelperSrc.GetSigningHelper(ctx)
 	if err != nil {
-		return errors.Wrap(err, "getting aws_signing_helper source")
+		return false, errors.Wrap(err, "getting aws_signing_helper source")
 	}
 	defer signingHelper.Close()
 
-	return artifact.Upgrade(artifactName, SigningHelperBinPath, signingHelper, artifactFilePerms, log)
+	return artifact.Upgrade(artifactName, binPath, signingHelper, artifactFilePerms, log)
 }
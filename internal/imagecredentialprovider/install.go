@@ -40,7 +40,7 @@ func Install(ctx context.Context, opts InstallOptions) error {
 		return errors.Wrap(err, "installing image-credential-provider")
 	}
 
-	if err := opts.Tracker.Add(artifact.ImageCredentialProvider); err != nil {
+	if err := opts.Tracker.Add(artifact.ImageCredentialProvider, filepath.Join(opts.InstallRoot, BinPath)); err != nil {
 		return errors.Wrap(err, "adding image-credential-provider to tracker")
 	}
 
@@ -86,16 +86,23 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 	return nil
 }
 
-func Uninstall() error {
-	return os.RemoveAll(path.Dir(BinPath))
+// Uninstall removes the image-credential-provider installed at binPath. Its directory is
+// only removed when binPath is the default BinPath, since the directory of another path can
+// hold files nodeadm didn't install.
+func Uninstall(binPath string) error {
+	if binPath == BinPath {
+		return os.RemoveAll(path.Dir(binPath))
+	}
+	return os.RemoveAll(binPath)
 }
 
-func Upgrade(ctx context.Context, src Source, log *zap.Logger) error {
+// Upgrade upgrades the image-credential-provider installed at binPath, returning whether the binary was replaced.
+func Upgrade(ctx context.Context, src Source, binPath string, log *zap.Logger) (bool, error) {
 	imageCredentialProvider, err := src.GetImageCredentialProvider(ctx)
 	if err != nil {
-		return errors.Wrap(err, "getting image-credential-provider source")
+		return false, errors.Wrap(err, "getting image-credential-provider source")
 	}
 	defer imageCredentialProvider.Close()
 
-	return artifact.Upgrade(artifactName, BinPath, imageCredentialProvider, artifactFilePerms, log)
+	return artifact.Upgrade(artifactName, binPath, imageCredentialProvider, artifactFilePerms, log)
 }
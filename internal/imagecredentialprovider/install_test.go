@@ -2,6 +2,8 @@ package imagecredentialprovider_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -34,3 +36,17 @@ func TestInstall(t *testing.T) {
 		VerifyFilePaths: []string{imagecredentialprovider.BinPath},
 	})
 }
+
+func TestUninstallCustomPath(t *testing.T) {
+	g := NewGomegaWithT(t)
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "ecr-credential-provider")
+	g.Expect(os.WriteFile(binPath, []byte("ecr-credential-provider"), 0o755)).To(Succeed())
+	// the directory of a custom path can hold files nodeadm didn't install
+	other := filepath.Join(dir, "other")
+	g.Expect(os.WriteFile(other, []byte("other"), 0o644)).To(Succeed())
+
+	g.Expect(imagecredentialprovider.Uninstall(binPath)).To(Succeed())
+	g.Expect(binPath).NotTo(BeAnExistingFile())
+	g.Expect(other).To(BeAnExistingFile())
+}
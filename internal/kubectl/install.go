@@ -39,7 +39,7 @@ func Install(ctx context.Context, opts InstallOptions) error {
 		return errors.Wrap(err, "installing kubectl")
 	}
 
-	if err := opts.Tracker.Add(artifact.Kubectl); err != nil {
+	if err := opts.Tracker.Add(artifact.Kubectl, filepath.Join(opts.InstallRoot, BinPath)); err != nil {
 		return errors.Wrap(err, "adding kubectl to tracker")
 	}
 
@@ -85,16 +85,18 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 	return nil
 }
 
-func Uninstall() error {
-	return os.RemoveAll(BinPath)
+// Uninstall removes kubectl installed at binPath.
+func Uninstall(binPath string) error {
+	return os.RemoveAll(binPath)
 }
 
-func Upgrade(ctx context.Context, src Source, log *zap.Logger) error {
+// Upgrade upgrades kubectl installed at binPath, returning whether the binary was replaced.
+func Upgrade(ctx context.Context, src Source, binPath string, log *zap.Logger) (bool, error) {
 	kubectl, err := src.GetKubectl(ctx)
 	if err != nil {
-		return errors.Wrap(err, "getting kubectl source")
+		return false, errors.Wrap(err, "getting kubectl source")
 	}
 	defer kubectl.Close()
 
-	return artifact.Upgrade(artifactName, BinPath, kubectl, artifactFilePerms, log)
+	return artifact.Upgrade(artifactName, binPath, kubectl, artifactFilePerms, log)
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"math"
 	"net"
 	"net/url"
@@ -13,6 +14,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -39,10 +41,14 @@ const (
 	kubeletConfigDir  = "config.json.d"
 	kubeletConfigPerm = 0o644
 
-	hybridNodeLabel            = "eks.amazonaws.com/compute-type=hybrid"
+	computeTypeLabelKey        = "eks.amazonaws.com/compute-type"
+	hybridNodeLabel            = computeTypeLabelKey + "=hybrid"
 	credentialProviderLabelKey = "eks.amazonaws.com/hybrid-credential-provider"
 
 	hybridProviderIdPrefix = "eks-hybrid"
+
+	// swapBehaviorLimited lets burstable pods swap in proportion to their memory request.
+	swapBehaviorLimited = "LimitedSwap"
 )
 
 var nodeNameProviderIdRegexPattern = regexp.MustCompile(`^eks-hybrid:///[^/]+/[^/]+/(.+)$`)
@@ -74,7 +80,9 @@ type kubeletConfig struct {
 	ClusterDNS               []string                         `json:"clusterDNS"`
 	ClusterDomain            string                           `json:"clusterDomain"`
 	ContainerRuntimeEndpoint string                           `json:"containerRuntimeEndpoint"`
+	CPUManagerPolicy         string                           `json:"cpuManagerPolicy,omitempty"`
 	EvictionHard             map[string]string                `json:"evictionHard,omitempty"`
+	FailSwapOn               *bool                            `json:"failSwapOn,omitempty"`
 	FeatureGates             map[string]bool                  `json:"featureGates"`
 	HairpinMode              string                           `json:"hairpinMode"`
 	KubeAPIBurst             *int                             `json:"kubeAPIBurst,omitempty"`
@@ -83,14 +91,18 @@ type kubeletConfig struct {
 	KubeReservedCgroup       *string                          `json:"kubeReservedCgroup,omitempty"`
 	Logging                  loggingConfiguration             `json:"logging"`
 	MaxPods                  int32                            `json:"maxPods,omitempty"`
+	MemorySwap               *memorySwapConfiguration         `json:"memorySwap,omitempty"`
 	ProtectKernelDefaults    bool                             `json:"protectKernelDefaults"`
 	ProviderID               *string                          `json:"providerID,omitempty"`
 	ReadOnlyPort             int                              `json:"readOnlyPort"`
 	RegisterWithTaints       []v1.Taint                       `json:"registerWithTaints,omitempty"`
+	ReservedSystemCPUs       string                           `json:"reservedSystemCPUs,omitempty"`
 	SerializeImagePulls      bool                             `json:"serializeImagePulls"`
 	ServerTLSBootstrap       bool                             `json:"serverTLSBootstrap"`
+	SystemReserved           map[string]string                `json:"systemReserved,omitempty"`
 	SystemReservedCgroup     *string                          `json:"systemReservedCgroup,omitempty"`
 	TLSCipherSuites          []string                         `json:"tlsCipherSuites"`
+	TopologyManagerPolicy    string                           `json:"topologyManagerPolicy,omitempty"`
 	ResolvConf               string                           `json:"resolvConf,omitempty"`
 	metav1.TypeMeta          `json:",inline"`
 }
@@ -99,6 +111,10 @@ type loggingConfiguration struct {
 	Verbosity int `json:"verbosity"`
 }
 
+type memorySwapConfiguration struct {
+	SwapBehavior string `json:"swapBehavior,omitempty"`
+}
+
 // Creates an internal kubelet configuration from the public facing bootstrap
 // kubelet configuration with additional sane defaults.
 func defaultKubeletSubConfig() kubeletConfig {
@@ -252,13 +268,53 @@ func (ksc *kubeletConfig) withHybridCloudProvider(cfg *api.NodeConfig, flags map
 	flags["hostname-override"] = cfg.Status.Hybrid.NodeName
 }
 
+// IsManagedNodeLabel returns true if nodeadm sets the node label key itself,
+// so it can't be set by the user.
+func IsManagedNodeLabel(key string) bool {
+	return key == computeTypeLabelKey || key == credentialProviderLabelKey || strings.HasPrefix(key, containerd.RuntimeNodeLabelPrefix)
+}
+
+// withHybridNodeLabels sets the node labels managed by nodeadm followed by the
+// user labels, sorted by key so the flag is stable across runs.
 func (ksc *kubeletConfig) withHybridNodeLabels(cfg *api.NodeConfig, flags map[string]string) {
 	var labels []string
 	labels = append(labels, hybridNodeLabel)
 	labels = append(labels, fmt.Sprintf("%s=%s", credentialProviderLabelKey, cfg.GetNodeType()))
+	labels = append(labels, containerd.RuntimeNodeLabels(cfg.Spec.Containerd.Runtimes)...)
+	for _, key := range slices.Sorted(maps.Keys(cfg.Spec.Hybrid.Labels)) {
+		labels = append(labels, fmt.Sprintf("%s=%s", key, cfg.Spec.Hybrid.Labels[key]))
+	}
 	flags["node-labels"] = strings.Join(labels, ",")
 }
 
+func (ksc *kubeletConfig) withHybridNodeTaints(cfg *api.NodeConfig) {
+	for _, taint := range cfg.Spec.Hybrid.Taints {
+		ksc.RegisterWithTaints = append(ksc.RegisterWithTaints, v1.Taint{
+			Key:    taint.Key,
+			Value:  taint.Value,
+			Effect: v1.TaintEffect(taint.Effect),
+		})
+	}
+}
+
+// withHybridSwap lets kubelet run on a node with swap and limits the swap usage of
+// burstable pods when the node config keeps swap enabled. NodeSwap is beta and
+// disabled by default before 1.30, and LimitedSwap is only supported from 1.28.
+func (ksc *kubeletConfig) withHybridSwap(cfg *api.NodeConfig, kubeletVersion string) error {
+	if !system.SwapLimited(cfg) {
+		return nil
+	}
+	if semver.Compare(kubeletVersion, "v1.28.0") < 0 {
+		return fmt.Errorf("limited swap requires kubelet v1.28 or later, found %s", kubeletVersion)
+	}
+	if semver.Compare(kubeletVersion, "v1.30.0") < 0 {
+		ksc.FeatureGates["NodeSwap"] = true
+	}
+	ksc.FailSwapOn = ptr.Bool(false)
+	ksc.MemorySwap = &memorySwapConfiguration{SwapBehavior: swapBehaviorLimited}
+	return nil
+}
+
 // When the DefaultReservedResources flag is enabled, override the kubelet
 // config with reserved cgroup values on behalf of the user
 func (ksc *kubeletConfig) withDefaultReservedResources(cfg *api.NodeConfig) {
@@ -277,28 +333,40 @@ func (ksc *kubeletConfig) withDefaultReservedResources(cfg *api.NodeConfig) {
 	}
 }
 
-// withHybridReservedResources reserves cpu and memory according to below computation
-// for kubelet in order for safe cluster management operation
-func (ksc *kubeletConfig) withHybridReservedResources() error {
+// withHybridReservedResources reserves resources for the system and kubelet according
+// to the reserved resources profile and overrides in the node config
+func (ksc *kubeletConfig) withHybridReservedResources(cfg *api.NodeConfig) error {
 	ksc.SystemReservedCgroup = ptr.String("/system")
 	ksc.KubeReservedCgroup = ptr.String("/runtime")
 
-	// calculate kube reserved memory
 	totalMemory, err := system.GetMachineMemoryCapacity()
 	if err != nil {
 		return err
 	}
+	// hugepages are pre-allocated for pods and can't be used by system and
+	// Kubernetes daemons, so reservations are based on the rest of the memory
+	if hugepages := system.HugepagesMemory(cfg); hugepages < totalMemory {
+		totalMemory -= hugepages
+	}
+	capacity := nodeCapacity{
+		milliCPU: getTotalCPUMillicores(),
+		memory:   totalMemory,
+	}
+	return ksc.withReservedResources(cfg.Spec.Hybrid.ReservedResources, capacity)
+}
+
+// getHybridMemoryToReserve returns the memory to reserve for kubelet on hybrid nodes
+// according to the following table
+// 255 MiB when total memory is < 1GiB
+// 25% of first 4GiB of total memory
+// 20% of next 4GiB of total memory
+// 10% of next 8 GiB of total memory
+// 6% of next 112 GiB of total memory
+// 2% of remaining total memory
+func getHybridMemoryToReserve(totalMemory uint64) string {
 	// Convert bytes to GiB
 	totalMemoryGiB := totalMemory / (1024 * 1024 * 1024)
 	var reserveMemoryString string
-
-	// For memory resources, nodeadm will reserve according to the following table for hybrid nodes
-	// 255 MiB when total memory is < 1GiB
-	// 25% of first 4GiB of total memory
-	// 20% of next 4GiB of total memory
-	// 10% of next 8 GiB of total memory
-	// 6% of next 112 GiB of total memory
-	// 2% of remaining total memory
 	switch {
 	case totalMemoryGiB < 1:
 		reserveMemoryString = fmt.Sprintf("%dMi", 255)
@@ -313,13 +381,7 @@ func (ksc *kubeletConfig) withHybridReservedResources() error {
 	case totalMemoryGiB > 128:
 		reserveMemoryString = fmt.Sprintf("%dGi", int(math.Round((0.25*4)+(0.20*4)+(0.10*8)+(0.06*112)+float64(totalMemoryGiB-128)*0.02)))
 	}
-
-	ksc.KubeReserved = map[string]string{
-		"cpu":               fmt.Sprintf("%dm", getCPUMillicoresToReserve()),
-		"ephemeral-storage": "1Gi",
-		"memory":            reserveMemoryString,
-	}
-	return nil
+	return reserveMemoryString
 }
 
 // withPodInfraContainerImage determines whether to add the
@@ -363,9 +425,22 @@ func (k *kubelet) GenerateKubeletConfig() (*kubeletConfig, error) {
 	if k.nodeConfig.IsHybridNode() {
 		kubeletConfig.withHybridCloudProvider(k.nodeConfig, k.flags)
 		kubeletConfig.withHybridNodeLabels(k.nodeConfig, k.flags)
-		if err := kubeletConfig.withHybridReservedResources(); err != nil {
+		kubeletConfig.withHybridNodeTaints(k.nodeConfig)
+		if err := kubeletConfig.withHybridSwap(k.nodeConfig, kubeletVersion); err != nil {
+			return nil, err
+		}
+		if err := kubeletConfig.withHybridReservedResources(k.nodeConfig); err != nil {
 			return nil, err
 		}
+		if k.nodeConfig.Spec.Hybrid.CPUManager == api.CPUManagerPolicyStatic {
+			nodes, err := system.GetNUMANodes()
+			if err != nil {
+				return nil, err
+			}
+			if err := kubeletConfig.withCPUManager(k.nodeConfig, nodes); err != nil {
+				return nil, err
+			}
+		}
 
 		// On Ubuntu, systemd-resolved adds loopback address as nameserver to /etc/resolv.conf
 		// This causes pods not being able to do successful dns lookups
@@ -413,7 +488,7 @@ func (k *kubelet) writeKubeletConfigToFile() error {
 	k.flags["config"] = configPath
 
 	zap.L().Info("Writing kubelet config to file...", zap.String("path", configPath))
-	return util.WriteFileWithDir(configPath, kubeletConfigBytes, kubeletConfigPerm)
+	return k.writeFile(configPath, kubeletConfigBytes, kubeletConfigPerm)
 }
 
 // WriteKubeletConfigToDir writes nodeadm's generated kubelet config to the
@@ -434,7 +509,7 @@ func (k *kubelet) writeKubeletConfigToDir() error {
 	k.flags["config"] = configPath
 
 	zap.L().Info("Writing kubelet config to file...", zap.String("path", configPath))
-	if err := util.WriteFileWithDir(configPath, kubeletConfigBytes, kubeletConfigPerm); err != nil {
+	if err := k.writeFile(configPath, kubeletConfigBytes, kubeletConfigPerm); err != nil {
 		return err
 	}
 
@@ -459,7 +534,7 @@ func (k *kubelet) writeKubeletConfigToDir() error {
 		if err != nil {
 			return err
 		}
-		if err := util.WriteFileWithDir(filePath, userKubeletConfigBytes, kubeletConfigPerm); err != nil {
+		if err := k.writeFile(filePath, userKubeletConfigBytes, kubeletConfigPerm); err != nil {
 			return err
 		}
 	}
@@ -512,11 +587,20 @@ func getNodeIp(ctx context.Context, imdsClient *imds.Client, cfg *api.NodeConfig
 }
 
 func getCPUMillicoresToReserve() int {
+	return getCPUMillicoresToReserveFor(getTotalCPUMillicores())
+}
+
+// getTotalCPUMillicores returns the node cpu capacity, or 0 if it can't be read.
+func getTotalCPUMillicores() int {
 	totalCPUMillicores, err := system.GetMilliNumCores()
 	if err != nil {
 		zap.L().Error("Error found when GetMilliNumCores", zap.Error(err))
 		return 0
 	}
+	return totalCPUMillicores
+}
+
+func getCPUMillicoresToReserveFor(totalCPUMillicores int) int {
 	cpuRanges := []int{0, 1000, 2000, 4000, totalCPUMillicores}
 	cpuPercentageReservedForRanges := []int{600, 100, 50, 25}
 	cpuToReserve := 0
@@ -3,7 +3,9 @@ package kubelet
 import (
 	"testing"
 
+	"github.com/aws/smithy-go/ptr"
 	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
 
 	"github.com/aws/eks-hybrid/internal/api"
 )
@@ -63,6 +65,119 @@ func TestHybridLabels(t *testing.T) {
 	assert.Equal(t, kubeletArgs["node-labels"], expectedLabels)
 }
 
+func TestHybridLabelsWithUserLabels(t *testing.T) {
+	nodeConfig := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Hybrid: &api.HybridOptions{
+				SSM: &api.SSM{
+					ActivationCode: "activation-code",
+					ActivationID:   "activation-id",
+				},
+				Labels: map[string]string{
+					"topology.example.com/rack": "r1",
+					"node.kubernetes.io/role":   "edge",
+					"example.com/empty":         "",
+				},
+			},
+		},
+	}
+	expectedLabels := "eks.amazonaws.com/compute-type=hybrid,eks.amazonaws.com/hybrid-credential-provider=ssm," +
+		"example.com/empty=,node.kubernetes.io/role=edge,topology.example.com/rack=r1"
+	kubeletArgs := make(map[string]string)
+	kubeletConfig := defaultKubeletSubConfig()
+	kubeletConfig.withHybridNodeLabels(&nodeConfig, kubeletArgs)
+	assert.Equal(t, expectedLabels, kubeletArgs["node-labels"])
+}
+
+func TestHybridLabelsWithRuntimes(t *testing.T) {
+	nodeConfig := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Containerd: api.ContainerdOptions{
+				Runtimes: []api.ContainerdRuntime{
+					{Name: "gvisor", RuntimeType: "io.containerd.runsc.v1", NodeLabel: true},
+					{Name: "kata", RuntimeType: "io.containerd.kata.v2"},
+				},
+			},
+			Hybrid: &api.HybridOptions{
+				SSM: &api.SSM{
+					ActivationCode: "activation-code",
+					ActivationID:   "activation-id",
+				},
+				Labels: map[string]string{
+					"topology.example.com/rack": "r1",
+				},
+			},
+		},
+	}
+	expectedLabels := "eks.amazonaws.com/compute-type=hybrid,eks.amazonaws.com/hybrid-credential-provider=ssm," +
+		"runtime.hybrid.eks.amazonaws.com/gvisor=true,topology.example.com/rack=r1"
+	kubeletArgs := make(map[string]string)
+	kubeletConfig := defaultKubeletSubConfig()
+	kubeletConfig.withHybridNodeLabels(&nodeConfig, kubeletArgs)
+	assert.Equal(t, expectedLabels, kubeletArgs["node-labels"])
+}
+
+func TestHybridTaints(t *testing.T) {
+	nodeConfig := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Hybrid: &api.HybridOptions{
+				Taints: []api.Taint{
+					{Key: "example.com/edge", Value: "true", Effect: api.TaintEffectNoSchedule},
+					{Key: "dedicated", Effect: api.TaintEffectNoExecute},
+				},
+			},
+		},
+	}
+	kubeletConfig := defaultKubeletSubConfig()
+	kubeletConfig.withHybridNodeTaints(&nodeConfig)
+	assert.Equal(t, []v1.Taint{
+		{Key: "example.com/edge", Value: "true", Effect: v1.TaintEffectNoSchedule},
+		{Key: "dedicated", Effect: v1.TaintEffectNoExecute},
+	}, kubeletConfig.RegisterWithTaints)
+}
+
+func TestHybridSwap(t *testing.T) {
+	nodeConfig := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Hybrid: &api.HybridOptions{
+				Swap: api.SwapModeLimited,
+			},
+		},
+	}
+
+	kubeletConfig := defaultKubeletSubConfig()
+	assert.NoError(t, kubeletConfig.withHybridSwap(&nodeConfig, "v1.31.0"))
+	assert.Equal(t, ptr.Bool(false), kubeletConfig.FailSwapOn)
+	assert.Equal(t, &memorySwapConfiguration{SwapBehavior: "LimitedSwap"}, kubeletConfig.MemorySwap)
+	assert.NotContains(t, kubeletConfig.FeatureGates, "NodeSwap")
+
+	kubeletConfig = defaultKubeletSubConfig()
+	assert.NoError(t, kubeletConfig.withHybridSwap(&nodeConfig, "v1.29.3"))
+	assert.True(t, kubeletConfig.FeatureGates["NodeSwap"])
+
+	kubeletConfig = defaultKubeletSubConfig()
+	assert.EqualError(t, kubeletConfig.withHybridSwap(&nodeConfig, "v1.27.16"), "limited swap requires kubelet v1.28 or later, found v1.27.16")
+}
+
+func TestHybridSwapDisabled(t *testing.T) {
+	nodeConfig := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Hybrid: &api.HybridOptions{},
+		},
+	}
+
+	kubeletConfig := defaultKubeletSubConfig()
+	assert.NoError(t, kubeletConfig.withHybridSwap(&nodeConfig, "v1.31.0"))
+	assert.Nil(t, kubeletConfig.FailSwapOn)
+	assert.Nil(t, kubeletConfig.MemorySwap)
+}
+
+func TestIsManagedNodeLabel(t *testing.T) {
+	assert.True(t, IsManagedNodeLabel("eks.amazonaws.com/compute-type"))
+	assert.True(t, IsManagedNodeLabel("eks.amazonaws.com/hybrid-credential-provider"))
+	assert.False(t, IsManagedNodeLabel("eks.amazonaws.com/nodegroup"))
+}
+
 func TestResolvConf(t *testing.T) {
 	resolvConfPath := "/dummy/path/to/resolv.conf"
 	kubeletConfig := defaultKubeletSubConfig()
@@ -0,0 +1,140 @@
+package kubelet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/system"
+)
+
+const (
+	// cpuManagerStateFile is where kubelet checkpoints the CPU manager state.
+	// kubelet refuses to start if the policy in it differs from the configured one.
+	cpuManagerStateFile = "/var/lib/kubelet/cpu_manager_state"
+
+	// topologyManagerPolicy aligns the exclusive cpus of a container with its
+	// other resources when possible, without rejecting pods that can't be aligned.
+	topologyManagerPolicy = "best-effort"
+)
+
+// withCPUManager configures the static CPU manager policy, reserving whole physical
+// cores on the first NUMA node for system and Kubernetes daemons. The number of
+// cores covers the cpu reserved in kubeReserved and systemReserved, so the node
+// allocatable doesn't change.
+//
+// kubelet doesn't allow reservedSystemCPUs with the reserved cgroups, and it replaces
+// the reserved cpu with the size of reservedSystemCPUs, so both are set accordingly.
+func (ksc *kubeletConfig) withCPUManager(cfg *api.NodeConfig, nodes []system.NUMANode) error {
+	if cfg.Spec.Hybrid.CPUManager != api.CPUManagerPolicyStatic {
+		return nil
+	}
+	reservedMilliCPU, err := reservedMilliCPU(ksc.KubeReserved, ksc.SystemReserved)
+	if err != nil {
+		return err
+	}
+	cpus, err := selectReservedCPUs(nodes, reservedMilliCPU)
+	if err != nil {
+		return err
+	}
+
+	ksc.CPUManagerPolicy = string(api.CPUManagerPolicyStatic)
+	ksc.TopologyManagerPolicy = topologyManagerPolicy
+	ksc.ReservedSystemCPUs = formatCPUList(cpus)
+	ksc.KubeReservedCgroup = nil
+	ksc.SystemReservedCgroup = nil
+	if ksc.KubeReserved == nil {
+		ksc.KubeReserved = map[string]string{}
+	}
+	ksc.KubeReserved["cpu"] = strconv.Itoa(len(cpus))
+	delete(ksc.SystemReserved, "cpu")
+	if len(ksc.SystemReserved) == 0 {
+		ksc.SystemReserved = nil
+	}
+
+	zap.L().Info("Configuring static CPU manager",
+		zap.String("reservedSystemCPUs", ksc.ReservedSystemCPUs),
+		zap.String("topologyManagerPolicy", ksc.TopologyManagerPolicy),
+	)
+	return nil
+}
+
+// reservedMilliCPU returns the total cpu reserved in millicores.
+func reservedMilliCPU(reserved ...map[string]string) (int64, error) {
+	var total int64
+	for _, r := range reserved {
+		value, ok := r["cpu"]
+		if !ok {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return 0, fmt.Errorf("parsing reserved cpu %q: %w", value, err)
+		}
+		total += quantity.MilliValue()
+	}
+	return total, nil
+}
+
+// selectReservedCPUs picks whole physical cores, with all their sibling cpus, from
+// the first NUMA node until they cover milliCPU. At least one core is always
+// reserved, since the static policy requires a non-zero cpu reservation.
+func selectReservedCPUs(nodes []system.NUMANode, milliCPU int64) ([]int, error) {
+	if len(nodes) == 0 || len(nodes[0].Cores) == 0 {
+		return nil, fmt.Errorf("no cpu topology available to select reserved cpus")
+	}
+	node := nodes[0]
+	var cpus []int
+	for _, core := range node.Cores {
+		if len(cpus) > 0 && int64(len(cpus))*1000 >= milliCPU {
+			break
+		}
+		cpus = append(cpus, core.CPUs...)
+	}
+	if int64(len(cpus))*1000 < milliCPU {
+		return nil, fmt.Errorf("reserved cpu %dm doesn't fit in the %d cpus of NUMA node %d", milliCPU, len(cpus), node.ID)
+	}
+	return cpus, nil
+}
+
+// formatCPUList formats cpus as a kubelet cpu list, like `0,1,8,9`.
+func formatCPUList(cpus []int) string {
+	ids := make([]string, 0, len(cpus))
+	for _, cpu := range cpus {
+		ids = append(ids, strconv.Itoa(cpu))
+	}
+	return strings.Join(ids, ",")
+}
+
+// resetCPUManagerState removes the CPU manager checkpoint when it was written with
+// a different policy, since kubelet fails to start otherwise.
+func resetCPUManagerState(cfg *api.NodeConfig, stateFile string) error {
+	policy := api.CPUManagerPolicyNone
+	if cfg.IsHybridNode() && cfg.Spec.Hybrid.CPUManager == api.CPUManagerPolicyStatic {
+		policy = api.CPUManagerPolicyStatic
+	}
+	data, err := os.ReadFile(filepath.Clean(stateFile))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var state struct {
+		PolicyName string `json:"policyName"`
+	}
+	if err := json.Unmarshal(data, &state); err == nil && state.PolicyName == string(policy) {
+		return nil
+	}
+	zap.L().Info("Removing CPU manager state for policy change", zap.String("file", stateFile), zap.String("policy", string(policy)))
+	if err := os.Remove(stateFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
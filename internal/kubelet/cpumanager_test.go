@@ -0,0 +1,143 @@
+package kubelet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/system"
+)
+
+// twoNodeTopology has two NUMA nodes with four hyperthreaded cores each.
+var twoNodeTopology = []system.NUMANode{
+	{
+		ID: 0,
+		Cores: []system.CPUCore{
+			{ID: 0, CPUs: []int{0, 8}},
+			{ID: 1, CPUs: []int{1, 9}},
+			{ID: 2, CPUs: []int{2, 10}},
+			{ID: 3, CPUs: []int{3, 11}},
+		},
+	},
+	{
+		ID: 1,
+		Cores: []system.CPUCore{
+			{ID: 0, SocketID: 1, CPUs: []int{4, 12}},
+			{ID: 1, SocketID: 1, CPUs: []int{5, 13}},
+			{ID: 2, SocketID: 1, CPUs: []int{6, 14}},
+			{ID: 3, SocketID: 1, CPUs: []int{7, 15}},
+		},
+	},
+}
+
+func staticCPUManagerNodeConfig() *api.NodeConfig {
+	return &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Hybrid: &api.HybridOptions{
+				CPUManager: api.CPUManagerPolicyStatic,
+			},
+		},
+	}
+}
+
+func TestWithCPUManagerStatic(t *testing.T) {
+	g := NewWithT(t)
+	ksc := defaultKubeletSubConfig()
+	ksc.SystemReservedCgroup = ptr.String("/system")
+	ksc.KubeReservedCgroup = ptr.String("/runtime")
+	ksc.KubeReserved = map[string]string{"cpu": "110m", "memory": "4Gi"}
+	ksc.SystemReserved = map[string]string{"cpu": "2", "memory": "1Gi"}
+
+	g.Expect(ksc.withCPUManager(staticCPUManagerNodeConfig(), twoNodeTopology)).To(Succeed())
+	g.Expect(ksc.CPUManagerPolicy).To(Equal("static"))
+	g.Expect(ksc.TopologyManagerPolicy).To(Equal("best-effort"))
+	// 2110m needs three cpus, which take two whole cores
+	g.Expect(ksc.ReservedSystemCPUs).To(Equal("0,8,1,9"))
+	g.Expect(ksc.KubeReserved).To(Equal(map[string]string{"cpu": "4", "memory": "4Gi"}))
+	g.Expect(ksc.SystemReserved).To(Equal(map[string]string{"memory": "1Gi"}))
+	g.Expect(ksc.KubeReservedCgroup).To(BeNil())
+	g.Expect(ksc.SystemReservedCgroup).To(BeNil())
+}
+
+func TestWithCPUManagerReservesAtLeastOneCore(t *testing.T) {
+	g := NewWithT(t)
+	ksc := defaultKubeletSubConfig()
+
+	g.Expect(ksc.withCPUManager(staticCPUManagerNodeConfig(), twoNodeTopology)).To(Succeed())
+	g.Expect(ksc.ReservedSystemCPUs).To(Equal("0,8"))
+	g.Expect(ksc.KubeReserved).To(Equal(map[string]string{"cpu": "2"}))
+	g.Expect(ksc.SystemReserved).To(BeNil())
+}
+
+func TestWithCPUManagerNone(t *testing.T) {
+	g := NewWithT(t)
+	ksc := defaultKubeletSubConfig()
+	cfg := staticCPUManagerNodeConfig()
+	cfg.Spec.Hybrid.CPUManager = api.CPUManagerPolicyNone
+
+	g.Expect(ksc.withCPUManager(cfg, twoNodeTopology)).To(Succeed())
+	g.Expect(ksc.CPUManagerPolicy).To(BeEmpty())
+	g.Expect(ksc.ReservedSystemCPUs).To(BeEmpty())
+}
+
+func TestWithCPUManagerReservationDoesNotFit(t *testing.T) {
+	g := NewWithT(t)
+	ksc := defaultKubeletSubConfig()
+	ksc.KubeReserved = map[string]string{"cpu": "9"}
+
+	g.Expect(ksc.withCPUManager(staticCPUManagerNodeConfig(), twoNodeTopology)).To(
+		MatchError("reserved cpu 9000m doesn't fit in the 8 cpus of NUMA node 0"))
+}
+
+func TestResetCPUManagerState(t *testing.T) {
+	testCases := []struct {
+		name       string
+		state      string
+		cpuManager api.CPUManagerPolicy
+		wantExists bool
+	}{
+		{
+			name:       "same policy",
+			state:      `{"policyName":"static","defaultCpuSet":"2-15","checksum":1}`,
+			cpuManager: api.CPUManagerPolicyStatic,
+			wantExists: true,
+		},
+		{
+			name:       "none to static",
+			state:      `{"policyName":"none","defaultCpuSet":"","checksum":1}`,
+			cpuManager: api.CPUManagerPolicyStatic,
+			wantExists: false,
+		},
+		{
+			name:       "static to default",
+			state:      `{"policyName":"static","defaultCpuSet":"2-15","checksum":1}`,
+			wantExists: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			stateFile := filepath.Join(t.TempDir(), "cpu_manager_state")
+			g.Expect(os.WriteFile(stateFile, []byte(tc.state), 0o644)).To(Succeed())
+			cfg := staticCPUManagerNodeConfig()
+			cfg.Spec.Hybrid.CPUManager = tc.cpuManager
+
+			g.Expect(resetCPUManagerState(cfg, stateFile)).To(Succeed())
+			_, err := os.Stat(stateFile)
+			if tc.wantExists {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(os.IsNotExist(err)).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestResetCPUManagerStateMissingFile(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(resetCPUManagerState(staticCPUManagerNodeConfig(), filepath.Join(t.TempDir(), "cpu_manager_state"))).To(Succeed())
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/daemon"
 	"github.com/aws/eks-hybrid/internal/kubernetes"
+	"github.com/aws/eks-hybrid/internal/render"
+	"github.com/aws/eks-hybrid/internal/util"
 	"github.com/aws/eks-hybrid/internal/validation"
 )
 
@@ -19,7 +21,10 @@ const (
 	kubernetesAuthenticationValidation = "k8s-authentication-validation"
 )
 
-var _ daemon.Daemon = &kubelet{}
+var (
+	_ daemon.Daemon   = &kubelet{}
+	_ daemon.Renderer = &kubelet{}
+)
 
 type CredentialProviderAwsConfig struct {
 	Profile         string
@@ -37,6 +42,8 @@ type kubelet struct {
 	credentialProviderAwsConfig CredentialProviderAwsConfig
 	validationRunner            *validation.Runner[*api.NodeConfig]
 	logger                      *zap.Logger
+	// writeFile writes the generated files, it's replaced to render them instead
+	writeFile render.WriteFileFunc
 }
 
 func NewKubeletDaemon(daemonManager daemon.DaemonManager, cfg *api.NodeConfig, awsConfig *aws.Config, credentialProviderAwsConfig CredentialProviderAwsConfig, logger *zap.Logger, skipPhases []string) daemon.Daemon {
@@ -48,6 +55,7 @@ func NewKubeletDaemon(daemonManager daemon.DaemonManager, cfg *api.NodeConfig, a
 		flags:                       make(map[string]string),
 		credentialProviderAwsConfig: credentialProviderAwsConfig,
 		logger:                      logger,
+		writeFile:                   util.WriteFileWithDir,
 	}
 
 	if skipPhases != nil {
@@ -58,19 +66,10 @@ func NewKubeletDaemon(daemonManager daemon.DaemonManager, cfg *api.NodeConfig, a
 }
 
 func (k *kubelet) Configure(ctx context.Context) error {
-	if err := k.writeKubeletConfig(); err != nil {
-		return err
-	}
-	if err := k.writeKubeconfig(); err != nil {
-		return err
-	}
-	if err := k.writeImageCredentialProviderConfig(); err != nil {
+	if err := k.writeConfigFiles(); err != nil {
 		return err
 	}
-	if err := writeClusterCaCert(k.nodeConfig.Spec.Cluster.CertificateAuthority); err != nil {
-		return err
-	}
-	if err := k.writeKubeletEnvironment(); err != nil {
+	if err := resetCPUManagerState(k.nodeConfig, cpuManagerStateFile); err != nil {
 		return err
 	}
 
@@ -86,6 +85,40 @@ func (k *kubelet) Configure(ctx context.Context) error {
 	return nil
 }
 
+// Render returns the files Configure would write without writing them.
+func (k *kubelet) Render(ctx context.Context) ([]render.File, error) {
+	recorder := &render.Recorder{}
+	renderer := &kubelet{
+		nodeConfig:                  k.nodeConfig,
+		awsConfig:                   k.awsConfig,
+		environment:                 make(map[string]string),
+		flags:                       make(map[string]string),
+		credentialProviderAwsConfig: k.credentialProviderAwsConfig,
+		logger:                      k.logger,
+		writeFile:                   recorder.WriteFile,
+	}
+	if err := renderer.writeConfigFiles(); err != nil {
+		return nil, err
+	}
+	return recorder.Files(), nil
+}
+
+func (k *kubelet) writeConfigFiles() error {
+	if err := k.writeKubeletConfig(); err != nil {
+		return err
+	}
+	if err := k.writeKubeconfig(); err != nil {
+		return err
+	}
+	if err := k.writeImageCredentialProviderConfig(); err != nil {
+		return err
+	}
+	if err := k.writeClusterCaCert(k.nodeConfig.Spec.Cluster.CertificateAuthority); err != nil {
+		return err
+	}
+	return k.writeKubeletEnvironment()
+}
+
 func (k *kubelet) EnsureRunning(ctx context.Context) error {
 	if err := k.daemonManager.DaemonReload(); err != nil {
 		return err
@@ -2,9 +2,8 @@ package kubelet
 
 import (
 	"fmt"
+	"sort"
 	"strings"
-
-	"github.com/aws/eks-hybrid/internal/util"
 )
 
 const (
@@ -22,6 +21,8 @@ func (k *kubelet) writeKubeletEnvironment() error {
 	for flag, value := range k.flags {
 		kubeletFlags = append(kubeletFlags, fmt.Sprintf("--%s=%s", flag, value))
 	}
+	// sort nodeadm's flags so the generated file is stable across runs
+	sort.Strings(kubeletFlags)
 	// append user-provided flags at the end to give them precedence
 	kubeletFlags = append(kubeletFlags, k.nodeConfig.Spec.Kubelet.Flags...)
 	// expose these flags via an environment variable scoped to nodeadm
@@ -31,7 +32,8 @@ func (k *kubelet) writeKubeletEnvironment() error {
 	for eKey, eValue := range k.environment {
 		kubeletEnvironment = append(kubeletEnvironment, fmt.Sprintf(`%s="%s"`, eKey, eValue))
 	}
-	return util.WriteFileWithDir(kubeletEnvironmentFilePath, []byte(strings.Join(kubeletEnvironment, "\n")), kubeletConfigPerm)
+	sort.Strings(kubeletEnvironment)
+	return k.writeFile(kubeletEnvironmentFilePath, []byte(strings.Join(kubeletEnvironment, "\n")), kubeletConfigPerm)
 }
 
 // Add values to the environment variables map in a terse manner
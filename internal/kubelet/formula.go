@@ -0,0 +1,305 @@
+package kubelet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// formulaEnv is what the names and percentages in a reservation formula refer to.
+type formulaEnv struct {
+	resource string
+	// capacity of the resource in its base unit, millicores for cpu and bytes for memory
+	capacity float64
+	maxPods  float64
+}
+
+// isFormula returns whether a reservation value is a formula instead of a single
+// quantity or percentage.
+func isFormula(value string) bool {
+	if _, err := resource.ParseQuantity(value); err == nil {
+		return false
+	}
+	return strings.ContainsAny(value, "+-*(),")
+}
+
+// evaluateFormula returns the amount of a resource a reservation formula reserves,
+// in the base unit of the resource: millicores for cpu, bytes for memory and
+// ephemeral-storage and a count for pid.
+//
+// Formulas combine, with `+`, `-`, `*` and parentheses:
+//   - quantities with a unit like `255Mi` or `100m`
+//   - numbers without a unit like `11`
+//   - percentages of the node capacity like `5%`, for cpu and memory
+//   - `maxPods`, the max pods of the node
+//   - `min(a, b, ...)` and `max(a, b, ...)`
+//   - `tiered(25%:4Gi, 20%:4Gi, 2%)`, which reserves a percentage of each tier of the
+//     node capacity, the last tier without a size being the rest of the capacity
+//
+// For example `255Mi + 11Mi * maxPods` or `min(10%, 2Gi)`.
+func evaluateFormula(formula string, env formulaEnv) (float64, error) {
+	p := &formulaParser{input: formula, env: env}
+	value, err := p.expression()
+	if err != nil {
+		return 0, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return 0, p.errorf("unexpected %q", p.input[p.pos:])
+	}
+	return value, nil
+}
+
+type formulaParser struct {
+	input string
+	pos   int
+	env   formulaEnv
+}
+
+// expression parses terms separated by + and -.
+func (p *formulaParser) expression() (float64, error) {
+	value, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch p.peek() {
+		case '+':
+			p.pos++
+			right, err := p.term()
+			if err != nil {
+				return 0, err
+			}
+			value += right
+		case '-':
+			p.pos++
+			right, err := p.term()
+			if err != nil {
+				return 0, err
+			}
+			value -= right
+		default:
+			return value, nil
+		}
+	}
+}
+
+// term parses factors separated by *.
+func (p *formulaParser) term() (float64, error) {
+	value, err := p.factor()
+	if err != nil {
+		return 0, err
+	}
+	for p.peek() == '*' {
+		p.pos++
+		right, err := p.factor()
+		if err != nil {
+			return 0, err
+		}
+		value *= right
+	}
+	return value, nil
+}
+
+func (p *formulaParser) factor() (float64, error) {
+	switch c := p.peek(); {
+	case c == '(':
+		p.pos++
+		value, err := p.expression()
+		if err != nil {
+			return 0, err
+		}
+		if err := p.expect(')'); err != nil {
+			return 0, err
+		}
+		return value, nil
+	case c == '.' || unicode.IsDigit(c):
+		return p.number()
+	case unicode.IsLetter(c):
+		return p.name()
+	case c == 0:
+		return 0, p.errorf("unexpected end of formula")
+	default:
+		return 0, p.errorf("unexpected %q", string(c))
+	}
+}
+
+// number parses a quantity, a number without a unit or a percentage of the capacity.
+func (p *formulaParser) number() (float64, error) {
+	start := p.pos
+	number, unit := p.scanNumber()
+	if p.peek() == '%' {
+		p.pos++
+		percentage, err := p.percentage(start, number+unit+"%")
+		if err != nil {
+			return 0, err
+		}
+		return p.env.capacity * percentage / 100, nil
+	}
+	if unit == "" {
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return 0, p.errorAt(start, "invalid number %q", number)
+		}
+		return value, nil
+	}
+	return p.quantity(start, number+unit)
+}
+
+// percentage parses the percentage value that starts at start.
+func (p *formulaParser) percentage(start int, value string) (float64, error) {
+	if p.env.resource != "cpu" && p.env.resource != "memory" {
+		return 0, fmt.Errorf("percentages are only supported for cpu and memory")
+	}
+	percentage, _, err := parsePercentage(value)
+	if err != nil {
+		return 0, p.errorAt(start, "invalid percentage %q: %w", value, err)
+	}
+	return percentage, nil
+}
+
+// quantity parses the quantity value that starts at start, in the base unit of the
+// resource.
+func (p *formulaParser) quantity(start int, value string) (float64, error) {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, p.errorAt(start, "invalid quantity %q", value)
+	}
+	if p.env.resource == "cpu" {
+		return float64(quantity.MilliValue()), nil
+	}
+	return float64(quantity.Value()), nil
+}
+
+func (p *formulaParser) name() (float64, error) {
+	start := p.pos
+	for p.pos < len(p.input) && unicode.IsLetter(rune(p.input[p.pos])) {
+		p.pos++
+	}
+	name := p.input[start:p.pos]
+	switch name {
+	case "maxPods":
+		return p.env.maxPods, nil
+	case "min", "max":
+		return p.minMax(name)
+	case "tiered":
+		return p.tiered()
+	}
+	return 0, p.errorAt(start, "unknown name %q", name)
+}
+
+// minMax parses the arguments of min and max.
+func (p *formulaParser) minMax(name string) (float64, error) {
+	if err := p.expect('('); err != nil {
+		return 0, err
+	}
+	var result float64
+	for i := 0; ; i++ {
+		value, err := p.expression()
+		if err != nil {
+			return 0, err
+		}
+		if i == 0 || (name == "min" && value < result) || (name == "max" && value > result) {
+			result = value
+		}
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+	return result, p.expect(')')
+}
+
+// tiered parses the tiers of tiered and reserves their percentage of the capacity in
+// each tier.
+func (p *formulaParser) tiered() (float64, error) {
+	if err := p.expect('('); err != nil {
+		return 0, err
+	}
+	var reserved, tierStart float64
+	for {
+		p.skipSpaces()
+		start := p.pos
+		number, unit := p.scanNumber()
+		if err := p.expect('%'); err != nil {
+			return 0, err
+		}
+		percentage, err := p.percentage(start, number+unit+"%")
+		if err != nil {
+			return 0, err
+		}
+		end := p.env.capacity
+		last := p.peek() != ':'
+		if !last {
+			p.pos++
+			p.skipSpaces()
+			sizeStart := p.pos
+			number, unit := p.scanNumber()
+			size, err := p.quantity(sizeStart, number+unit)
+			if err != nil {
+				return 0, err
+			}
+			end = min(tierStart+size, p.env.capacity)
+		}
+		if end > tierStart {
+			reserved += (end - tierStart) * percentage / 100
+			tierStart = end
+		}
+		if last || p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+	return reserved, p.expect(')')
+}
+
+// scanNumber scans a number and the unit that follows it.
+func (p *formulaParser) scanNumber() (number, unit string) {
+	start := p.pos
+	for p.pos < len(p.input) && (p.input[p.pos] == '.' || unicode.IsDigit(rune(p.input[p.pos]))) {
+		p.pos++
+	}
+	unitStart := p.pos
+	for p.pos < len(p.input) && unicode.IsLetter(rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return p.input[start:unitStart], p.input[unitStart:p.pos]
+}
+
+func (p *formulaParser) expect(c rune) error {
+	if p.peek() != c {
+		if p.pos >= len(p.input) {
+			return p.errorf("expected %q at the end of formula", string(c))
+		}
+		return p.errorf("expected %q", string(c))
+	}
+	p.pos++
+	return nil
+}
+
+// peek skips spaces and returns the next character, or 0 at the end of the formula.
+func (p *formulaParser) peek() rune {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return rune(p.input[p.pos])
+}
+
+func (p *formulaParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *formulaParser) errorf(format string, args ...any) error {
+	return p.errorAt(p.pos, format, args...)
+}
+
+// errorAt returns an error for the formula at the 0-based pos.
+func (p *formulaParser) errorAt(pos int, format string, args ...any) error {
+	return fmt.Errorf("at position %d: %w", pos+1, fmt.Errorf(format, args...))
+}
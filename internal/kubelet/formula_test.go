@@ -0,0 +1,75 @@
+package kubelet
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestEvaluateFormula(t *testing.T) {
+	const gi = 1024 * 1024 * 1024
+	cpu := formulaEnv{resource: "cpu", capacity: 8000, maxPods: 110}
+	memory := formulaEnv{resource: "memory", capacity: 32 * gi, maxPods: 110}
+	pid := formulaEnv{resource: "pid", maxPods: 110}
+	testCases := []struct {
+		name    string
+		formula string
+		env     formulaEnv
+		want    float64
+	}{
+		{name: "eks-default memory", formula: "255Mi + 11Mi * maxPods", env: memory, want: (255 + 11*110) * 1024 * 1024},
+		{name: "hybrid-default cpu", formula: "tiered(6%:1, 1%:1, 0.5%:2, 0.25%)", env: cpu, want: 90},
+		{name: "hybrid-default memory", formula: "tiered(25%:4Gi, 20%:4Gi, 10%:8Gi, 6%:112Gi, 2%)", env: memory, want: (1 + 0.8 + 0.8 + 0.96) * gi},
+		{name: "tiers beyond the capacity", formula: "tiered(50%:1, 10%:100)", env: formulaEnv{resource: "cpu", capacity: 500}, want: 250},
+		{name: "last tier with a size", formula: "tiered(10%:16Gi)", env: memory, want: 1.6 * gi},
+		{name: "min", formula: "min(10%, 2Gi)", env: memory, want: 2 * gi},
+		{name: "max", formula: "max(1%, 100m, 50m)", env: cpu, want: 100},
+		{name: "parentheses", formula: "(maxPods - 10) * 10", env: pid, want: 1000},
+		{name: "precedence", formula: "1000 + 2 * maxPods", env: pid, want: 1220},
+		{name: "cpu quantity", formula: "1 * 500m + 0.5 * 1", env: cpu, want: 500.5},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			value, err := evaluateFormula(tc.formula, tc.env)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(value).To(BeNumerically("~", tc.want, 0.001))
+		})
+	}
+}
+
+func TestEvaluateFormulaErrors(t *testing.T) {
+	memory := formulaEnv{resource: "memory"}
+	testCases := []struct {
+		name    string
+		formula string
+		env     formulaEnv
+		wantErr string
+	}{
+		{name: "unknown name", formula: "255Mi + pods", env: memory, wantErr: `at position 9: unknown name "pods"`},
+		{name: "missing operand", formula: "255Mi +", env: memory, wantErr: "at position 8: unexpected end of formula"},
+		{name: "unclosed parenthesis", formula: "min(1Gi, 2Gi", env: memory, wantErr: `at position 13: expected ")" at the end of formula`},
+		{name: "trailing input", formula: "(1Gi) 2Gi", env: memory, wantErr: `at position 7: unexpected "2Gi"`},
+		{name: "invalid quantity", formula: "1Gx + 1", env: memory, wantErr: `at position 1: invalid quantity "1Gx"`},
+		{name: "invalid percentage", formula: "200% + 1", env: memory, wantErr: `at position 1: invalid percentage "200%": percentage must be a number between 0 and 100`},
+		{name: "percentage of pid", formula: "5% + 1", env: formulaEnv{resource: "pid"}, wantErr: "percentages are only supported for cpu and memory"},
+		{name: "tier without percentage", formula: "tiered(4Gi, 2%)", env: memory, wantErr: `at position 11: expected "%"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := evaluateFormula(tc.formula, tc.env)
+			g.Expect(err).To(MatchError(tc.wantErr))
+		})
+	}
+}
+
+func TestIsFormula(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(isFormula("500Mi")).To(BeFalse())
+	g.Expect(isFormula("5%")).To(BeFalse())
+	g.Expect(isFormula("1e-3")).To(BeFalse())
+	g.Expect(isFormula("lots")).To(BeFalse())
+	g.Expect(isFormula("255Mi + 11Mi * maxPods")).To(BeTrue())
+	g.Expect(isFormula("min(10%, 2Gi)")).To(BeTrue())
+}
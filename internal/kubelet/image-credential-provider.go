@@ -14,7 +14,6 @@ import (
 	config "k8s.io/kubelet/config/v1"
 
 	"github.com/aws/eks-hybrid/internal/api"
-	"github.com/aws/eks-hybrid/internal/util"
 )
 
 const (
@@ -48,7 +47,7 @@ func (k *kubelet) writeImageCredentialProviderConfig() error {
 	k.flags["image-credential-provider-bin-dir"] = path.Dir(ecrCredentialProviderBinPath)
 	k.flags["image-credential-provider-config"] = imageCredentialProviderConfigPath
 
-	return util.WriteFileWithDir(imageCredentialProviderConfigPath, credentialProviderConfig, imageCredentialProviderPerm)
+	return k.writeFile(imageCredentialProviderConfigPath, credentialProviderConfig, imageCredentialProviderPerm)
 }
 
 func generateImageCredentialProviderConfig(cfg *api.NodeConfig, ecrCredentialProviderBinPath string, kubeletCredentialProviderAwsConfig CredentialProviderAwsConfig) ([]byte, error) {
@@ -55,7 +55,7 @@ func Install(ctx context.Context, opts InstallOptions) error {
 		return errors.Wrap(err, "installing systemd unit")
 	}
 
-	if err := opts.Tracker.Add(artifact.Kubelet); err != nil {
+	if err := opts.Tracker.Add(artifact.Kubelet, filepath.Join(opts.InstallRoot, BinPath), filepath.Join(opts.InstallRoot, UnitPath)); err != nil {
 		return errors.Wrap(err, "adding kubelet to tracker")
 	}
 
@@ -105,11 +105,17 @@ type UninstallOptions struct {
 	// InstallRoot is optionally the root directory of the installation
 	// If not provided, the default will be /
 	InstallRoot string
+	// BinPath is where kubelet was installed. Defaults to BinPath under InstallRoot.
+	BinPath string
 }
 
 func Uninstall(opts UninstallOptions) error {
+	binPath := opts.BinPath
+	if binPath == "" {
+		binPath = filepath.Join(opts.InstallRoot, BinPath)
+	}
 	pathsToRemove := []string{
-		filepath.Join(opts.InstallRoot, BinPath),
+		binPath,
 		filepath.Join(opts.InstallRoot, UnitPath),
 		filepath.Join(opts.InstallRoot, kubeconfigPath),
 		filepath.Join(opts.InstallRoot, path.Dir(kubeletConfigRoot)),
@@ -138,12 +144,13 @@ func Uninstall(opts UninstallOptions) error {
 	return nil
 }
 
-func Upgrade(ctx context.Context, src Source, log *zap.Logger) error {
+// Upgrade upgrades kubelet installed at binPath, returning whether the binary was replaced.
+func Upgrade(ctx context.Context, src Source, binPath string, log *zap.Logger) (bool, error) {
 	kubelet, err := src.GetKubelet(ctx)
 	if err != nil {
-		return errors.Wrap(err, "getting kubelet source")
+		return false, errors.Wrap(err, "getting kubelet source")
 	}
 	defer kubelet.Close()
 
-	return artifact.Upgrade(artifactName, BinPath, kubelet, artifactFilePerms, log)
+	return artifact.Upgrade(artifactName, binPath, kubelet, artifactFilePerms, log)
 }
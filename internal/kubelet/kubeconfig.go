@@ -14,7 +14,6 @@ import (
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/iamauthenticator"
 	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
-	"github.com/aws/eks-hybrid/internal/util"
 )
 
 const (
@@ -43,10 +42,10 @@ func (k *kubelet) writeKubeconfig() error {
 		//   - if "aws eks describe-cluster" is bypassed, for local outpost, the value of CLUSTER_NAME parameter will be cluster id.
 		//   - otherwise, the cluster id will use the id returned by "aws eks describe-cluster".
 		k.flags["bootstrap-kubeconfig"] = kubeconfigBootstrapPath
-		return util.WriteFileWithDir(kubeconfigBootstrapPath, kubeconfig, kubeconfigPerm)
+		return k.writeFile(kubeconfigBootstrapPath, kubeconfig, kubeconfigPerm)
 	} else {
 		k.flags["kubeconfig"] = kubeconfigPath
-		return util.WriteFileWithDir(kubeconfigPath, kubeconfig, kubeconfigPerm)
+		return k.writeFile(kubeconfigPath, kubeconfig, kubeconfigPerm)
 	}
 }
 
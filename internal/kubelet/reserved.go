@@ -0,0 +1,251 @@
+package kubelet
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+var (
+	reservableResources = []string{"cpu", "memory", "ephemeral-storage", "pid"}
+	evictionSignals     = []string{
+		"memory.available",
+		"nodefs.available",
+		"nodefs.inodesFree",
+		"imagefs.available",
+		"imagefs.inodesFree",
+		"containerfs.available",
+		"containerfs.inodesFree",
+		"pid.available",
+	}
+	reservedResourcesProfiles = []api.ReservedResourcesProfile{
+		api.ReservedResourcesProfileEKSDefault,
+		api.ReservedResourcesProfileHybridDefault,
+		api.ReservedResourcesProfileMinimal,
+	}
+)
+
+// nodeCapacity is the node capacity that percentages of reserved resources refer to.
+type nodeCapacity struct {
+	milliCPU int
+	// memory in bytes
+	memory uint64
+}
+
+// withReservedResources sets kubeReserved from the profile and the overrides,
+// systemReserved from the overrides and merges the hard eviction thresholds
+// into the defaults.
+func (ksc *kubeletConfig) withReservedResources(reserved *api.ReservedResources, capacity nodeCapacity) error {
+	if reserved == nil {
+		reserved = &api.ReservedResources{}
+	}
+	profile := reserved.Profile
+	if profile == "" {
+		profile = api.ReservedResourcesProfileHybridDefault
+	}
+
+	maxPods := ksc.MaxPods
+	if maxPods == 0 {
+		maxPods = defaultMaxPods
+	}
+	kubeReserved, err := profileKubeReserved(profile, capacity, maxPods)
+	if err != nil {
+		return err
+	}
+	for name, value := range reserved.KubeReserved {
+		quantity, err := resolveReservation(name, value, capacity, maxPods)
+		if err != nil {
+			return fmt.Errorf("kubeReserved: %w", err)
+		}
+		kubeReserved[name] = quantity
+	}
+	ksc.KubeReserved = kubeReserved
+
+	for name, value := range reserved.SystemReserved {
+		quantity, err := resolveReservation(name, value, capacity, maxPods)
+		if err != nil {
+			return fmt.Errorf("systemReserved: %w", err)
+		}
+		if ksc.SystemReserved == nil {
+			ksc.SystemReserved = map[string]string{}
+		}
+		ksc.SystemReserved[name] = quantity
+	}
+
+	for signal, value := range reserved.EvictionHard {
+		if err := validateEvictionThreshold(signal, value); err != nil {
+			return fmt.Errorf("evictionHard: %w", err)
+		}
+		ksc.EvictionHard[signal] = value
+	}
+
+	zap.L().Info("Reserving resources for kubelet",
+		zap.String("profile", string(profile)),
+		zap.Any("kubeReserved", ksc.KubeReserved),
+		zap.Any("systemReserved", ksc.SystemReserved),
+		zap.Any("evictionHard", ksc.EvictionHard),
+	)
+	return nil
+}
+
+// profileKubeReserved returns the kube reserved resources of a profile.
+func profileKubeReserved(profile api.ReservedResourcesProfile, capacity nodeCapacity, maxPods int32) (map[string]string, error) {
+	switch profile {
+	case api.ReservedResourcesProfileEKSDefault:
+		return map[string]string{
+			"cpu":               fmt.Sprintf("%dm", getCPUMillicoresToReserveFor(capacity.milliCPU)),
+			"ephemeral-storage": "1Gi",
+			"memory":            fmt.Sprintf("%dMi", getMemoryMebibytesToReserve(maxPods)),
+		}, nil
+	case api.ReservedResourcesProfileHybridDefault:
+		return map[string]string{
+			"cpu":               fmt.Sprintf("%dm", getCPUMillicoresToReserveFor(capacity.milliCPU)),
+			"ephemeral-storage": "1Gi",
+			"memory":            getHybridMemoryToReserve(capacity.memory),
+		}, nil
+	case api.ReservedResourcesProfileMinimal:
+		return map[string]string{
+			"cpu":               "60m",
+			"ephemeral-storage": "1Gi",
+			"memory":            "255Mi",
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown reserved resources profile %q", profile)
+}
+
+// resolveReservation returns the quantity to reserve for a resource, converting
+// percentages of the node capacity and formulas into quantities.
+func resolveReservation(name, value string, capacity nodeCapacity, maxPods int32) (string, error) {
+	if err := validateReservation(name, value); err != nil {
+		return "", err
+	}
+	if isFormula(value) {
+		env := formulaEnv{resource: name, maxPods: float64(maxPods)}
+		switch name {
+		case "cpu":
+			env.capacity = float64(capacity.milliCPU)
+		case "memory":
+			env.capacity = float64(capacity.memory)
+		}
+		amount, err := evaluateFormula(value, env)
+		if err != nil {
+			return "", fmt.Errorf("invalid formula %q for %s: %w", value, name, err)
+		}
+		if amount < 0 {
+			return "", fmt.Errorf("formula %q for %s reserves a negative amount", value, name)
+		}
+		return formatReservation(name, amount), nil
+	}
+	percentage, ok, _ := parsePercentage(value)
+	if !ok {
+		return value, nil
+	}
+	switch name {
+	case "cpu":
+		return fmt.Sprintf("%dm", int64(float64(capacity.milliCPU)*percentage/100)), nil
+	default:
+		return fmt.Sprintf("%dMi", int64(float64(capacity.memory)*percentage/100/(1024*1024))), nil
+	}
+}
+
+// formatReservation formats an amount of a resource in its base unit as a quantity.
+func formatReservation(name string, amount float64) string {
+	switch name {
+	case "cpu":
+		return fmt.Sprintf("%dm", int64(amount))
+	case "memory", "ephemeral-storage":
+		return fmt.Sprintf("%dMi", int64(amount/(1024*1024)))
+	default:
+		return fmt.Sprintf("%d", int64(amount))
+	}
+}
+
+// validateReservation checks the value is a quantity, a formula or, for cpu and
+// memory, a percentage of the node capacity.
+func validateReservation(name, value string) error {
+	if !slices.Contains(reservableResources, name) {
+		return fmt.Errorf("unsupported resource %q. Must be one of: [%s]", name, strings.Join(reservableResources, ", "))
+	}
+	if isFormula(value) {
+		if _, err := evaluateFormula(value, formulaEnv{resource: name, maxPods: defaultMaxPods}); err != nil {
+			return fmt.Errorf("invalid formula %q for %s: %w", value, name, err)
+		}
+		return nil
+	}
+	if _, ok, err := parsePercentage(value); ok || err != nil {
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+		}
+		if name != "cpu" && name != "memory" {
+			return fmt.Errorf("invalid value %q for %s: percentages are only supported for cpu and memory", value, name)
+		}
+		return nil
+	}
+	if _, err := resource.ParseQuantity(value); err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+	}
+	return nil
+}
+
+func validateEvictionThreshold(signal, value string) error {
+	if !slices.Contains(evictionSignals, signal) {
+		return fmt.Errorf("unsupported eviction signal %q. Must be one of: [%s]", signal, strings.Join(evictionSignals, ", "))
+	}
+	if _, ok, err := parsePercentage(value); ok || err != nil {
+		if err != nil {
+			return fmt.Errorf("invalid threshold %q for %s: %w", value, signal, err)
+		}
+		return nil
+	}
+	if _, err := resource.ParseQuantity(value); err != nil {
+		return fmt.Errorf("invalid threshold %q for %s: %w", value, signal, err)
+	}
+	return nil
+}
+
+// parsePercentage parses values like `5%` or `2.5%`. ok is false when the value
+// isn't a percentage.
+func parsePercentage(value string) (percentage float64, ok bool, err error) {
+	number, ok := strings.CutSuffix(value, "%")
+	if !ok {
+		return 0, false, nil
+	}
+	percentage, err = strconv.ParseFloat(number, 64)
+	if err != nil || percentage < 0 || percentage > 100 {
+		return 0, true, fmt.Errorf("percentage must be a number between 0 and 100")
+	}
+	return percentage, true, nil
+}
+
+// ValidateReservedResources checks the reserved resources config, reporting
+// each problem as a [validation.FieldError] under path.
+func ValidateReservedResources(reserved *api.ReservedResources, path string) []error {
+	var errs []error
+	if reserved.Profile != "" && !slices.Contains(reservedResourcesProfiles, reserved.Profile) {
+		errs = append(errs, validation.NewFieldError(path+".profile", fmt.Sprintf("unknown reserved resources profile %q. Must be one of: %v", reserved.Profile, reservedResourcesProfiles)))
+	}
+	for _, name := range slices.Sorted(maps.Keys(reserved.KubeReserved)) {
+		if err := validateReservation(name, reserved.KubeReserved[name]); err != nil {
+			errs = append(errs, validation.WithField(path+".kubeReserved", err))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(reserved.SystemReserved)) {
+		if err := validateReservation(name, reserved.SystemReserved[name]); err != nil {
+			errs = append(errs, validation.WithField(path+".systemReserved", err))
+		}
+	}
+	for _, signal := range slices.Sorted(maps.Keys(reserved.EvictionHard)) {
+		if err := validateEvictionThreshold(signal, reserved.EvictionHard[signal]); err != nil {
+			errs = append(errs, validation.WithField(path+".evictionHard", err))
+		}
+	}
+	return errs
+}
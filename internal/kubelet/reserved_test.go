@@ -0,0 +1,188 @@
+package kubelet
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+func TestWithReservedResources(t *testing.T) {
+	capacity := nodeCapacity{
+		milliCPU: 8000,
+		memory:   32 * 1024 * 1024 * 1024,
+	}
+	testCases := []struct {
+		name               string
+		reserved           *api.ReservedResources
+		wantKubeReserved   map[string]string
+		wantSystemReserved map[string]string
+		wantEvictionHard   map[string]string
+	}{
+		{
+			name: "defaults to hybrid-default",
+			wantKubeReserved: map[string]string{
+				"cpu":               "90m",
+				"ephemeral-storage": "1Gi",
+				"memory":            "4Gi",
+			},
+			wantEvictionHard: map[string]string{
+				"memory.available":  "100Mi",
+				"nodefs.available":  "10%",
+				"nodefs.inodesFree": "5%",
+			},
+		},
+		{
+			name:     "eks-default",
+			reserved: &api.ReservedResources{Profile: api.ReservedResourcesProfileEKSDefault},
+			wantKubeReserved: map[string]string{
+				"cpu":               "90m",
+				"ephemeral-storage": "1Gi",
+				"memory":            "1465Mi",
+			},
+			wantEvictionHard: map[string]string{
+				"memory.available":  "100Mi",
+				"nodefs.available":  "10%",
+				"nodefs.inodesFree": "5%",
+			},
+		},
+		{
+			name: "minimal with overrides",
+			reserved: &api.ReservedResources{
+				Profile: api.ReservedResourcesProfileMinimal,
+				KubeReserved: map[string]string{
+					"memory": "512Mi",
+					"pid":    "1000",
+				},
+				SystemReserved: map[string]string{
+					"cpu":    "5%",
+					"memory": "2.5%",
+				},
+				EvictionHard: map[string]string{
+					"memory.available": "5%",
+					"pid.available":    "10%",
+				},
+			},
+			wantKubeReserved: map[string]string{
+				"cpu":               "60m",
+				"ephemeral-storage": "1Gi",
+				"memory":            "512Mi",
+				"pid":               "1000",
+			},
+			wantSystemReserved: map[string]string{
+				"cpu":    "400m",
+				"memory": "819Mi",
+			},
+			wantEvictionHard: map[string]string{
+				"memory.available":  "5%",
+				"nodefs.available":  "10%",
+				"nodefs.inodesFree": "5%",
+				"pid.available":     "10%",
+			},
+		},
+		{
+			name: "formulas",
+			reserved: &api.ReservedResources{
+				KubeReserved: map[string]string{
+					"memory": "255Mi + 11Mi * maxPods",
+					"pid":    "1000 + 10 * maxPods",
+				},
+				SystemReserved: map[string]string{
+					"cpu":    "min(5%, 250m)",
+					"memory": "tiered(10%:8Gi, 2%)",
+				},
+			},
+			wantKubeReserved: map[string]string{
+				"cpu":               "90m",
+				"ephemeral-storage": "1Gi",
+				"memory":            "1465Mi",
+				"pid":               "2100",
+			},
+			wantSystemReserved: map[string]string{
+				"cpu":    "250m",
+				"memory": "1310Mi",
+			},
+			wantEvictionHard: map[string]string{
+				"memory.available":  "100Mi",
+				"nodefs.available":  "10%",
+				"nodefs.inodesFree": "5%",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ksc := defaultKubeletSubConfig()
+			g.Expect(ksc.withReservedResources(tc.reserved, capacity)).To(Succeed())
+			g.Expect(ksc.KubeReserved).To(Equal(tc.wantKubeReserved))
+			g.Expect(ksc.SystemReserved).To(Equal(tc.wantSystemReserved))
+			g.Expect(ksc.EvictionHard).To(Equal(tc.wantEvictionHard))
+		})
+	}
+}
+
+func TestWithReservedResourcesInvalid(t *testing.T) {
+	g := NewWithT(t)
+	ksc := defaultKubeletSubConfig()
+	err := ksc.withReservedResources(&api.ReservedResources{
+		KubeReserved: map[string]string{"ephemeral-storage": "10%"},
+	}, nodeCapacity{})
+	g.Expect(err).To(MatchError(`kubeReserved: invalid value "10%" for ephemeral-storage: percentages are only supported for cpu and memory`))
+
+	err = ksc.withReservedResources(&api.ReservedResources{
+		SystemReserved: map[string]string{"memory": "1Gi - 2Gi"},
+	}, nodeCapacity{})
+	g.Expect(err).To(MatchError(`systemReserved: formula "1Gi - 2Gi" for memory reserves a negative amount`))
+}
+
+func TestValidateReservedResources(t *testing.T) {
+	g := NewWithT(t)
+	errs := ValidateReservedResources(&api.ReservedResources{
+		Profile: "large",
+		KubeReserved: map[string]string{
+			"gpu":    "1",
+			"memory": "150%",
+			"pid":    "1000 + pods",
+		},
+		SystemReserved: map[string]string{
+			"cpu": "lots",
+		},
+		EvictionHard: map[string]string{
+			"memory.free":      "100Mi",
+			"nodefs.available": "5%",
+		},
+	}, "spec.hybrid.reservedResources")
+
+	var fields, messages []string
+	for _, err := range errs {
+		fields = append(fields, validation.Field(err))
+		messages = append(messages, err.Error())
+	}
+	g.Expect(fields).To(Equal([]string{
+		"spec.hybrid.reservedResources.profile",
+		"spec.hybrid.reservedResources.kubeReserved",
+		"spec.hybrid.reservedResources.kubeReserved",
+		"spec.hybrid.reservedResources.kubeReserved",
+		"spec.hybrid.reservedResources.systemReserved",
+		"spec.hybrid.reservedResources.evictionHard",
+	}))
+	g.Expect(messages).To(Equal([]string{
+		`unknown reserved resources profile "large". Must be one of: [eks-default hybrid-default minimal]`,
+		`unsupported resource "gpu". Must be one of: [cpu, memory, ephemeral-storage, pid]`,
+		`invalid value "150%" for memory: percentage must be a number between 0 and 100`,
+		`invalid formula "1000 + pods" for pid: at position 8: unknown name "pods"`,
+		`invalid value "lots" for cpu: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+		`unsupported eviction signal "memory.free". Must be one of: [memory.available, nodefs.available, nodefs.inodesFree, imagefs.available, imagefs.inodesFree, containerfs.available, containerfs.inodesFree, pid.available]`,
+	}))
+}
+
+func TestGetHybridMemoryToReserve(t *testing.T) {
+	g := NewWithT(t)
+	const gib = 1024 * 1024 * 1024
+	g.Expect(getHybridMemoryToReserve(gib / 2)).To(Equal("255Mi"))
+	g.Expect(getHybridMemoryToReserve(4 * gib)).To(Equal("1Gi"))
+	g.Expect(getHybridMemoryToReserve(16 * gib)).To(Equal("3Gi"))
+	g.Expect(getHybridMemoryToReserve(256 * gib)).To(Equal("12Gi"))
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/aws/eks-hybrid/internal/render"
 )
 
 func (enp *ec2NodeProvider) ConfigureAws(ctx context.Context) error {
@@ -17,6 +19,16 @@ func (enp *ec2NodeProvider) ConfigureAws(ctx context.Context) error {
 	return nil
 }
 
+// LoadAws loads the AWS config. On EC2 this doesn't modify the host, so it's the same as ConfigureAws.
+func (enp *ec2NodeProvider) LoadAws(ctx context.Context) error {
+	return enp.ConfigureAws(ctx)
+}
+
+// RenderAWSConfig returns no files, since ConfigureAws doesn't write any on EC2.
+func (enp *ec2NodeProvider) RenderAWSConfig() ([]render.File, error) {
+	return nil, nil
+}
+
 func (enp *ec2NodeProvider) GetConfig() *aws.Config {
 	return enp.awsConfig
 }
@@ -1,31 +1,37 @@
 package ec2
 
 import (
-	"fmt"
+	"errors"
 
 	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/containerd"
+	"github.com/aws/eks-hybrid/internal/validation"
 )
 
 func (enp *ec2NodeProvider) withEc2NodeValidators() {
 	enp.validator = func(cfg *api.NodeConfig) error {
+		var errs []error
 		if cfg.Spec.Cluster.Name == "" {
-			return fmt.Errorf("Name is missing in cluster configuration")
+			errs = append(errs, validation.NewFieldError("spec.cluster.name", "Name is missing in cluster configuration"))
 		}
 		if cfg.Spec.Cluster.APIServerEndpoint == "" {
-			return fmt.Errorf("Apiserver endpoint is missing in cluster configuration")
+			errs = append(errs, validation.NewFieldError("spec.cluster.apiServerEndpoint", "Apiserver endpoint is missing in cluster configuration"))
 		}
 		if cfg.Spec.Cluster.CertificateAuthority == nil {
-			return fmt.Errorf("Certificate authority is missing in cluster configuration")
+			errs = append(errs, validation.NewFieldError("spec.cluster.certificateAuthority", "Certificate authority is missing in cluster configuration"))
 		}
 		if cfg.Spec.Cluster.CIDR == "" {
-			return fmt.Errorf("CIDR is missing in cluster configuration")
+			errs = append(errs, validation.NewFieldError("spec.cluster.cidr", "CIDR is missing in cluster configuration"))
 		}
 		if cfg.IsOutpostNode() {
 			if cfg.Spec.Cluster.ID == "" {
-				return fmt.Errorf("CIDR is missing in cluster configuration")
+				errs = append(errs, validation.NewFieldError("spec.cluster.id", "CIDR is missing in cluster configuration"))
 			}
 		}
-		return nil
+		errs = append(errs, containerd.ValidateRegistries(cfg.Spec.Containerd.Registries, "spec.containerd.registries")...)
+		errs = append(errs, containerd.ValidateUserConfig(cfg.Spec.Containerd.Config, "spec.containerd.config")...)
+		errs = append(errs, containerd.ValidateRuntimes(cfg.Spec.Containerd.Runtimes, "spec.containerd.runtimes")...)
+		return errors.Join(errs...)
 	}
 }
 
@@ -5,6 +5,7 @@ import "github.com/aws/eks-hybrid/internal/system"
 func (hnp *HybridNodeProvider) GetAspects() []system.SystemAspect {
 	return []system.SystemAspect{
 		system.NewSysctlAspect(hnp.nodeConfig),
+		system.NewHugepagesAspect(hnp.nodeConfig, hnp.logger),
 		system.NewSwapAspect(hnp.nodeConfig, hnp.logger),
 		system.NewPortsAspect(hnp.nodeConfig, hnp.logger),
 	}
@@ -12,9 +12,11 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/creds"
 	"github.com/aws/eks-hybrid/internal/daemon"
 	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
 	"github.com/aws/eks-hybrid/internal/kubelet"
+	"github.com/aws/eks-hybrid/internal/render"
 	"github.com/aws/eks-hybrid/internal/ssm"
 	"github.com/aws/eks-hybrid/internal/util/file"
 )
@@ -58,6 +60,30 @@ func (hnp *HybridNodeProvider) ConfigureAws(ctx context.Context) error {
 	return nil
 }
 
+// LoadAws loads the AWS config the same way the kubelet does, from the credentials
+// already present in the host.
+func (hnp *HybridNodeProvider) LoadAws(ctx context.Context) error {
+	awsConfig, err := creds.ReadConfigAsKubelet(ctx, hnp.nodeConfig)
+	if err != nil {
+		return fmt.Errorf("loading aws config: %w", err)
+	}
+	hnp.awsConfig = &awsConfig
+	return nil
+}
+
+// RenderAWSConfig returns the files ConfigureAws would write without writing them.
+// SSM writes its configuration when registering the node, so nothing is rendered for it.
+func (hnp *HybridNodeProvider) RenderAWSConfig() ([]render.File, error) {
+	if !hnp.nodeConfig.IsIAMRolesAnywhere() {
+		return nil, nil
+	}
+	configurator := RolesAnywhereAWSConfigurator{
+		Manager: hnp.daemonManager,
+		Logger:  hnp.logger,
+	}
+	return configurator.Render(hnp.nodeConfig)
+}
+
 func (hnp *HybridNodeProvider) GetConfig() *aws.Config {
 	return hnp.awsConfig
 }
@@ -88,17 +114,7 @@ type RolesAnywhereAWSConfigurator struct {
 }
 
 func (c RolesAnywhereAWSConfigurator) Configure(ctx context.Context, nodeConfig *api.NodeConfig) error {
-	if err := iamrolesanywhere.WriteAWSConfig(iamrolesanywhere.AWSConfig{
-		TrustAnchorARN:       nodeConfig.Spec.Hybrid.IAMRolesAnywhere.TrustAnchorARN,
-		ProfileARN:           nodeConfig.Spec.Hybrid.IAMRolesAnywhere.ProfileARN,
-		RoleARN:              nodeConfig.Spec.Hybrid.IAMRolesAnywhere.RoleARN,
-		Region:               nodeConfig.Spec.Cluster.Region,
-		NodeName:             nodeConfig.Status.Hybrid.NodeName,
-		ConfigPath:           nodeConfig.Spec.Hybrid.IAMRolesAnywhere.AwsConfigPath,
-		SigningHelperBinPath: iamrolesanywhere.SigningHelperBinPath,
-		CertificatePath:      nodeConfig.Spec.Hybrid.IAMRolesAnywhere.CertificatePath,
-		PrivateKeyPath:       nodeConfig.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath,
-	}); err != nil {
+	if err := iamrolesanywhere.WriteAWSConfig(rolesAnywhereAWSConfig(nodeConfig)); err != nil {
 		return err
 	}
 
@@ -121,6 +137,43 @@ func (c RolesAnywhereAWSConfigurator) Configure(ctx context.Context, nodeConfig
 	return nil
 }
 
+// Render returns the files Configure would write without writing them.
+func (c RolesAnywhereAWSConfigurator) Render(nodeConfig *api.NodeConfig) ([]render.File, error) {
+	awsConfig := rolesAnywhereAWSConfig(nodeConfig)
+	if awsConfig.ConfigPath == "" {
+		awsConfig.ConfigPath = iamrolesanywhere.DefaultAWSConfigPath
+	}
+	data, err := iamrolesanywhere.GenerateAWSConfig(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	files := []render.File{{Path: awsConfig.ConfigPath, Content: data, Perm: 0o644}}
+
+	if !nodeConfig.Spec.Hybrid.EnableCredentialsFile {
+		return files, nil
+	}
+
+	service, err := iamrolesanywhere.GenerateUpdateSystemdService(nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+	return append(files, render.File{Path: iamrolesanywhere.SigningHelperServiceFilePath, Content: service, Perm: 0o644}), nil
+}
+
+func rolesAnywhereAWSConfig(nodeConfig *api.NodeConfig) iamrolesanywhere.AWSConfig {
+	return iamrolesanywhere.AWSConfig{
+		TrustAnchorARN:       nodeConfig.Spec.Hybrid.IAMRolesAnywhere.TrustAnchorARN,
+		ProfileARN:           nodeConfig.Spec.Hybrid.IAMRolesAnywhere.ProfileARN,
+		RoleARN:              nodeConfig.Spec.Hybrid.IAMRolesAnywhere.RoleARN,
+		Region:               nodeConfig.Spec.Cluster.Region,
+		NodeName:             nodeConfig.Status.Hybrid.NodeName,
+		ConfigPath:           nodeConfig.Spec.Hybrid.IAMRolesAnywhere.AwsConfigPath,
+		SigningHelperBinPath: iamrolesanywhere.SigningHelperBinPath,
+		CertificatePath:      nodeConfig.Spec.Hybrid.IAMRolesAnywhere.CertificatePath,
+		PrivateKeyPath:       nodeConfig.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath,
+	}
+}
+
 func LoadAWSConfigForRolesAnywhere(ctx context.Context, nodeConfig *api.NodeConfig) (aws.Config, error) {
 	return config.LoadDefaultConfig(ctx,
 		config.WithRegion(nodeConfig.Spec.Cluster.Region),
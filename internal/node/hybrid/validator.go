@@ -1,12 +1,20 @@
 package hybrid
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+
 	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/aws"
 	"github.com/aws/eks-hybrid/internal/certificate"
+	"github.com/aws/eks-hybrid/internal/containerd"
+	"github.com/aws/eks-hybrid/internal/kubelet"
 	"github.com/aws/eks-hybrid/internal/util/file"
 	"github.com/aws/eks-hybrid/internal/validation"
 )
@@ -15,8 +23,16 @@ const (
 	// https://docs.aws.amazon.com/systems-manager/latest/APIReference/API_CreateActivation.html#systemsmanager-CreateActivation-response-ActivationId
 	ssmActivationIDPattern   = `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`
 	ssmActivationCodePattern = `^.{20,250}$`
-	iamRolesCertGuideURL     = "To generate a new IAM Roles Anywhere (IAM-RA) certificate, see the steps in the documentation: https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-creds.html#hybrid-nodes-role"
-	hostnameOverrideFlag     = "hostname-override"
+	// same pattern as the ARN fields of the NodeConfig CRD
+	arnPattern           = `^arn:aws[a-z-]*:[a-z0-9-]+:[a-z0-9-]*:[0-9]{12}:.+$`
+	iamRolesCertGuideURL = "To generate a new IAM Roles Anywhere (IAM-RA) certificate, see the steps in the documentation: https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-creds.html#hybrid-nodes-role"
+	hostnameOverrideFlag = "hostname-override"
+)
+
+var (
+	ssmActivationIDRegex   = regexp.MustCompile(ssmActivationIDPattern)
+	ssmActivationCodeRegex = regexp.MustCompile(ssmActivationCodePattern)
+	arnRegex               = regexp.MustCompile(arnPattern)
 )
 
 func extractFlagValue(args []string, flag string) string {
@@ -33,57 +49,53 @@ func extractFlagValue(args []string, flag string) string {
 	return flagValue
 }
 
+// withHybridValidators sets a validator that checks every field of the node config
+// and returns all the problems found, each as a [validation.FieldError].
 func (hnp *HybridNodeProvider) withHybridValidators() {
 	hnp.validator = func(cfg *api.NodeConfig) error {
+		var errs []error
 		if cfg.Spec.Cluster.Name == "" {
-			return fmt.Errorf("Name is missing in cluster configuration")
+			errs = append(errs, validation.NewFieldError("spec.cluster.name", "Name is missing in cluster configuration"))
 		}
 		if cfg.Spec.Cluster.Region == "" {
-			return fmt.Errorf("Region is missing in cluster configuration")
+			errs = append(errs, validation.NewFieldError("spec.cluster.region", "Region is missing in cluster configuration"))
 		}
 		if hostnameOverride := extractFlagValue(cfg.Spec.Kubelet.Flags, hostnameOverrideFlag); hostnameOverride != "" {
-			return fmt.Errorf("hostname-override kubelet flag is not supported for hybrid nodes but found override: %s", hostnameOverride)
-		}
-		if !cfg.IsIAMRolesAnywhere() && !cfg.IsSSM() {
-			return fmt.Errorf("Either IAMRolesAnywhere or SSM must be provided for hybrid node configuration")
-		}
-		if cfg.IsIAMRolesAnywhere() && cfg.IsSSM() {
-			return fmt.Errorf("Only one of IAMRolesAnywhere or SSM must be provided for hybrid node configuration")
+			errs = append(errs, validation.NewFieldError("spec.kubelet.flags", fmt.Sprintf("hostname-override kubelet flag is not supported for hybrid nodes but found override: %s", hostnameOverride)))
 		}
-		if cfg.IsIAMRolesAnywhere() {
-			if err := validateRolesAnywhereNode(cfg); err != nil {
-				return err
-			}
+		switch {
+		case !cfg.IsIAMRolesAnywhere() && !cfg.IsSSM():
+			errs = append(errs, validation.NewFieldError("spec.hybrid", "Either IAMRolesAnywhere or SSM must be provided for hybrid node configuration"))
+		case cfg.IsIAMRolesAnywhere() && cfg.IsSSM():
+			errs = append(errs, validation.NewFieldError("spec.hybrid", "Only one of IAMRolesAnywhere or SSM must be provided for hybrid node configuration"))
+		case cfg.IsIAMRolesAnywhere():
+			errs = append(errs, validateRolesAnywhereNode(cfg)...)
+		case cfg.IsSSM():
+			errs = append(errs, validateSSMNode(cfg)...)
 		}
-		if cfg.IsSSM() {
-			if cfg.Spec.Hybrid.SSM.ActivationCode == "" {
-				return fmt.Errorf("ActivationCode is missing in hybrid ssm configuration")
-			}
-			if cfg.Spec.Hybrid.SSM.ActivationID == "" {
-				return fmt.Errorf("ActivationID is missing in hybrid ssm configuration")
-			}
-
-			// Compile the activation code pattern
-			reCode, err := regexp.Compile(ssmActivationCodePattern)
-			if err != nil {
-				return fmt.Errorf("internal error: invalid ActivationCode pattern: %v", err)
-			}
-			// Check if ActivationCode matches the pattern
-			if !reCode.MatchString(cfg.Spec.Hybrid.SSM.ActivationCode) {
-				return fmt.Errorf("invalid ActivationCode format: %s. Must be 20-250 characters", cfg.Spec.Hybrid.SSM.ActivationCode)
+		errs = append(errs, containerd.ValidateRegistries(cfg.Spec.Containerd.Registries, "spec.containerd.registries")...)
+		errs = append(errs, containerd.ValidateUserConfig(cfg.Spec.Containerd.Config, "spec.containerd.config")...)
+		errs = append(errs, containerd.ValidateRuntimes(cfg.Spec.Containerd.Runtimes, "spec.containerd.runtimes")...)
+		if cfg.Spec.Hybrid != nil {
+			errs = append(errs, validateNodeLabels(cfg.Spec.Hybrid.Labels)...)
+			errs = append(errs, validateNodeTaints(cfg.Spec.Hybrid.Taints)...)
+			if cfg.Spec.Hybrid.ReservedResources != nil {
+				errs = append(errs, kubelet.ValidateReservedResources(cfg.Spec.Hybrid.ReservedResources, "spec.hybrid.reservedResources")...)
 			}
-
-			// Compile the regex patterns
-			reID, err := regexp.Compile(ssmActivationIDPattern)
-			if err != nil {
-				return fmt.Errorf("internal error: invalid ActivationID pattern: %v", err)
+			errs = append(errs, validateHugepages(cfg.Spec.Hybrid.Hugepages)...)
+			errs = append(errs, aws.ValidateArtifactsOptions(cfg.Spec.Hybrid.Artifacts, "spec.hybrid.artifacts")...)
+			switch cfg.Spec.Hybrid.CPUManager {
+			case "", api.CPUManagerPolicyNone, api.CPUManagerPolicyStatic:
+			default:
+				errs = append(errs, validation.NewFieldError("spec.hybrid.cpuManager", fmt.Sprintf("invalid CPU manager policy %q. Must be one of: [%s, %s]", cfg.Spec.Hybrid.CPUManager, api.CPUManagerPolicyNone, api.CPUManagerPolicyStatic)))
 			}
-			// Check if ActivationID matches the pattern
-			if !reID.MatchString(cfg.Spec.Hybrid.SSM.ActivationID) {
-				return fmt.Errorf("invalid ActivationID format: %s. Must be in format: ^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$", cfg.Spec.Hybrid.SSM.ActivationID)
+			switch cfg.Spec.Hybrid.Swap {
+			case "", api.SwapModeDisable, api.SwapModeLimited:
+			default:
+				errs = append(errs, validation.NewFieldError("spec.hybrid.swap", fmt.Sprintf("invalid swap mode %q. Must be one of: [%s, %s]", cfg.Spec.Hybrid.Swap, api.SwapModeDisable, api.SwapModeLimited)))
 			}
 		}
-		return nil
+		return errors.Join(errs...)
 	}
 }
 
@@ -95,43 +107,157 @@ func (hnp *HybridNodeProvider) ValidateConfig() error {
 	return nil
 }
 
-func validateRolesAnywhereNode(node *api.NodeConfig) error {
-	if node.Spec.Hybrid.IAMRolesAnywhere.RoleARN == "" {
-		return fmt.Errorf("RoleARN is missing in hybrid iam roles anywhere configuration")
+func validateSSMNode(cfg *api.NodeConfig) []error {
+	var errs []error
+	ssm := cfg.Spec.Hybrid.SSM
+	if ssm.ActivationCode == "" {
+		errs = append(errs, validation.NewFieldError("spec.hybrid.ssm.activationCode", "ActivationCode is missing in hybrid ssm configuration"))
+	} else if !ssmActivationCodeRegex.MatchString(ssm.ActivationCode) {
+		errs = append(errs, validation.NewFieldError("spec.hybrid.ssm.activationCode", "invalid ActivationCode format. Must be 20-250 characters"))
 	}
-	if node.Spec.Hybrid.IAMRolesAnywhere.ProfileARN == "" {
-		return fmt.Errorf("ProfileARN is missing in hybrid iam roles anywhere configuration")
+	if ssm.ActivationID == "" {
+		errs = append(errs, validation.NewFieldError("spec.hybrid.ssm.activationId", "ActivationID is missing in hybrid ssm configuration"))
+	} else if !ssmActivationIDRegex.MatchString(ssm.ActivationID) {
+		errs = append(errs, validation.NewFieldError("spec.hybrid.ssm.activationId", fmt.Sprintf("invalid ActivationID format: %s. Must be in format: %s", ssm.ActivationID, ssmActivationIDPattern)))
 	}
-	if node.Spec.Hybrid.IAMRolesAnywhere.TrustAnchorARN == "" {
-		return fmt.Errorf("TrustAnchorARN is missing in hybrid iam roles anywhere configuration")
+	return errs
+}
+
+func validateRolesAnywhereNode(node *api.NodeConfig) []error {
+	var errs []error
+	iamRA := node.Spec.Hybrid.IAMRolesAnywhere
+	if iamRA.RoleARN == "" {
+		errs = append(errs, validation.NewFieldError("spec.hybrid.iamRolesAnywhere.roleArn", "RoleARN is missing in hybrid iam roles anywhere configuration"))
+	} else if !arnRegex.MatchString(iamRA.RoleARN) {
+		errs = append(errs, validation.NewFieldError("spec.hybrid.iamRolesAnywhere.roleArn", fmt.Sprintf("invalid RoleARN format %s. Must be an ARN like arn:aws:iam::123456789012:role/name", iamRA.RoleARN)))
 	}
-	if node.Spec.Hybrid.IAMRolesAnywhere.NodeName == "" {
-		return fmt.Errorf("NodeName can't be empty in hybrid iam roles anywhere configuration")
+	if iamRA.ProfileARN == "" {
+		errs = append(errs, validation.NewFieldError("spec.hybrid.iamRolesAnywhere.profileArn", "ProfileARN is missing in hybrid iam roles anywhere configuration"))
+	} else if !arnRegex.MatchString(iamRA.ProfileARN) {
+		errs = append(errs, validation.NewFieldError("spec.hybrid.iamRolesAnywhere.profileArn", fmt.Sprintf("invalid ProfileARN format %s. Must be an ARN like arn:aws:rolesanywhere:us-west-2:123456789012:profile/id", iamRA.ProfileARN)))
 	}
-	if len(node.Spec.Hybrid.IAMRolesAnywhere.NodeName) > 64 {
-		return fmt.Errorf("NodeName can't be longer than 64 characters in hybrid iam roles anywhere configuration")
+	if iamRA.TrustAnchorARN == "" {
+		errs = append(errs, validation.NewFieldError("spec.hybrid.iamRolesAnywhere.trustAnchorArn", "TrustAnchorARN is missing in hybrid iam roles anywhere configuration"))
+	} else if !arnRegex.MatchString(iamRA.TrustAnchorARN) {
+		errs = append(errs, validation.NewFieldError("spec.hybrid.iamRolesAnywhere.trustAnchorArn", fmt.Sprintf("invalid TrustAnchorARN format %s. Must be an ARN like arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/id", iamRA.TrustAnchorARN)))
+	}
+	if iamRA.NodeName == "" {
+		errs = append(errs, validation.NewFieldError("spec.hybrid.iamRolesAnywhere.nodeName", "NodeName can't be empty in hybrid iam roles anywhere configuration"))
+	} else if len(iamRA.NodeName) > 64 {
+		errs = append(errs, validation.NewFieldError("spec.hybrid.iamRolesAnywhere.nodeName", "NodeName can't be longer than 64 characters in hybrid iam roles anywhere configuration"))
 	}
 
 	// IAM roles anywhere certificate validation
-	if node.Spec.Hybrid.IAMRolesAnywhere.CertificatePath == "" {
-		return fmt.Errorf("CertificatePath is missing in hybrid iam roles anywhere configuration")
+	const certificatePathField = "spec.hybrid.iamRolesAnywhere.certificatePath"
+	if iamRA.CertificatePath == "" {
+		errs = append(errs, validation.NewFieldError(certificatePathField, "CertificatePath is missing in hybrid iam roles anywhere configuration"))
+	} else if !file.Exists(iamRA.CertificatePath) {
+		errs = append(errs, validation.NewFieldError(certificatePathField, fmt.Sprintf("IAM Roles Anywhere certificate %s not found", iamRA.CertificatePath)))
+	} else if err := certificate.Validate(iamRA.CertificatePath, nil); err != nil {
+		errs = append(errs, validation.WithField(certificatePathField, addIAMRARemediation(iamRA.CertificatePath, err)))
 	}
-	if !file.Exists(node.Spec.Hybrid.IAMRolesAnywhere.CertificatePath) {
-		return fmt.Errorf("IAM Roles Anywhere certificate %s not found", node.Spec.Hybrid.IAMRolesAnywhere.CertificatePath)
+
+	// IAM roles anywhere key validation
+	const privateKeyPathField = "spec.hybrid.iamRolesAnywhere.privateKeyPath"
+	if iamRA.PrivateKeyPath == "" {
+		errs = append(errs, validation.NewFieldError(privateKeyPathField, "PrivateKeyPath is missing in hybrid iam roles anywhere configuration"))
+	} else if !file.Exists(iamRA.PrivateKeyPath) {
+		errs = append(errs, validation.NewFieldError(privateKeyPathField, fmt.Sprintf("IAM Roles Anywhere private key %s not found", iamRA.PrivateKeyPath)))
 	}
-	if err := certificate.Validate(node.Spec.Hybrid.IAMRolesAnywhere.CertificatePath, nil); err != nil {
-		return addIAMRARemediation(node.Spec.Hybrid.IAMRolesAnywhere.CertificatePath, err)
+
+	return errs
+}
+
+// validateNodeLabels checks the labels follow the Kubernetes label syntax and
+// don't use a namespace kubelet refuses to register or a key nodeadm sets itself.
+func validateNodeLabels(labels map[string]string) []error {
+	const labelsField = "spec.hybrid.labels"
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		if msgs := k8svalidation.IsQualifiedName(key); len(msgs) > 0 {
+			errs = append(errs, validation.NewFieldError(labelsField, fmt.Sprintf("invalid label key %q: %s", key, strings.Join(msgs, "; "))))
+			continue
+		}
+		if msgs := k8svalidation.IsValidLabelValue(labels[key]); len(msgs) > 0 {
+			errs = append(errs, validation.NewFieldError(labelsField, fmt.Sprintf("invalid value for label %q: %s", key, strings.Join(msgs, "; "))))
+		}
+		if kubelet.IsManagedNodeLabel(key) {
+			errs = append(errs, validation.NewFieldError(labelsField, fmt.Sprintf("label %q is set by nodeadm and can't be overridden", key)))
+		} else if isReservedLabelKey(key) {
+			errs = append(errs, validation.NewFieldError(labelsField, fmt.Sprintf("label %q uses a reserved namespace. Only the kubelet.kubernetes.io and node.kubernetes.io namespaces are allowed under kubernetes.io and k8s.io", key)))
+		}
 	}
+	return errs
+}
 
-	// IAM roles anywhere key validation
-	if node.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath == "" {
-		return fmt.Errorf("PrivateKeyPath is missing in hybrid iam roles anywhere configuration")
+// isReservedLabelKey returns true for keys in the kubernetes.io and k8s.io namespaces,
+// which kubelet only allows to self-assign under kubelet.kubernetes.io and node.kubernetes.io.
+func isReservedLabelKey(key string) bool {
+	namespace, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	for _, allowed := range []string{"kubelet.kubernetes.io", "node.kubernetes.io"} {
+		if namespace == allowed || strings.HasSuffix(namespace, "."+allowed) {
+			return false
+		}
 	}
-	if !file.Exists(node.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath) {
-		return fmt.Errorf("IAM Roles Anywhere private key %s not found", node.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath)
+	for _, reserved := range []string{"kubernetes.io", "k8s.io"} {
+		if namespace == reserved || strings.HasSuffix(namespace, "."+reserved) {
+			return true
+		}
 	}
+	return false
+}
 
-	return nil
+// validateNodeTaints checks the taints follow the Kubernetes taint syntax and
+// that no two taints share the same key and effect.
+func validateNodeTaints(taints []api.Taint) []error {
+	const taintsField = "spec.hybrid.taints"
+	var errs []error
+	seen := map[api.Taint]bool{}
+	for i, taint := range taints {
+		if msgs := k8svalidation.IsQualifiedName(taint.Key); len(msgs) > 0 {
+			errs = append(errs, validation.NewFieldError(taintsField, fmt.Sprintf("invalid key %q for taint %d: %s", taint.Key, i, strings.Join(msgs, "; "))))
+		}
+		if msgs := k8svalidation.IsValidLabelValue(taint.Value); len(msgs) > 0 {
+			errs = append(errs, validation.NewFieldError(taintsField, fmt.Sprintf("invalid value %q for taint %d: %s", taint.Value, i, strings.Join(msgs, "; "))))
+		}
+		switch taint.Effect {
+		case api.TaintEffectNoSchedule, api.TaintEffectPreferNoSchedule, api.TaintEffectNoExecute:
+		default:
+			errs = append(errs, validation.NewFieldError(taintsField, fmt.Sprintf("invalid effect %q for taint %d. Must be one of: [%s, %s, %s]", taint.Effect, i,
+				api.TaintEffectNoSchedule, api.TaintEffectPreferNoSchedule, api.TaintEffectNoExecute)))
+		}
+		id := api.Taint{Key: taint.Key, Effect: taint.Effect}
+		if seen[id] {
+			errs = append(errs, validation.NewFieldError(taintsField, fmt.Sprintf("duplicate taint %d with key %q and effect %q", i, taint.Key, taint.Effect)))
+		}
+		seen[id] = true
+	}
+	return errs
+}
+
+// validateHugepages checks the hugepage sizes are supported and set only once.
+func validateHugepages(hugepages []api.Hugepages) []error {
+	const hugepagesField = "spec.hybrid.hugepages"
+	var errs []error
+	seen := map[api.HugepageSize]bool{}
+	for i, hp := range hugepages {
+		switch hp.Size {
+		case api.HugepageSize2Mi, api.HugepageSize1Gi:
+		default:
+			errs = append(errs, validation.NewFieldError(hugepagesField, fmt.Sprintf("invalid size %q for hugepages %d. Must be one of: [%s, %s]", hp.Size, i, api.HugepageSize2Mi, api.HugepageSize1Gi)))
+		}
+		if hp.Count < 0 {
+			errs = append(errs, validation.NewFieldError(hugepagesField, fmt.Sprintf("invalid count %d for hugepages %d. Must be 0 or more", hp.Count, i)))
+		}
+		if seen[hp.Size] {
+			errs = append(errs, validation.NewFieldError(hugepagesField, fmt.Sprintf("duplicate hugepages %d with size %q", i, hp.Size)))
+		}
+		seen[hp.Size] = true
+	}
+	return errs
 }
 
 // addIAMRARemediation adds IAM Role Anywhere specific remediation messages based on error type
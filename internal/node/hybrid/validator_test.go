@@ -11,7 +11,9 @@ import (
 
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/node/hybrid"
+	"github.com/aws/eks-hybrid/internal/signature"
 	"github.com/aws/eks-hybrid/internal/test"
+	"github.com/aws/eks-hybrid/internal/validation"
 )
 
 func Test_HybridNodeProviderValidateConfig(t *testing.T) {
@@ -49,6 +51,27 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 		// IAM Roles Anywhere NodeConfig spec validation
 		{
 			name: "happy path",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						IAMRolesAnywhere: &api.IAMRolesAnywhere{
+							NodeName:        "my-node",
+							TrustAnchorARN:  "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/ta",
+							ProfileARN:      "arn:aws:rolesanywhere:us-west-2:123456789012:profile/p",
+							RoleARN:         "arn:aws:iam::123456789012:role/my-role",
+							CertificatePath: certPath,
+							PrivateKeyPath:  keyPath,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid arns",
 			node: &api.NodeConfig{
 				Spec: api.NodeConfigSpec{
 					Cluster: api.ClusterDetails{
@@ -59,14 +82,17 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 						IAMRolesAnywhere: &api.IAMRolesAnywhere{
 							NodeName:        "my-node",
 							TrustAnchorARN:  "trust-anchor-arn",
-							ProfileARN:      "profile-arn",
-							RoleARN:         "role-arn",
+							ProfileARN:      "arn:aws:rolesanywhere:us-west-2:1234:profile/p",
+							RoleARN:         "my-role",
 							CertificatePath: certPath,
 							PrivateKeyPath:  keyPath,
 						},
 					},
 				},
 			},
+			wantError: "invalid RoleARN format my-role. Must be an ARN like arn:aws:iam::123456789012:role/name\n" +
+				"invalid ProfileARN format arn:aws:rolesanywhere:us-west-2:1234:profile/p. Must be an ARN like arn:aws:rolesanywhere:us-west-2:123456789012:profile/id\n" +
+				"invalid TrustAnchorARN format trust-anchor-arn. Must be an ARN like arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/id",
 		},
 		{
 			name: "no node name",
@@ -78,14 +104,16 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					},
 					Hybrid: &api.HybridOptions{
 						IAMRolesAnywhere: &api.IAMRolesAnywhere{
-							TrustAnchorARN: "trust-anchor-arn",
-							ProfileARN:     "profile-arn",
-							RoleARN:        "role-arn",
+							TrustAnchorARN: "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/ta",
+							ProfileARN:     "arn:aws:rolesanywhere:us-west-2:123456789012:profile/p",
+							RoleARN:        "arn:aws:iam::123456789012:role/my-role",
 						},
 					},
 				},
 			},
-			wantError: "NodeName can't be empty in hybrid iam roles anywhere configuration",
+			wantError: "NodeName can't be empty in hybrid iam roles anywhere configuration\n" +
+				"CertificatePath is missing in hybrid iam roles anywhere configuration\n" +
+				"PrivateKeyPath is missing in hybrid iam roles anywhere configuration",
 		},
 		{
 			name: "node name too long",
@@ -98,14 +126,16 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					Hybrid: &api.HybridOptions{
 						IAMRolesAnywhere: &api.IAMRolesAnywhere{
 							NodeName:       "my-node-too-long-1111111111111111111111111111111111111111111111111111",
-							TrustAnchorARN: "trust-anchor-arn",
-							ProfileARN:     "profile-arn",
-							RoleARN:        "role-arn",
+							TrustAnchorARN: "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/ta",
+							ProfileARN:     "arn:aws:rolesanywhere:us-west-2:123456789012:profile/p",
+							RoleARN:        "arn:aws:iam::123456789012:role/my-role",
 						},
 					},
 				},
 			},
-			wantError: "NodeName can't be longer than 64 characters in hybrid iam roles anywhere configuration",
+			wantError: "NodeName can't be longer than 64 characters in hybrid iam roles anywhere configuration\n" +
+				"CertificatePath is missing in hybrid iam roles anywhere configuration\n" +
+				"PrivateKeyPath is missing in hybrid iam roles anywhere configuration",
 		},
 		{
 			name: "no certificate path",
@@ -118,15 +148,16 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					Hybrid: &api.HybridOptions{
 						IAMRolesAnywhere: &api.IAMRolesAnywhere{
 							NodeName:       "my-node",
-							TrustAnchorARN: "trust-anchor-arn",
-							ProfileARN:     "profile-arn",
-							RoleARN:        "role-arn",
+							TrustAnchorARN: "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/ta",
+							ProfileARN:     "arn:aws:rolesanywhere:us-west-2:123456789012:profile/p",
+							RoleARN:        "arn:aws:iam::123456789012:role/my-role",
 							PrivateKeyPath: "/etc/certificates/iam/pki/my-server.key",
 						},
 					},
 				},
 			},
-			wantError: "CertificatePath is missing in hybrid iam roles anywhere configuration",
+			wantError: "CertificatePath is missing in hybrid iam roles anywhere configuration\n" +
+				"IAM Roles Anywhere private key /etc/certificates/iam/pki/my-server.key not found",
 		},
 		{
 			name: "no private key path",
@@ -139,9 +170,9 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					Hybrid: &api.HybridOptions{
 						IAMRolesAnywhere: &api.IAMRolesAnywhere{
 							NodeName:        "my-node",
-							TrustAnchorARN:  "trust-anchor-arn",
-							ProfileARN:      "profile-arn",
-							RoleARN:         "role-arn",
+							TrustAnchorARN:  "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/ta",
+							ProfileARN:      "arn:aws:rolesanywhere:us-west-2:123456789012:profile/p",
+							RoleARN:         "arn:aws:iam::123456789012:role/my-role",
 							CertificatePath: certPath,
 						},
 					},
@@ -160,9 +191,9 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					Hybrid: &api.HybridOptions{
 						IAMRolesAnywhere: &api.IAMRolesAnywhere{
 							NodeName:        "my-node",
-							TrustAnchorARN:  "trust-anchor-arn",
-							ProfileARN:      "profile-arn",
-							RoleARN:         "role-arn",
+							TrustAnchorARN:  "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/ta",
+							ProfileARN:      "arn:aws:rolesanywhere:us-west-2:123456789012:profile/p",
+							RoleARN:         "arn:aws:iam::123456789012:role/my-role",
 							PrivateKeyPath:  keyPath,
 							CertificatePath: tmpDir + "/missing.crt",
 						},
@@ -182,9 +213,9 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					Hybrid: &api.HybridOptions{
 						IAMRolesAnywhere: &api.IAMRolesAnywhere{
 							NodeName:        "my-node",
-							TrustAnchorARN:  "trust-anchor-arn",
-							ProfileARN:      "profile-arn",
-							RoleARN:         "role-arn",
+							TrustAnchorARN:  "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/ta",
+							ProfileARN:      "arn:aws:rolesanywhere:us-west-2:123456789012:profile/p",
+							RoleARN:         "arn:aws:iam::123456789012:role/my-role",
 							CertificatePath: certPath,
 							PrivateKeyPath:  tmpDir + "/missing.key",
 						},
@@ -204,9 +235,9 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					Hybrid: &api.HybridOptions{
 						IAMRolesAnywhere: &api.IAMRolesAnywhere{
 							NodeName:        "my-node",
-							TrustAnchorARN:  "trust-anchor-arn",
-							ProfileARN:      "profile-arn",
-							RoleARN:         "role-arn",
+							TrustAnchorARN:  "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/ta",
+							ProfileARN:      "arn:aws:rolesanywhere:us-west-2:123456789012:profile/p",
+							RoleARN:         "arn:aws:iam::123456789012:role/my-role",
 							CertificatePath: certPath,
 							PrivateKeyPath:  keyPath,
 						},
@@ -229,9 +260,9 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					Hybrid: &api.HybridOptions{
 						IAMRolesAnywhere: &api.IAMRolesAnywhere{
 							NodeName:        "my-node",
-							TrustAnchorARN:  "trust-anchor-arn",
-							ProfileARN:      "profile-arn",
-							RoleARN:         "role-arn",
+							TrustAnchorARN:  "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/ta",
+							ProfileARN:      "arn:aws:rolesanywhere:us-west-2:123456789012:profile/p",
+							RoleARN:         "arn:aws:iam::123456789012:role/my-role",
 							PrivateKeyPath:  keyPath,
 							CertificatePath: wrongPermCertPath,
 						},
@@ -251,9 +282,9 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					Hybrid: &api.HybridOptions{
 						IAMRolesAnywhere: &api.IAMRolesAnywhere{
 							NodeName:        "my-node",
-							TrustAnchorARN:  "trust-anchor-arn",
-							ProfileARN:      "profile-arn",
-							RoleARN:         "role-arn",
+							TrustAnchorARN:  "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/ta",
+							ProfileARN:      "arn:aws:rolesanywhere:us-west-2:123456789012:profile/p",
+							RoleARN:         "arn:aws:iam::123456789012:role/my-role",
 							PrivateKeyPath:  keyPath,
 							CertificatePath: invalidCA,
 						},
@@ -273,9 +304,9 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					Hybrid: &api.HybridOptions{
 						IAMRolesAnywhere: &api.IAMRolesAnywhere{
 							NodeName:        "my-node",
-							TrustAnchorARN:  "trust-anchor-arn",
-							ProfileARN:      "profile-arn",
-							RoleARN:         "role-arn",
+							TrustAnchorARN:  "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/ta",
+							ProfileARN:      "arn:aws:rolesanywhere:us-west-2:123456789012:profile/p",
+							RoleARN:         "arn:aws:iam::123456789012:role/my-role",
 							PrivateKeyPath:  keyPath,
 							CertificatePath: expiredCertPath,
 						},
@@ -295,9 +326,9 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					Hybrid: &api.HybridOptions{
 						IAMRolesAnywhere: &api.IAMRolesAnywhere{
 							NodeName:        "my-node",
-							TrustAnchorARN:  "trust-anchor-arn",
-							ProfileARN:      "profile-arn",
-							RoleARN:         "role-arn",
+							TrustAnchorARN:  "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/ta",
+							ProfileARN:      "arn:aws:rolesanywhere:us-west-2:123456789012:profile/p",
+							RoleARN:         "arn:aws:iam::123456789012:role/my-role",
 							PrivateKeyPath:  keyPath,
 							CertificatePath: invalidSysTimeCertPath,
 						},
@@ -317,9 +348,9 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					Hybrid: &api.HybridOptions{
 						IAMRolesAnywhere: &api.IAMRolesAnywhere{
 							NodeName:        "my-node",
-							TrustAnchorARN:  "trust-anchor-arn",
-							ProfileARN:      "profile-arn",
-							RoleARN:         "role-arn",
+							TrustAnchorARN:  "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/ta",
+							ProfileARN:      "arn:aws:rolesanywhere:us-west-2:123456789012:profile/p",
+							RoleARN:         "arn:aws:iam::123456789012:role/my-role",
 							CertificatePath: certPath,
 							PrivateKeyPath:  keyPath,
 						},
@@ -401,7 +432,7 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					},
 				},
 			},
-			wantError: "invalid ActivationCode format: activation-code. Must be 20-250 characters",
+			wantError: "invalid ActivationCode format. Must be 20-250 characters",
 		},
 		{
 			name: "invalid ssm activation code (too long - 251 chars)",
@@ -419,7 +450,7 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 					},
 				},
 			},
-			wantError: "invalid ActivationCode format: " + strings.Repeat("a", 251) + ". Must be 20-250 characters",
+			wantError: "invalid ActivationCode format. Must be 20-250 characters",
 		},
 		{
 			name: "invalid ssm activation id by length",
@@ -457,6 +488,395 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 			},
 			wantError: "invalid ActivationID format: e488f2f6-e686-4afb-8A04-ef6dfabcdefff. Must be in format: ^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$",
 		},
+		// Node labels and taints validation
+		{
+			name: "valid labels and taints",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						Labels: map[string]string{
+							"example.com/rack":        "r1",
+							"node.kubernetes.io/role": "edge",
+							"team":                    "",
+						},
+						Taints: []api.Taint{
+							{Key: "example.com/edge", Value: "true", Effect: api.TaintEffectNoSchedule},
+							{Key: "example.com/edge", Value: "true", Effect: api.TaintEffectNoExecute},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid label key",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						Labels: map[string]string{
+							"example.com/rack/row": "r1",
+						},
+					},
+				},
+			},
+			wantError: `invalid label key "example.com/rack/row": a qualified name must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]') with an optional DNS subdomain prefix and '/' (e.g. 'example.com/MyName')`,
+		},
+		{
+			name: "invalid label value",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						Labels: map[string]string{
+							"example.com/rack": "rack 1",
+						},
+					},
+				},
+			},
+			wantError: `invalid value for label "example.com/rack": a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')`,
+		},
+		{
+			name: "label with reserved namespace",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						Labels: map[string]string{
+							"topology.kubernetes.io/zone": "zone-1",
+						},
+					},
+				},
+			},
+			wantError: `label "topology.kubernetes.io/zone" uses a reserved namespace. Only the kubelet.kubernetes.io and node.kubernetes.io namespaces are allowed under kubernetes.io and k8s.io`,
+		},
+		{
+			name: "label set by nodeadm",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						Labels: map[string]string{
+							"eks.amazonaws.com/compute-type": "ec2",
+						},
+					},
+				},
+			},
+			wantError: `label "eks.amazonaws.com/compute-type" is set by nodeadm and can't be overridden`,
+		},
+		{
+			name: "invalid taint effect",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						Taints: []api.Taint{
+							{Key: "example.com/edge", Effect: "NoRun"},
+						},
+					},
+				},
+			},
+			wantError: `invalid effect "NoRun" for taint 0. Must be one of: [NoSchedule, PreferNoSchedule, NoExecute]`,
+		},
+		{
+			name: "duplicate taint",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						Taints: []api.Taint{
+							{Key: "example.com/edge", Value: "a", Effect: api.TaintEffectNoSchedule},
+							{Key: "example.com/edge", Value: "b", Effect: api.TaintEffectNoSchedule},
+						},
+					},
+				},
+			},
+			wantError: `duplicate taint 1 with key "example.com/edge" and effect "NoSchedule"`,
+		},
+		{
+			name: "invalid reserved resources",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						ReservedResources: &api.ReservedResources{
+							Profile: api.ReservedResourcesProfileMinimal,
+							SystemReserved: map[string]string{
+								"ephemeral-storage": "5%",
+							},
+						},
+					},
+				},
+			},
+			wantError: `invalid value "5%" for ephemeral-storage: percentages are only supported for cpu and memory`,
+		},
+		{
+			name: "invalid cpu manager policy",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						CPUManager: "dynamic",
+					},
+				},
+			},
+			wantError: `invalid CPU manager policy "dynamic". Must be one of: [none, static]`,
+		},
+		{
+			name: "invalid containerd registry",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Containerd: api.ContainerdOptions{
+						Registries: []api.ContainerdRegistry{
+							{Name: "docker.io", Mirrors: []api.RegistryMirror{{URL: "mirror.example.com"}}},
+						},
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+					},
+				},
+			},
+			wantError: `invalid url "mirror.example.com" for mirror 0 of registry "docker.io": scheme must be http or https`,
+		},
+		{
+			name: "invalid containerd config",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Containerd: api.ContainerdOptions{
+						Config: "[plugins.\"io.containerd.grpc.v1.cri\".containerd]\ndiscard_unpacked_layers = flase\n",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+					},
+				},
+			},
+			wantError: "containerd config is not valid TOML: line 2, column 27: expected keyword \"false\"",
+		},
+		{
+			name: "invalid containerd runtime",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Containerd: api.ContainerdOptions{
+						Runtimes: []api.ContainerdRuntime{
+							{Name: "gvisor", RuntimeType: "runsc"},
+						},
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+					},
+				},
+			},
+			wantError: `invalid runtimeType "runsc" for runtime "gvisor": must be a shim name like io.containerd.runsc.v1`,
+		},
+		{
+			name: "invalid artifacts mirror",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						Artifacts: &api.ArtifactsOptions{
+							ManifestURL: "artifactory.example.com/manifest.yaml",
+						},
+					},
+				},
+			},
+			wantError: `invalid manifest url: "artifactory.example.com/manifest.yaml" must be an http or https url`,
+		},
+		{
+			name: "invalid artifacts trusted keys",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						Artifacts: &api.ArtifactsOptions{
+							TrustedKeys: "not a key",
+						},
+					},
+				},
+			},
+			wantError: "invalid trusted keys: " + signature.ErrNoTrustedKeys.Error(),
+		},
+		{
+			name: "invalid artifacts max download rate",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						Artifacts: &api.ArtifactsOptions{
+							MaxDownloadRate: "-1Mi",
+						},
+					},
+				},
+			},
+			wantError: "invalid max download rate -1Mi: must be positive",
+		},
+		{
+			name: "invalid swap mode",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						Swap: "unlimited",
+					},
+				},
+			},
+			wantError: `invalid swap mode "unlimited". Must be one of: [disable, limited]`,
+		},
+		{
+			name: "duplicate hugepages size",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						Hugepages: []api.Hugepages{
+							{Size: api.HugepageSize2Mi, Count: 512},
+							{Size: api.HugepageSize2Mi, Count: 1024},
+						},
+					},
+				},
+			},
+			wantError: `duplicate hugepages 1 with size "2Mi"`,
+		},
+		{
+			name: "invalid hugepages size",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+						Hugepages: []api.Hugepages{
+							{Size: "4Mi", Count: 512},
+						},
+					},
+				},
+			},
+			wantError: `invalid size "4Mi" for hugepages 0. Must be one of: [2Mi, 1Gi]`,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -473,3 +893,31 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_HybridNodeProviderValidateConfigReportsAllErrors(t *testing.T) {
+	g := NewWithT(t)
+	node := &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Hybrid: &api.HybridOptions{
+				SSM: &api.SSM{
+					ActivationID: "not-an-activation-id",
+				},
+			},
+		},
+	}
+	p, err := hybrid.NewHybridNodeProvider(node, []string{}, zap.NewNop())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	err = p.ValidateConfig()
+	g.Expect(err).To(HaveOccurred())
+	var fields []string
+	for _, e := range validation.Unwrap(err) {
+		fields = append(fields, validation.Field(e))
+	}
+	g.Expect(fields).To(Equal([]string{
+		"spec.cluster.name",
+		"spec.cluster.region",
+		"spec.hybrid.ssm.activationCode",
+		"spec.hybrid.ssm.activationId",
+	}))
+}
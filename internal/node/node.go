@@ -3,10 +3,12 @@ package node
 import (
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/configprovider"
 	"github.com/aws/eks-hybrid/internal/node/ec2"
 	"github.com/aws/eks-hybrid/internal/node/hybrid"
 	"github.com/aws/eks-hybrid/internal/nodeprovider"
+	"github.com/aws/eks-hybrid/internal/secret"
 )
 
 func NewNodeProvider(configSource string, skipPhases []string, logger *zap.Logger) (nodeprovider.NodeProvider, error) {
@@ -19,6 +21,18 @@ func NewNodeProvider(configSource string, skipPhases []string, logger *zap.Logge
 	if err != nil {
 		return nil, err
 	}
+	if reporter, ok := provider.(configprovider.FieldSourceReporter); ok && len(reporter.FieldSources()) > 0 {
+		logger.Info("Merged configuration from multiple files", zap.Any("fieldSources", reporter.FieldSources()))
+	}
+	return NewNodeProviderFromConfig(nodeConfig, skipPhases, logger)
+}
+
+// NewNodeProviderFromConfig returns the node provider for a node config that was
+// already loaded, resolving its secrets.
+func NewNodeProviderFromConfig(nodeConfig *api.NodeConfig, skipPhases []string, logger *zap.Logger) (nodeprovider.NodeProvider, error) {
+	if err := secret.ResolveNodeConfig(nodeConfig); err != nil {
+		return nil, err
+	}
 	if nodeConfig.IsHybridNode() {
 		logger.Info("Setting up hybrid node provider...")
 		return hybrid.NewHybridNodeProvider(nodeConfig, skipPhases, logger)
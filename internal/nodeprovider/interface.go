@@ -9,6 +9,7 @@ import (
 	"github.com/aws/eks-hybrid/internal/aws"
 	"github.com/aws/eks-hybrid/internal/configenricher"
 	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/render"
 	"github.com/aws/eks-hybrid/internal/system"
 )
 
@@ -47,3 +48,14 @@ type NodeProvider interface {
 	configenricher.ConfigEnricher
 	aws.Config
 }
+
+// Renderer is implemented by node providers that can generate the files written
+// to configure the node without modifying the host.
+type Renderer interface {
+	// LoadAws loads the AWS config from the credentials already present in the host,
+	// without configuring them like ConfigureAws does.
+	LoadAws(ctx context.Context) error
+
+	// RenderAWSConfig returns the files ConfigureAws would write without writing them.
+	RenderAWSConfig() ([]render.File, error)
+}
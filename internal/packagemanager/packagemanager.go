@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"time"
 
@@ -28,6 +29,7 @@ const (
 	snapRemoveVerb  = "remove"
 
 	yumUtilsManager             = "yum-config-manager"
+	yumDownloader               = "yumdownloader"
 	yumUtilsManagerPkg          = "yum-utils"
 	centOsDockerRepo            = "https://download.docker.com/linux/centos/docker-ce.repo"
 	ubuntuDockerRepo            = "https://download.docker.com/linux/ubuntu"
@@ -54,10 +56,25 @@ type DistroPackageManager struct {
 	deleteVerb          string
 	refreshMetadataVerb string
 	dockerRepo          string
-	logger              *zap.Logger
+	// localPackagesDir holds the package files to install instead of the repository
+	// packages, in a directory per package, like an offline bundle.
+	localPackagesDir string
+	logger           *zap.Logger
 }
 
-func New(containerdSource tracker.ContainerdSourceName, logger *zap.Logger) (*DistroPackageManager, error) {
+// Option configures a DistroPackageManager.
+type Option func(*DistroPackageManager)
+
+// WithLocalPackages installs containerd and iptables from the package files under dir,
+// in a directory per package as written by DownloadPackages, instead of the repositories.
+// Packages without a directory are still installed from the repositories.
+func WithLocalPackages(dir string) Option {
+	return func(pm *DistroPackageManager) {
+		pm.localPackagesDir = dir
+	}
+}
+
+func New(containerdSource tracker.ContainerdSourceName, logger *zap.Logger, opts ...Option) (*DistroPackageManager, error) {
 	manager, err := getOsPackageManager()
 	if err != nil {
 		return nil, err
@@ -74,9 +91,17 @@ func New(containerdSource tracker.ContainerdSourceName, logger *zap.Logger) (*Di
 	if containerdSource == tracker.ContainerdSourceDocker {
 		pm.dockerRepo = managerToDockerRepoMap[manager]
 	}
+	for _, opt := range opts {
+		opt(pm)
+	}
 	return pm, nil
 }
 
+// Manager returns the name of the package manager, like apt or yum.
+func (pm *DistroPackageManager) Manager() string {
+	return pm.manager
+}
+
 // Configure configures the package manager.
 func (pm *DistroPackageManager) Configure(ctx context.Context) error {
 	// Add docker repos to the package manager
@@ -230,7 +255,7 @@ func (pm *DistroPackageManager) refreshMetadataCacheCommand(ctx context.Context)
 func (pm *DistroPackageManager) GetContainerd(version string) artifact.Package {
 	packageName := pm.getContainerdPackageNameWithVersion(version)
 	return artifact.NewPackageSource(
-		artifact.NewCmd(pm.manager, pm.installVerb, packageName, "-y"),
+		pm.installCmd(containerdDistroPkgName, packageName),
 		artifact.NewCmd(pm.manager, pm.deleteVerb, packageName, "-y"),
 		artifact.NewCmd(pm.manager, pm.updateVerb, packageName, "-y"),
 	)
@@ -239,7 +264,7 @@ func (pm *DistroPackageManager) GetContainerd(version string) artifact.Package {
 // GetIptables satisfies the getiptables source interface
 func (pm *DistroPackageManager) GetIptables() artifact.Package {
 	return artifact.NewPackageSource(
-		artifact.NewCmd(pm.manager, pm.installVerb, iptablesPkgName, "-y"),
+		pm.installCmd(iptablesPkgName, iptablesPkgName),
 		artifact.NewCmd(pm.manager, pm.deleteVerb, iptablesPkgName, "-y"),
 		artifact.NewCmd(pm.manager, pm.updateVerb, iptablesPkgName, "-y"),
 	)
@@ -263,6 +288,66 @@ func (pm *DistroPackageManager) GetSSMPackage() artifact.Package {
 	)
 }
 
+// installCmd returns the command to install packageName, using the local package files
+// for name when there are any.
+func (pm *DistroPackageManager) installCmd(name, packageName string) artifact.Cmd {
+	if pm.localPackagesDir != "" {
+		files, _ := filepath.Glob(filepath.Join(pm.localPackagesDir, name, "*"+packageFileExtension[pm.manager]))
+		if len(files) > 0 {
+			return artifact.NewCmd(pm.manager, append([]string{pm.installVerb, "-y"}, files...)...)
+		}
+	}
+	return artifact.NewCmd(pm.manager, pm.installVerb, packageName, "-y")
+}
+
+// DownloadPackages downloads the containerd and iptables packages, with the dependencies
+// missing on this host, to a directory per package under dir, for WithLocalPackages.
+func (pm *DistroPackageManager) DownloadPackages(ctx context.Context, dir, containerdVersion string) error {
+	packages := map[string]string{
+		containerdDistroPkgName: pm.getContainerdPackageNameWithVersion(containerdVersion),
+		iptablesPkgName:         iptablesPkgName,
+	}
+	for _, name := range []string{containerdDistroPkgName, iptablesPkgName} {
+		pkgDir := filepath.Join(dir, name)
+		if err := os.MkdirAll(pkgDir, 0o755); err != nil {
+			return err
+		}
+		pm.logger.Info("Downloading package...", zap.String("package", packages[name]))
+		if err := pm.downloadPackage(ctx, pkgDir, packages[name]); err != nil {
+			return errors.Wrapf(err, "downloading %s package", name)
+		}
+	}
+	return nil
+}
+
+func (pm *DistroPackageManager) downloadPackage(ctx context.Context, dir, packageName string) error {
+	var download *exec.Cmd
+	switch pm.manager {
+	case aptPackageManager:
+		// apt keeps its lock and partial downloads in the archives directory
+		if err := os.MkdirAll(filepath.Join(dir, "partial"), 0o755); err != nil {
+			return err
+		}
+		defer os.RemoveAll(filepath.Join(dir, "partial"))
+		defer os.Remove(filepath.Join(dir, "lock"))
+		// reinstall downloads the package even when it's already installed on this host
+		download = exec.CommandContext(ctx, "apt-get", "install", "--download-only", "--reinstall", "-y", "-o", "Dir::Cache::archives="+dir, packageName)
+	case yumPackageManager:
+		if _, err := exec.LookPath(yumDownloader); err != nil {
+			if err := cmd.Retry(ctx, pm.yumUtilsPackage().InstallCmd, 5*time.Second); err != nil {
+				return errors.Wrapf(err, "failed to install %s using package manager", yumUtilsManagerPkg)
+			}
+		}
+		download = exec.CommandContext(ctx, yumDownloader, "--resolve", "--destdir", dir, packageName)
+	default:
+		return fmt.Errorf("downloading packages is not supported with %s", pm.manager)
+	}
+	if out, err := download.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "running %s: %s", download, out)
+	}
+	return nil
+}
+
 func (pm *DistroPackageManager) caCertsPackage() artifact.Package {
 	return artifact.NewPackageSource(
 		artifact.NewCmd(pm.manager, pm.installVerb, caCertsPkgName, "-y"),
@@ -309,6 +394,11 @@ func getOsPackageManager() (string, error) {
 	return "", errors.New("unsupported package manager encountered. Please run nodeadm from a supported os")
 }
 
+var packageFileExtension = map[string]string{
+	aptPackageManager: ".deb",
+	yumPackageManager: ".rpm",
+}
+
 var packageManagerInstallCmd = map[string]string{
 	aptPackageManager: "install",
 	yumPackageManager: "install",
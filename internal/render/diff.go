@@ -0,0 +1,68 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Diff returns a unified diff from the file currently on disk to the generated
+// file, or an empty string if they match. A missing file is diffed against
+// /dev/null. Both sides are redacted, so the diff never shows credentials; a
+// change only in redacted values is reported without showing them.
+func Diff(file File) (string, error) {
+	fromFile := file.Path
+	var current []byte
+	info, err := os.Stat(file.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		fromFile = "/dev/null"
+	} else if err != nil {
+		return "", err
+	} else {
+		if current, err = os.ReadFile(file.Path); err != nil {
+			return "", err
+		}
+	}
+
+	var sb strings.Builder
+	if info != nil && info.Mode().Perm() != file.Perm {
+		fmt.Fprintf(&sb, "# %s: mode %s, expected %s\n", file.Path, info.Mode().Perm(), file.Perm)
+	}
+	if info != nil && bytes.Equal(current, file.Content) {
+		return sb.String(), nil
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(Redact(current)),
+		B:        splitLines(Redact(file.Content)),
+		FromFile: fromFile,
+		ToFile:   file.Path,
+		Context:  3,
+	})
+	if err != nil {
+		return "", err
+	}
+	if diff == "" && info == nil {
+		diff = fmt.Sprintf("# %s: missing\n", file.Path)
+	} else if diff == "" {
+		diff = fmt.Sprintf("# %s: redacted values differ\n", file.Path)
+	}
+	sb.WriteString(diff)
+	return sb.String(), nil
+}
+
+func splitLines(content []byte) []string {
+	text := string(content)
+	if text == "" {
+		return nil
+	}
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	lines := strings.SplitAfter(text, "\n")
+	return lines[:len(lines)-1]
+}
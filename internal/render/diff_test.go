@@ -0,0 +1,46 @@
+package render_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/render"
+)
+
+func TestDiff(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "config.toml")
+	file := render.File{Path: path, Content: []byte("version = 2\nroot = \"/var/lib/containerd\"\n"), Perm: 0o644}
+
+	diff, err := render.Diff(file)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(Equal("--- /dev/null\n+++ " + path + "\n@@ -0,0 +1,2 @@\n+version = 2\n+root = \"/var/lib/containerd\"\n"))
+
+	g.Expect(os.WriteFile(path, file.Content, 0o644)).To(Succeed())
+	g.Expect(os.Chmod(path, 0o644)).To(Succeed())
+	diff, err = render.Diff(file)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(BeEmpty())
+
+	g.Expect(os.WriteFile(path, []byte("version = 2\nroot = \"/data/containerd\"\n"), 0o600)).To(Succeed())
+	g.Expect(os.Chmod(path, 0o600)).To(Succeed())
+	diff, err = render.Diff(file)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(Equal("# " + path + ": mode -rw-------, expected -rw-r--r--\n--- " + path + "\n+++ " + path + "\n@@ -1,2 +1,2 @@\n version = 2\n-root = \"/data/containerd\"\n+root = \"/var/lib/containerd\"\n"))
+}
+
+func TestDiffRedactsSecrets(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "config.toml")
+	g.Expect(os.WriteFile(path, []byte("password = \"old\"\n"), 0o600)).To(Succeed())
+	g.Expect(os.Chmod(path, 0o600)).To(Succeed())
+
+	diff, err := render.Diff(render.File{Path: path, Content: []byte("password = \"new\"\n"), Perm: 0o600})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).NotTo(ContainSubstring("old"))
+	g.Expect(diff).NotTo(ContainSubstring("new"))
+	g.Expect(diff).To(Equal("# " + path + ": redacted values differ\n"))
+}
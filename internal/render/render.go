@@ -0,0 +1,99 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/aws/eks-hybrid/internal/secret"
+)
+
+const redactedValue = "<redacted>"
+
+// secretLineRegex matches `key = value`, `key: value` and `"key": value` lines where the
+// key looks like it holds a credential. Values that open an object or array are skipped.
+var secretLineRegex = regexp.MustCompile(`(?i)^(\s*"?[\w.-]*(?:password|passwd|secret|token|activation[_-]?code|client-key-data|\bauth)"?\s*[:=]\s*)([^\s{\[].*?)(,?)\s*$`)
+
+// File is a file generated by nodeadm to configure the node.
+type File struct {
+	Path    string
+	Content []byte
+	Perm    fs.FileMode
+}
+
+// WriteFileFunc writes a file, creating its parent directories if needed.
+// It matches util.WriteFileWithDir so generated files can be captured instead.
+type WriteFileFunc func(path string, data []byte, perm fs.FileMode) error
+
+// Recorder captures files instead of writing them to disk.
+type Recorder struct {
+	files []File
+}
+
+// WriteFile records a file. Writing the same path again replaces the previous content.
+func (r *Recorder) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	file := File{
+		Path:    path,
+		Content: append([]byte(nil), data...),
+		Perm:    perm,
+	}
+	for i := range r.files {
+		if r.files[i].Path == path {
+			r.files[i] = file
+			return nil
+		}
+	}
+	r.files = append(r.files, file)
+	return nil
+}
+
+// Files returns the recorded files in the order they were first written.
+func (r *Recorder) Files() []File {
+	return r.files
+}
+
+// Redact masks the values of keys that look like credentials, like passwords and tokens,
+// and any value registered as a secret.
+func Redact(content []byte) []byte {
+	lines := strings.Split(secret.Redact(string(content)), "\n")
+	for i, line := range lines {
+		lines[i] = secretLineRegex.ReplaceAllString(line, fmt.Sprintf(`${1}"%s"${3}`, redactedValue))
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// Print writes every file to w, each preceded by a header with its path and permissions.
+func Print(w io.Writer, files []File) error {
+	for _, file := range files {
+		if _, err := fmt.Fprintf(w, "# %s (%s)\n", file.Path, file.Perm); err != nil {
+			return err
+		}
+		content := string(file.Content)
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteToDir writes every file under dir, using the file's absolute path as the
+// path relative to dir.
+func WriteToDir(dir string, files []File) error {
+	for _, file := range files {
+		target := filepath.Join(dir, filepath.Clean(file.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, file.Content, file.Perm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package render_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/render"
+)
+
+func TestRedact(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "toml registry auth",
+			content:  "[plugins.\"io.containerd.grpc.v1.cri\".registry.configs.\"my.registry\".auth]\n  username = \"user\"\n  password = \"hunter2\"\n  auth = \"dXNlcjpodW50ZXIy\"\n  identitytoken = \"token\"",
+			expected: "[plugins.\"io.containerd.grpc.v1.cri\".registry.configs.\"my.registry\".auth]\n  username = \"user\"\n  password = \"<redacted>\"\n  auth = \"<redacted>\"\n  identitytoken = \"<redacted>\"",
+		},
+		{
+			name:     "json keys",
+			content:  "{\n    \"bootstrapToken\": \"abc\",\n    \"authentication\": {\n        \"anonymous\": {}\n    }\n}",
+			expected: "{\n    \"bootstrapToken\": \"<redacted>\",\n    \"authentication\": {\n        \"anonymous\": {}\n    }\n}",
+		},
+		{
+			name:     "yaml keys",
+			content:  "users:\n- name: kubelet\n  user:\n    token: abc\n    client-key-data: a2V5",
+			expected: "users:\n- name: kubelet\n  user:\n    token: \"<redacted>\"\n    client-key-data: \"<redacted>\"",
+		},
+		{
+			name:     "nothing to redact",
+			content:  "region = us-west-2\ncredential_process = /usr/local/bin/aws_signing_helper credential-process",
+			expected: "region = us-west-2\ncredential_process = /usr/local/bin/aws_signing_helper credential-process",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(string(render.Redact([]byte(tc.content)))).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	g := NewWithT(t)
+	recorder := &render.Recorder{}
+	g.Expect(recorder.WriteFile("/etc/a", []byte("a"), 0o644)).To(Succeed())
+	g.Expect(recorder.WriteFile("/etc/b", []byte("b"), 0o600)).To(Succeed())
+	g.Expect(recorder.WriteFile("/etc/a", []byte("a2"), 0o644)).To(Succeed())
+
+	g.Expect(recorder.Files()).To(Equal([]render.File{
+		{Path: "/etc/a", Content: []byte("a2"), Perm: 0o644},
+		{Path: "/etc/b", Content: []byte("b"), Perm: 0o600},
+	}))
+}
+
+func TestPrintAndWriteToDir(t *testing.T) {
+	g := NewWithT(t)
+	files := []render.File{
+		{Path: "/etc/containerd/config.toml", Content: []byte("version = 2"), Perm: 0o644},
+		{Path: "/etc/eks/kubelet/environment", Content: []byte("NODEADM_KUBELET_ARGS=\"--v=2\"\n"), Perm: 0o600},
+	}
+
+	var out bytes.Buffer
+	g.Expect(render.Print(&out, files)).To(Succeed())
+	g.Expect(out.String()).To(Equal("# /etc/containerd/config.toml (-rw-r--r--)\nversion = 2\n\n# /etc/eks/kubelet/environment (-rw-------)\nNODEADM_KUBELET_ARGS=\"--v=2\"\n\n"))
+
+	dir := t.TempDir()
+	g.Expect(render.WriteToDir(dir, files)).To(Succeed())
+	content, err := os.ReadFile(filepath.Join(dir, "etc/containerd/config.toml"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("version = 2"))
+	info, err := os.Stat(filepath.Join(dir, "etc/eks/kubelet/environment"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+}
@@ -0,0 +1,110 @@
+// Package schema builds schemas for the nodeadm configuration from the
+// CustomResourceDefinition generated from the API types.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/api"
+	"github.com/aws/eks-hybrid/crds"
+)
+
+const (
+	jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+	preserveUnknownFieldsKey = "x-kubernetes-preserve-unknown-fields"
+)
+
+// OpenAPI returns the OpenAPI v3 schema of the NodeConfig kind for the given
+// API version, as published in the CustomResourceDefinition.
+func OpenAPI(version string) (map[string]interface{}, error) {
+	var crd struct {
+		Spec struct {
+			Versions []struct {
+				Name   string `json:"name"`
+				Schema struct {
+					OpenAPIV3Schema map[string]interface{} `json:"openAPIV3Schema"`
+				} `json:"schema"`
+			} `json:"versions"`
+		} `json:"spec"`
+	}
+	if err := yaml.Unmarshal(crds.NodeConfig, &crd); err != nil {
+		return nil, fmt.Errorf("parsing NodeConfig CustomResourceDefinition: %w", err)
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version {
+			return v.Schema.OpenAPIV3Schema, nil
+		}
+	}
+	return nil, fmt.Errorf("no schema found for NodeConfig version %s", version)
+}
+
+// JSONSchema returns a JSON Schema of the NodeConfig kind for the given API version.
+// Unlike the OpenAPI schema, it rejects unknown fields like nodeadm's strict decoding
+// and constrains apiVersion and kind, so editors and linters can validate configs offline.
+func JSONSchema(version string) (map[string]interface{}, error) {
+	openAPI, err := OpenAPI(version)
+	if err != nil {
+		return nil, err
+	}
+	schema := toJSONSchema(openAPI)
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = api.KindNodeConfig
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		properties["apiVersion"] = map[string]interface{}{
+			"type": "string",
+			"enum": []interface{}{api.GroupName + "/" + version},
+		}
+		properties["kind"] = map[string]interface{}{
+			"type": "string",
+			"enum": []interface{}{api.KindNodeConfig},
+		}
+	}
+	schema["required"] = []interface{}{"apiVersion", "kind"}
+	return schema, nil
+}
+
+// toJSONSchema converts an OpenAPI v3 structural schema to JSON Schema.
+func toJSONSchema(openAPI map[string]interface{}) map[string]interface{} {
+	schema := map[string]interface{}{}
+	for key, value := range openAPI {
+		switch key {
+		case "properties":
+			properties := map[string]interface{}{}
+			for name, property := range value.(map[string]interface{}) {
+				properties[name] = toJSONSchema(property.(map[string]interface{}))
+			}
+			schema[key] = properties
+		case "items", "additionalProperties":
+			if sub, ok := value.(map[string]interface{}); ok {
+				schema[key] = toJSONSchema(sub)
+			} else {
+				schema[key] = value
+			}
+		default:
+			if !strings.HasPrefix(key, "x-kubernetes-") {
+				schema[key] = value
+			}
+		}
+	}
+	if preserve, _ := openAPI[preserveUnknownFieldsKey].(bool); preserve {
+		// embedded documents like the kubelet configuration accept any value
+		delete(schema, "type")
+		return schema
+	}
+	if _, ok := schema["properties"]; ok {
+		if _, ok := schema["additionalProperties"]; !ok {
+			schema["additionalProperties"] = false
+		}
+	}
+	return schema
+}
+
+// Marshal encodes a schema as indented JSON.
+func Marshal(schema map[string]interface{}) ([]byte, error) {
+	return json.MarshalIndent(schema, "", "  ")
+}
@@ -0,0 +1,53 @@
+package schema_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/schema"
+)
+
+func property(g *WithT, s map[string]interface{}, path ...string) map[string]interface{} {
+	for _, name := range path {
+		properties, ok := s["properties"].(map[string]interface{})
+		g.Expect(ok).To(BeTrue(), "no properties before %s", name)
+		s, ok = properties[name].(map[string]interface{})
+		g.Expect(ok).To(BeTrue(), "no property %s", name)
+	}
+	return s
+}
+
+func TestJSONSchema(t *testing.T) {
+	g := NewWithT(t)
+	s, err := schema.JSONSchema("v1alpha1")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(s).To(HaveKeyWithValue("$schema", "https://json-schema.org/draft/2020-12/schema"))
+	g.Expect(s).To(HaveKeyWithValue("required", ConsistOf("apiVersion", "kind")))
+	g.Expect(property(g, s, "apiVersion")).To(HaveKeyWithValue("enum", ConsistOf("node.eks.aws/v1alpha1")))
+	g.Expect(property(g, s, "kind")).To(HaveKeyWithValue("enum", ConsistOf("NodeConfig")))
+
+	g.Expect(property(g, s, "spec")).To(HaveKeyWithValue("additionalProperties", false))
+	g.Expect(property(g, s, "spec", "instance", "localStorage", "strategy")).To(HaveKeyWithValue("enum", ConsistOf("RAID0", "Mount")))
+	g.Expect(property(g, s, "spec", "hybrid", "ssm", "activationId")).To(HaveKeyWithValue("pattern", "^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$"))
+	g.Expect(property(g, s, "spec", "hybrid", "ssm", "activationCode")).To(HaveKeyWithValue("pattern", "^.{20,250}$"))
+	for _, field := range []string{"trustAnchorArn", "profileArn", "roleArn"} {
+		g.Expect(property(g, s, "spec", "hybrid", "iamRolesAnywhere", field)).To(HaveKey("pattern"))
+	}
+	g.Expect(property(g, s, "spec", "cluster", "name")).To(HaveKeyWithValue("description", "Name is the name of your EKS cluster"))
+
+	kubeletConfig := property(g, s, "spec", "kubelet", "config")
+	g.Expect(kubeletConfig).To(HaveKeyWithValue("additionalProperties", Equal(map[string]interface{}{})))
+}
+
+func TestOpenAPI(t *testing.T) {
+	g := NewWithT(t)
+	s, err := schema.OpenAPI("v1alpha1")
+	g.Expect(err).NotTo(HaveOccurred())
+	kubeletConfig := property(g, s, "spec", "kubelet", "config")
+	g.Expect(kubeletConfig["additionalProperties"]).To(HaveKeyWithValue("x-kubernetes-preserve-unknown-fields", true))
+
+	_, err = schema.OpenAPI("v1")
+	g.Expect(err).To(MatchError("no schema found for NodeConfig version v1"))
+}
@@ -0,0 +1,121 @@
+// Package secret resolves sensitive config values from secret sources and
+// redacts them from logs and errors.
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+const (
+	redactedValue = "<redacted>"
+
+	// credentialsDirectoryEnv is set by systemd to the directory holding the unit's credentials.
+	credentialsDirectoryEnv = "CREDENTIALS_DIRECTORY"
+)
+
+var (
+	mu     sync.RWMutex
+	values []string
+)
+
+// Register adds a value to redact from logs and errors.
+func Register(value string) {
+	if value == "" {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, v := range values {
+		if v == value {
+			return
+		}
+	}
+	values = append(values, value)
+}
+
+// Redact replaces every registered value in s.
+func Redact(s string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, v := range values {
+		s = strings.ReplaceAll(s, v, redactedValue)
+	}
+	return s
+}
+
+// Resolve reads the value of a secret source and registers it for redaction.
+func Resolve(source *api.SecretSource) (string, error) {
+	var set []string
+	if source.File != "" {
+		set = append(set, "file")
+	}
+	if source.Env != "" {
+		set = append(set, "env")
+	}
+	if source.Credential != "" {
+		set = append(set, "credential")
+	}
+	if len(set) != 1 {
+		return "", fmt.Errorf("exactly one of file, env or credential must be set in a secret source, got %d", len(set))
+	}
+
+	var value string
+	switch {
+	case source.File != "":
+		data, err := os.ReadFile(source.File)
+		if err != nil {
+			return "", fmt.Errorf("reading secret file: %w", err)
+		}
+		value = strings.TrimRight(string(data), "\r\n")
+	case source.Env != "":
+		var ok bool
+		if value, ok = os.LookupEnv(source.Env); !ok {
+			return "", fmt.Errorf("secret environment variable %s is not set", source.Env)
+		}
+	case source.Credential != "":
+		dir := os.Getenv(credentialsDirectoryEnv)
+		if dir == "" {
+			return "", fmt.Errorf("reading systemd credential %s: %s is not set, make sure nodeadm runs in a unit with LoadCredential", source.Credential, credentialsDirectoryEnv)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, source.Credential))
+		if err != nil {
+			return "", fmt.Errorf("reading systemd credential: %w", err)
+		}
+		value = strings.TrimRight(string(data), "\r\n")
+	}
+	if value == "" {
+		return "", errors.New("secret source is empty")
+	}
+	Register(value)
+	return value, nil
+}
+
+// ResolveNodeConfig fills the sensitive fields of the node config from their secret
+// sources and registers every sensitive value for redaction, including the ones set inline.
+func ResolveNodeConfig(cfg *api.NodeConfig) error {
+	if !cfg.IsSSM() {
+		return nil
+	}
+	ssm := cfg.Spec.Hybrid.SSM
+	if ssm.ActivationCodeFrom != nil {
+		const field = "spec.hybrid.ssm.activationCodeFrom"
+		if ssm.ActivationCode != "" {
+			return validation.NewFieldError(field, "Only one of ActivationCode or ActivationCodeFrom can be set in hybrid ssm configuration")
+		}
+		code, err := Resolve(ssm.ActivationCodeFrom)
+		if err != nil {
+			return validation.WithField(field, fmt.Errorf("resolving ActivationCodeFrom: %w", err))
+		}
+		ssm.ActivationCode = code
+	}
+	Register(ssm.ActivationCode)
+	return nil
+}
@@ -0,0 +1,105 @@
+package secret_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/secret"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+func ssmNodeConfig(ssm *api.SSM) *api.NodeConfig {
+	return &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Hybrid: &api.HybridOptions{SSM: ssm},
+		},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "activation-code"), []byte("code-from-file\n"), 0o600)).To(Succeed())
+	t.Setenv("ACTIVATION_CODE", "code-from-env")
+	t.Setenv("CREDENTIALS_DIRECTORY", dir)
+
+	value, err := secret.Resolve(&api.SecretSource{File: filepath.Join(dir, "activation-code")})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(value).To(Equal("code-from-file"))
+
+	value, err = secret.Resolve(&api.SecretSource{Env: "ACTIVATION_CODE"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(value).To(Equal("code-from-env"))
+
+	value, err = secret.Resolve(&api.SecretSource{Credential: "activation-code"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(value).To(Equal("code-from-file"))
+
+	_, err = secret.Resolve(&api.SecretSource{Env: "MISSING_ACTIVATION_CODE"})
+	g.Expect(err).To(MatchError("secret environment variable MISSING_ACTIVATION_CODE is not set"))
+
+	_, err = secret.Resolve(&api.SecretSource{Env: "ACTIVATION_CODE", File: "/etc/code"})
+	g.Expect(err).To(MatchError("exactly one of file, env or credential must be set in a secret source, got 2"))
+
+	t.Setenv("CREDENTIALS_DIRECTORY", "")
+	_, err = secret.Resolve(&api.SecretSource{Credential: "activation-code"})
+	g.Expect(err).To(MatchError(ContainSubstring("CREDENTIALS_DIRECTORY is not set")))
+}
+
+func TestResolveNodeConfig(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("SSM_ACTIVATION_CODE", "resolve-node-config-activation-code")
+
+	cfg := ssmNodeConfig(&api.SSM{
+		ActivationCodeFrom: &api.SecretSource{Env: "SSM_ACTIVATION_CODE"},
+		ActivationID:       "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+	})
+	g.Expect(secret.ResolveNodeConfig(cfg)).To(Succeed())
+	g.Expect(cfg.Spec.Hybrid.SSM.ActivationCode).To(Equal("resolve-node-config-activation-code"))
+	g.Expect(secret.Redact("-code resolve-node-config-activation-code")).To(Equal("-code <redacted>"))
+
+	cfg = ssmNodeConfig(&api.SSM{
+		ActivationCode:     "inline-activation-code-value",
+		ActivationCodeFrom: &api.SecretSource{Env: "SSM_ACTIVATION_CODE"},
+	})
+	err := secret.ResolveNodeConfig(cfg)
+	g.Expect(err).To(MatchError("Only one of ActivationCode or ActivationCodeFrom can be set in hybrid ssm configuration"))
+	g.Expect(validation.Field(err)).To(Equal("spec.hybrid.ssm.activationCodeFrom"))
+
+	cfg = ssmNodeConfig(&api.SSM{ActivationCode: "inline-activation-code-value"})
+	g.Expect(secret.ResolveNodeConfig(cfg)).To(Succeed())
+	g.Expect(secret.Redact("inline-activation-code-value")).To(Equal("<redacted>"))
+}
+
+func TestRedactingCore(t *testing.T) {
+	g := NewWithT(t)
+	secret.Register("redacting-core-secret")
+	core, logs := observer.New(zapcore.InfoLevel)
+	log := zap.New(core).WithOptions(zap.WrapCore(secret.NewRedactingCore))
+
+	log.With(zap.String("with", "redacting-core-secret")).Info("using redacting-core-secret",
+		zap.String("code", "redacting-core-secret"),
+		zap.Error(errors.New("running command [-code redacting-core-secret]")),
+		zap.Strings("args", []string{"-code", "redacting-core-secret"}),
+		zap.Int("count", 1),
+	)
+
+	g.Expect(logs.Len()).To(Equal(1))
+	entry := logs.All()[0]
+	g.Expect(entry.Message).To(Equal("using <redacted>"))
+	g.Expect(entry.ContextMap()).To(Equal(map[string]interface{}{
+		"with":  "<redacted>",
+		"code":  "<redacted>",
+		"error": "running command [-code <redacted>]",
+		"args":  `["-code","<redacted>"]`,
+		"count": int64(1),
+	}))
+}
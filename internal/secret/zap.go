@@ -0,0 +1,72 @@
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// redactingCore redacts registered secret values from log entries before
+// passing them to the wrapped core.
+type redactingCore struct {
+	zapcore.Core
+}
+
+// NewRedactingCore wraps core so registered secret values never reach the logs.
+// It is meant to be used with [zap.WrapCore].
+func NewRedactingCore(core zapcore.Core) zapcore.Core {
+	return &redactingCore{Core: core}
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(redactFields(fields))}
+}
+
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = Redact(entry.Message)
+	return c.Core.Write(entry, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	mu.RLock()
+	empty := len(values) == 0
+	mu.RUnlock()
+	if empty {
+		return fields
+	}
+
+	redacted := make([]zapcore.Field, len(fields))
+	for i, field := range fields {
+		redacted[i] = field
+		switch field.Type {
+		case zapcore.StringType:
+			redacted[i].String = Redact(field.String)
+		case zapcore.ErrorType:
+			if err, ok := field.Interface.(error); ok {
+				redacted[i] = zap.String(field.Key, Redact(err.Error()))
+			}
+		case zapcore.StringerType:
+			if stringer, ok := field.Interface.(fmt.Stringer); ok {
+				redacted[i] = zap.String(field.Key, Redact(stringer.String()))
+			}
+		case zapcore.ReflectType, zapcore.ArrayMarshalerType, zapcore.ObjectMarshalerType:
+			data, err := json.Marshal(field.Interface)
+			if err != nil {
+				continue
+			}
+			if value := Redact(string(data)); value != string(data) {
+				redacted[i] = zap.String(field.Key, value)
+			}
+		}
+	}
+	return redacted
+}
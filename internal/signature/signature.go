@@ -0,0 +1,106 @@
+package signature
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ProtonMail/gopenpgp/v3/armor"
+	"github.com/ProtonMail/gopenpgp/v3/crypto"
+)
+
+// set build time, base64 encoded armored public keys of the release signing keys
+var trustedKeys string
+
+// TrustedKeysEnv is the environment variable with the path to a file of armored public keys
+// trusted instead of the keys nodeadm was built with, like the keys of a private mirror.
+const TrustedKeysEnv = "NODEADM_TRUSTED_KEYS"
+
+const publicKeyHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+// ErrNoTrustedKeys is returned when there are no keys to verify signatures with.
+var ErrNoTrustedKeys = errors.New("no trusted signing keys found")
+
+// Verifier verifies the detached PGP signatures of the release manifest and artifacts.
+type Verifier struct {
+	keys *crypto.KeyRing
+}
+
+// DefaultKeys returns the armored public keys nodeadm was built with.
+func DefaultKeys() (string, error) {
+	keys, err := base64.StdEncoding.DecodeString(trustedKeys)
+	if err != nil {
+		return "", fmt.Errorf("decoding built-in trusted keys: %w", err)
+	}
+	return string(keys), nil
+}
+
+// OverrideKeys returns the armored public keys to trust instead of the keys nodeadm was
+// built with: the ones in keysFile when set, then the ones in the file from TrustedKeysEnv,
+// then configKeys. It returns an empty string when none are set.
+func OverrideKeys(keysFile, configKeys string) (string, error) {
+	if keysFile == "" {
+		keysFile = os.Getenv(TrustedKeysEnv)
+	}
+	if keysFile != "" {
+		keys, err := os.ReadFile(keysFile)
+		if err != nil {
+			return "", fmt.Errorf("reading trusted keys: %w", err)
+		}
+		return string(keys), nil
+	}
+	return configKeys, nil
+}
+
+// NewVerifier returns a Verifier trusting the armored public keys, which can hold
+// several key blocks.
+func NewVerifier(armoredKeys string) (*Verifier, error) {
+	var binaryKeys []byte
+	for _, block := range splitKeyBlocks(armoredKeys) {
+		keys, err := armor.Unarmor(block)
+		if err != nil {
+			return nil, fmt.Errorf("reading trusted keys: %w", err)
+		}
+		binaryKeys = append(binaryKeys, keys...)
+	}
+	if len(binaryKeys) == 0 {
+		return nil, ErrNoTrustedKeys
+	}
+	keyRing, err := crypto.NewKeyRingFromBinary(binaryKeys)
+	if err != nil {
+		return nil, fmt.Errorf("reading trusted keys: %w", err)
+	}
+	if keyRing.CountEntities() == 0 {
+		return nil, ErrNoTrustedKeys
+	}
+	return &Verifier{keys: keyRing}, nil
+}
+
+// Verify checks signature is a valid detached signature of data from a trusted key.
+// The signature can be armored or binary.
+func (v *Verifier) Verify(data, signature []byte) error {
+	if len(signature) == 0 {
+		return errors.New("signature is empty")
+	}
+	verifier, err := crypto.PGP().Verify().
+		VerificationKeys(v.keys).
+		New()
+	if err != nil {
+		return err
+	}
+	result, err := verifier.VerifyDetached(data, signature, crypto.Auto)
+	if err != nil {
+		return err
+	}
+	return result.SignatureError()
+}
+
+func splitKeyBlocks(armoredKeys string) []string {
+	var blocks []string
+	for _, block := range strings.Split(armoredKeys, publicKeyHeader)[1:] {
+		blocks = append(blocks, publicKeyHeader+block)
+	}
+	return blocks
+}
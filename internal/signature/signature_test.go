@@ -0,0 +1,61 @@
+package signature_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/signature"
+	"github.com/aws/eks-hybrid/internal/test"
+)
+
+func TestVerify(t *testing.T) {
+	g := NewWithT(t)
+	key := test.GenerateSigningKey(g)
+	otherKey := test.GenerateSigningKey(g)
+	data := []byte("0123456789abcdef  kubelet\n")
+
+	verifier, err := signature.NewVerifier(key.PublicKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(verifier.Verify(data, key.Sign(g, data))).To(Succeed())
+	g.Expect(verifier.Verify([]byte("fedcba9876543210  kubelet\n"), key.Sign(g, data))).NotTo(Succeed())
+	g.Expect(verifier.Verify(data, otherKey.Sign(g, data))).NotTo(Succeed())
+	g.Expect(verifier.Verify(data, nil)).To(MatchError("signature is empty"))
+}
+
+func TestNewVerifierMultipleKeys(t *testing.T) {
+	g := NewWithT(t)
+	key := test.GenerateSigningKey(g)
+	otherKey := test.GenerateSigningKey(g)
+	data := []byte("manifest")
+
+	verifier, err := signature.NewVerifier(key.PublicKey + "\n" + otherKey.PublicKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(verifier.Verify(data, key.Sign(g, data))).To(Succeed())
+	g.Expect(verifier.Verify(data, otherKey.Sign(g, data))).To(Succeed())
+}
+
+func TestNewVerifierNoKeys(t *testing.T) {
+	g := NewWithT(t)
+	_, err := signature.NewVerifier("")
+	g.Expect(err).To(MatchError(signature.ErrNoTrustedKeys))
+}
+
+func TestOverrideKeys(t *testing.T) {
+	g := NewWithT(t)
+	keysFile := filepath.Join(t.TempDir(), "keys.asc")
+	g.Expect(os.WriteFile(keysFile, []byte("file keys"), 0o644)).To(Succeed())
+	envKeysFile := filepath.Join(t.TempDir(), "keys.asc")
+	g.Expect(os.WriteFile(envKeysFile, []byte("env keys"), 0o644)).To(Succeed())
+
+	t.Setenv(signature.TrustedKeysEnv, "")
+	g.Expect(signature.OverrideKeys(keysFile, "config keys")).To(Equal("file keys"))
+	g.Expect(signature.OverrideKeys("", "config keys")).To(Equal("config keys"))
+	g.Expect(signature.OverrideKeys("", "")).To(BeEmpty())
+
+	t.Setenv(signature.TrustedKeysEnv, envKeysFile)
+	g.Expect(signature.OverrideKeys("", "config keys")).To(Equal("env keys"))
+	g.Expect(signature.OverrideKeys(keysFile, "config keys")).To(Equal("file keys"))
+}
@@ -62,7 +62,7 @@ func Install(ctx context.Context, opts InstallOptions) error {
 		return err
 	}
 
-	return opts.Tracker.Add(artifact.Ssm)
+	return opts.Tracker.Add(artifact.Ssm, filepath.Join(opts.InstallRoot, defaultInstallerPath))
 }
 
 func installFromSource(ctx context.Context, opts InstallOptions) error {
@@ -149,7 +149,7 @@ func TestInstall(t *testing.T) {
 			tr := &tracker.Tracker{Artifacts: &tracker.InstalledArtifacts{}}
 			logger := zap.NewNop()
 
-			err := ssm.Install(context.Background(), ssm.InstallOptions{
+			err := ssm.Install(test.ContextWithFastRetries(), ssm.InstallOptions{
 				Tracker:     tr,
 				Source:      source,
 				Logger:      logger,
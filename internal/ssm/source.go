@@ -134,13 +134,18 @@ func (s ssmInstallerSource) PublicKey() string {
 
 // Rename existing buildSSMURL to defaultBuildSSMURL
 func (s ssmInstallerSource) defaultBuildSSMURL() (string, error) {
+	return InstallerURL(s.region)
+}
+
+// InstallerURL returns the official release URL of the SSM installer for region.
+func InstallerURL(region string) (string, error) {
 	variant, err := detectPlatformVariant()
 	if err != nil {
 		return "", err
 	}
 
 	platform := fmt.Sprintf("%v_%v", variant, runtime.GOARCH)
-	return fmt.Sprintf("https://amazon-ssm-%v.s3.%v.amazonaws.com/latest/%v/ssm-setup-cli", s.region, s.region, platform), nil
+	return fmt.Sprintf("https://amazon-ssm-%v.s3.%v.amazonaws.com/latest/%v/ssm-setup-cli", region, region, platform), nil
 }
 
 // detectPlatformVariant returns a portion of the SSM installers URL that is dependent on the
@@ -1,7 +1,6 @@
 package ssm_test
 
 import (
-	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -81,7 +80,7 @@ func TestGetSSMInstaller(t *testing.T) {
 			source := ssm.NewSSMInstaller(zap.NewNop(), "test-region",
 				ssm.WithURLBuilder(urlBuilder),
 			)
-			reader, err := source.GetSSMInstaller(context.Background())
+			reader, err := source.GetSSMInstaller(test.ContextWithFastRetries())
 
 			if tt.wantErr != "" {
 				g.Expect(err).To(HaveOccurred())
@@ -166,7 +165,7 @@ func TestGetSSMInstallerSignature(t *testing.T) {
 			source := ssm.NewSSMInstaller(zap.NewNop(), "test-region",
 				ssm.WithURLBuilder(urlBuilder),
 			)
-			reader, err := source.GetSSMInstallerSignature(context.Background())
+			reader, err := source.GetSSMInstallerSignature(test.ContextWithFastRetries())
 
 			if tt.wantErr != "" {
 				g.Expect(err).To(HaveOccurred())
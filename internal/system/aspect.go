@@ -1,6 +1,14 @@
 package system
 
+import "github.com/aws/eks-hybrid/internal/render"
+
 type SystemAspect interface {
 	Name() string
 	Setup() error
 }
+
+// AspectRenderer is implemented by aspects that write configuration files
+// during Setup, so those files can be generated without applying them.
+type AspectRenderer interface {
+	Render() ([]render.File, error)
+}
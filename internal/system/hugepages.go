@@ -0,0 +1,253 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+const (
+	hugepagesAspectName = "hugepages"
+	grubDropInDir       = "/etc/default/grub.d"
+	grubDropInFile      = "99-nodeadm-hugepages.cfg"
+	grubDropInPerm      = 0o644
+)
+
+var (
+	hugepagesDir         = "/sys/kernel/mm/hugepages"
+	kernelCmdlinePath    = "/proc/cmdline"
+	meminfoPath          = "/proc/meminfo"
+	hugepageSizeRegexp   = regexp.MustCompile(`Hugepagesize:\s*([0-9]+) kB`)
+	hugepagesTotalRegexp = regexp.MustCompile(`HugePages_Total:\s*([0-9]+)`)
+
+	hugepageSizesKB = map[api.HugepageSize]uint64{
+		api.HugepageSize2Mi: 2 * 1024,
+		api.HugepageSize1Gi: 1024 * 1024,
+	}
+)
+
+type hugepagesAspect struct {
+	nodeConfig *api.NodeConfig
+	logger     *zap.Logger
+	// writeFile writes to sysfs, the kernel may allocate fewer pages than written.
+	writeFile func(name string, data []byte, perm os.FileMode) error
+	// configureKernelCmdline adds arguments to the kernel command line for the next boot.
+	configureKernelCmdline func(args string) error
+	// trackKernelArgs records the arguments added to the kernel command line, so
+	// uninstall removes them.
+	trackKernelArgs func(args string) error
+}
+
+var _ SystemAspect = &hugepagesAspect{}
+
+func NewHugepagesAspect(cfg *api.NodeConfig, logger *zap.Logger) SystemAspect {
+	return &hugepagesAspect{
+		nodeConfig:             cfg,
+		logger:                 logger,
+		writeFile:              os.WriteFile,
+		configureKernelCmdline: configureKernelCmdline,
+		trackKernelArgs:        tracker.AddKernelArgs,
+	}
+}
+
+func (h *hugepagesAspect) Name() string {
+	return hugepagesAspectName
+}
+
+// Setup allocates the hugepages of the node config. 2Mi pages, the default size,
+// are set by the sysctl aspect through vm.nr_hugepages, 1Gi pages are allocated
+// through sysfs. 1Gi pages that can't be allocated at runtime, because memory is
+// too fragmented, are configured on the kernel command line for the next boot.
+func (h *hugepagesAspect) Setup() error {
+	hugepages := configuredHugepages(h.nodeConfig)
+	if len(hugepages) == 0 {
+		return nil
+	}
+	meminfo, err := os.ReadFile(meminfoPath)
+	if err != nil {
+		return err
+	}
+	defaultSizeKB, err := parseMeminfoValue(meminfo, hugepageSizeRegexp)
+	if err != nil {
+		return fmt.Errorf("reading default hugepage size: %w", err)
+	}
+
+	var bootArgs []string
+	for _, hp := range hugepages {
+		sizeKB := hugepageSizesKB[hp.Size]
+		nrHugepagesPath := filepath.Join(hugepagesDir, fmt.Sprintf("hugepages-%dkB", sizeKB), "nr_hugepages")
+		if _, err := os.Stat(nrHugepagesPath); err != nil {
+			return fmt.Errorf("%s hugepages are not supported by the kernel: %w", hp.Size, err)
+		}
+		if hp.Size == api.HugepageSize2Mi && sizeKB != defaultSizeKB {
+			return fmt.Errorf("2Mi hugepages require 2Mi to be the default hugepage size, found %dkB", defaultSizeKB)
+		} else if hp.Size != api.HugepageSize2Mi {
+			if err := h.writeFile(nrHugepagesPath, []byte(strconv.Itoa(int(hp.Count))), 0o644); err != nil {
+				return fmt.Errorf("allocating %s hugepages: %w", hp.Size, err)
+			}
+		}
+
+		allocated, err := readUint(nrHugepagesPath)
+		if err != nil {
+			return err
+		}
+		h.logger.Info("Allocated hugepages", zap.String("size", string(hp.Size)), zap.Uint64("allocated", allocated), zap.Int32("requested", hp.Count))
+		if allocated == uint64(hp.Count) {
+			continue
+		}
+		if hp.Size != api.HugepageSize1Gi {
+			return fmt.Errorf("only %d of %d %s hugepages could be allocated, free memory on the node or request fewer pages", allocated, hp.Count, hp.Size)
+		}
+		bootArgs = append(bootArgs, "hugepagesz=1G", fmt.Sprintf("hugepages=%d", hp.Count))
+	}
+
+	if err := verifyMeminfoHugepages(hugepages); err != nil {
+		return err
+	}
+
+	if len(bootArgs) == 0 {
+		return nil
+	}
+	args := strings.Join(bootArgs, " ")
+	cmdline, err := os.ReadFile(kernelCmdlinePath)
+	if err != nil {
+		return err
+	}
+	if strings.Contains(" "+strings.TrimSpace(string(cmdline))+" ", " "+args+" ") {
+		return fmt.Errorf("1Gi hugepages are configured on the kernel command line with %q but the kernel couldn't allocate them, request fewer pages", args)
+	}
+	if err := h.configureKernelCmdline(args); err != nil {
+		return err
+	}
+	if err := h.trackKernelArgs(args); err != nil {
+		return fmt.Errorf("tracking kernel command line args: %w", err)
+	}
+	h.logger.Warn("1Gi hugepages must be allocated at boot. Added them to the kernel command line, reboot the node to allocate them. nodeadm uninstall removes them", zap.String("args", args))
+	return nil
+}
+
+// verifyMeminfoHugepages checks /proc/meminfo reports the requested 2Mi pages,
+// which are the default hugepage size it reports on.
+func verifyMeminfoHugepages(hugepages []api.Hugepages) error {
+	for _, hp := range hugepages {
+		if hp.Size != api.HugepageSize2Mi {
+			continue
+		}
+		meminfo, err := os.ReadFile(meminfoPath)
+		if err != nil {
+			return err
+		}
+		total, err := parseMeminfoValue(meminfo, hugepagesTotalRegexp)
+		if err != nil {
+			return fmt.Errorf("reading total hugepages: %w", err)
+		}
+		if total != uint64(hp.Count) {
+			return fmt.Errorf("%s reports %d %s hugepages, expected %d", meminfoPath, total, hp.Size, hp.Count)
+		}
+	}
+	return nil
+}
+
+// configuredHugepages returns the hugepages of the node config.
+func configuredHugepages(cfg *api.NodeConfig) []api.Hugepages {
+	if !cfg.IsHybridNode() {
+		return nil
+	}
+	return cfg.Spec.Hybrid.Hugepages
+}
+
+// HugepagesMemory returns the memory in bytes taken by the hugepages of the node config.
+func HugepagesMemory(cfg *api.NodeConfig) uint64 {
+	var total uint64
+	for _, hp := range configuredHugepages(cfg) {
+		total += hugepageSizesKB[hp.Size] * 1024 * uint64(hp.Count)
+	}
+	return total
+}
+
+// hugepagesSysctlConfig returns the sysctl settings for the hugepages of the node config.
+func hugepagesSysctlConfig(cfg *api.NodeConfig) string {
+	for _, hp := range configuredHugepages(cfg) {
+		if hp.Size == api.HugepageSize2Mi {
+			return fmt.Sprintf("vm.nr_hugepages=%d\n", hp.Count)
+		}
+	}
+	return ""
+}
+
+// configureKernelCmdline adds args to the kernel command line of every boot entry,
+// with grubby on RHEL based distributions and a GRUB drop-in on Ubuntu.
+func configureKernelCmdline(args string) error {
+	if _, err := exec.LookPath("grubby"); err == nil {
+		out, err := exec.Command("grubby", "--update-kernel=ALL", "--args="+args).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("running grubby: %s, error: %v", out, err)
+		}
+		return nil
+	}
+	if _, err := os.Stat(grubDropInDir); err == nil {
+		dropIn := fmt.Sprintf("GRUB_CMDLINE_LINUX_DEFAULT=\"$GRUB_CMDLINE_LINUX_DEFAULT %s\"\n", args)
+		if err := util.WriteFileWithDir(filepath.Join(grubDropInDir, grubDropInFile), []byte(dropIn), grubDropInPerm); err != nil {
+			return err
+		}
+		out, err := exec.Command("update-grub").CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("running update-grub: %s, error: %v", out, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("can't configure the kernel command line on this OS, add %q to it and reboot", args)
+}
+
+// RemoveKernelCmdlineArgs removes args added by configureKernelCmdline from the kernel
+// command line of every boot entry. The change applies on the next boot.
+func RemoveKernelCmdlineArgs(args string) error {
+	if _, err := exec.LookPath("grubby"); err == nil {
+		out, err := exec.Command("grubby", "--update-kernel=ALL", "--remove-args="+args).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("running grubby: %s, error: %v", out, err)
+		}
+		return nil
+	}
+	dropIn := filepath.Join(grubDropInDir, grubDropInFile)
+	if _, err := os.Stat(dropIn); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := os.Remove(dropIn); err != nil {
+		return err
+	}
+	out, err := exec.Command("update-grub").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("running update-grub: %s, error: %v", out, err)
+	}
+	return nil
+}
+
+func parseMeminfoValue(meminfo []byte, r *regexp.Regexp) (uint64, error) {
+	matches := r.FindSubmatch(meminfo)
+	if len(matches) != 2 {
+		return 0, fmt.Errorf("failed to match regexp %s in %s", r, meminfoPath)
+	}
+	return strconv.ParseUint(string(matches[1]), 10, 64)
+}
+
+func readUint(path string) (uint64, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
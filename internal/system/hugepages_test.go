@@ -0,0 +1,147 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/render"
+)
+
+// setFakeHugepages creates a fake sysfs hugepages tree and /proc files, with
+// the given pages already allocated for each size.
+func setFakeHugepages(t *testing.T, allocated2Mi, allocated1Gi int, cmdline string) {
+	root := t.TempDir()
+	oldHugepagesDir, oldMeminfoPath, oldKernelCmdlinePath := hugepagesDir, meminfoPath, kernelCmdlinePath
+	t.Cleanup(func() {
+		hugepagesDir, meminfoPath, kernelCmdlinePath = oldHugepagesDir, oldMeminfoPath, oldKernelCmdlinePath
+	})
+	hugepagesDir = filepath.Join(root, "hugepages")
+	meminfoPath = filepath.Join(root, "meminfo")
+	kernelCmdlinePath = filepath.Join(root, "cmdline")
+
+	for dir, count := range map[string]int{"hugepages-2048kB": allocated2Mi, "hugepages-1048576kB": allocated1Gi} {
+		require.NoError(t, os.MkdirAll(filepath.Join(hugepagesDir, dir), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(hugepagesDir, dir, "nr_hugepages"), []byte(fmt.Sprintf("%d\n", count)), 0o644))
+	}
+	meminfo := fmt.Sprintf("MemTotal:       32000000 kB\nHugePages_Total:    %d\nHugepagesize:       2048 kB\n", allocated2Mi)
+	require.NoError(t, os.WriteFile(meminfoPath, []byte(meminfo), 0o644))
+	require.NoError(t, os.WriteFile(kernelCmdlinePath, []byte(cmdline+"\n"), 0o644))
+}
+
+func hugepagesNodeConfig(hugepages ...api.Hugepages) *api.NodeConfig {
+	return &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Hybrid: &api.HybridOptions{
+				Hugepages: hugepages,
+			},
+		},
+	}
+}
+
+func TestHugepagesAspectSetup(t *testing.T) {
+	// 2Mi pages were allocated by the sysctl aspect
+	setFakeHugepages(t, 512, 0, "BOOT_IMAGE=/vmlinuz root=/dev/sda1")
+	var cmdlineArgs string
+	aspect := &hugepagesAspect{
+		nodeConfig: hugepagesNodeConfig(
+			api.Hugepages{Size: api.HugepageSize2Mi, Count: 512},
+			api.Hugepages{Size: api.HugepageSize1Gi, Count: 4},
+		),
+		logger:    zap.NewNop(),
+		writeFile: os.WriteFile,
+		configureKernelCmdline: func(args string) error {
+			cmdlineArgs = args
+			return nil
+		},
+	}
+
+	require.NoError(t, aspect.Setup())
+	data, err := os.ReadFile(filepath.Join(hugepagesDir, "hugepages-1048576kB", "nr_hugepages"))
+	require.NoError(t, err)
+	assert.Equal(t, "4", string(data))
+	assert.Empty(t, cmdlineArgs)
+}
+
+func TestHugepagesAspectSetupConfiguresKernelCmdline(t *testing.T) {
+	setFakeHugepages(t, 0, 0, "BOOT_IMAGE=/vmlinuz root=/dev/sda1")
+	var cmdlineArgs, trackedArgs string
+	aspect := &hugepagesAspect{
+		nodeConfig: hugepagesNodeConfig(api.Hugepages{Size: api.HugepageSize1Gi, Count: 4}),
+		logger:     zap.NewNop(),
+		writeFile:  partialAllocation(1),
+		configureKernelCmdline: func(args string) error {
+			cmdlineArgs = args
+			return nil
+		},
+		trackKernelArgs: func(args string) error {
+			trackedArgs = args
+			return nil
+		},
+	}
+
+	require.NoError(t, aspect.Setup())
+	assert.Equal(t, "hugepagesz=1G hugepages=4", cmdlineArgs)
+	assert.Equal(t, cmdlineArgs, trackedArgs)
+}
+
+func TestHugepagesAspectSetupAlreadyOnKernelCmdline(t *testing.T) {
+	setFakeHugepages(t, 0, 0, "BOOT_IMAGE=/vmlinuz hugepagesz=1G hugepages=4")
+	aspect := &hugepagesAspect{
+		nodeConfig: hugepagesNodeConfig(api.Hugepages{Size: api.HugepageSize1Gi, Count: 4}),
+		logger:     zap.NewNop(),
+		configureKernelCmdline: func(args string) error {
+			return fmt.Errorf("unexpected call")
+		},
+		writeFile: partialAllocation(2),
+	}
+
+	err := aspect.Setup()
+	assert.EqualError(t, err, `1Gi hugepages are configured on the kernel command line with "hugepagesz=1G hugepages=4" but the kernel couldn't allocate them, request fewer pages`)
+}
+
+func TestHugepagesAspectSetup2MiNotAllocated(t *testing.T) {
+	setFakeHugepages(t, 100, 0, "")
+	aspect := &hugepagesAspect{
+		nodeConfig: hugepagesNodeConfig(api.Hugepages{Size: api.HugepageSize2Mi, Count: 512}),
+		logger:     zap.NewNop(),
+	}
+
+	err := aspect.Setup()
+	assert.EqualError(t, err, "only 100 of 512 2Mi hugepages could be allocated, free memory on the node or request fewer pages")
+}
+
+func TestHugepagesMemory(t *testing.T) {
+	cfg := hugepagesNodeConfig(
+		api.Hugepages{Size: api.HugepageSize2Mi, Count: 512},
+		api.Hugepages{Size: api.HugepageSize1Gi, Count: 2},
+	)
+	assert.Equal(t, uint64(3*1024*1024*1024), HugepagesMemory(cfg))
+	assert.Equal(t, uint64(0), HugepagesMemory(&api.NodeConfig{}))
+}
+
+func TestSysctlRenderWithHugepages(t *testing.T) {
+	aspect := &sysctlAspect{nodeConfig: hugepagesNodeConfig(api.Hugepages{Size: api.HugepageSize2Mi, Count: 512})}
+	files, err := aspect.Render()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, render.File{
+		Path:    "/etc/sysctl.d/99-nodeadm.conf",
+		Content: []byte("vm.overcommit_memory=1\nkernel.panic=10\nkernel.panic_on_oops=1\nnet.ipv4.ip_forward=1\nvm.nr_hugepages=512\n"),
+		Perm:    0o644,
+	}, files[0])
+}
+
+// partialAllocation returns a writeFile that simulates the kernel allocating
+// only allocated pages, whatever the count written.
+func partialAllocation(allocated int) func(string, []byte, os.FileMode) error {
+	return func(name string, _ []byte, perm os.FileMode) error {
+		return os.WriteFile(name, []byte(fmt.Sprintf("%d\n", allocated)), perm)
+	}
+}
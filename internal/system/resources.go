@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -81,6 +83,75 @@ func GetMilliNumCores() (int, error) {
 	return allLogicalCoresCount * 1000, err
 }
 
+// CPUCore is a physical cpu core and the ids of its online logical cpus.
+type CPUCore struct {
+	ID       int
+	SocketID int
+	CPUs     []int
+}
+
+// NUMANode is a NUMA node and its physical cpu cores.
+type NUMANode struct {
+	ID    int
+	Cores []CPUCore
+}
+
+// GetNUMANodes returns the NUMA nodes of the machine ordered by id, with their
+// cores ordered by socket and core id. Machines that don't report a NUMA
+// topology are returned as a single node 0 with every cpu.
+func GetNUMANodes() ([]NUMANode, error) {
+	nodesDirs, err := getNodesPaths()
+	if err != nil {
+		return nil, err
+	}
+	if len(nodesDirs) == 0 {
+		cpuDirs, err := getCPUsPaths(cpusPath)
+		if err != nil {
+			return nil, err
+		}
+		cores, err := getCoresInfo(cpuDirs)
+		if err != nil {
+			return nil, err
+		}
+		return []NUMANode{newNUMANode(0, cores)}, nil
+	}
+
+	var nodes []NUMANode
+	for _, dir := range nodesDirs {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "node"))
+		if err != nil {
+			return nil, fmt.Errorf("unexpected format of NUMA node directory: %s", dir)
+		}
+		cpuDirs, err := getCPUsPaths(dir)
+		if err != nil {
+			return nil, err
+		}
+		cores, err := getCoresInfo(cpuDirs)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, newNUMANode(id, cores))
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes, nil
+}
+
+func newNUMANode(id int, cores []core) NUMANode {
+	node := NUMANode{ID: id}
+	for _, c := range cores {
+		cpus := slices.Clone(c.Threads)
+		slices.Sort(cpus)
+		node.Cores = append(node.Cores, CPUCore{ID: c.Id, SocketID: c.SocketID, CPUs: cpus})
+	}
+	sort.Slice(node.Cores, func(i, j int) bool {
+		if node.Cores[i].SocketID != node.Cores[j].SocketID {
+			return node.Cores[i].SocketID < node.Cores[j].SocketID
+		}
+		return node.Cores[i].ID < node.Cores[j].ID
+	})
+	return node
+}
+
 func getCPUCount() (int, error) {
 	cpusPaths, err := getCPUsPaths(cpusPath)
 	if err != nil {
@@ -274,7 +345,7 @@ func isCpuOnline(path string, cpuID uint16) (bool, error) {
 // GetMachineMemoryCapacity returns the machine's total memory from /proc/meminfo.
 // Returns the total memory capacity as number of bytes.
 func GetMachineMemoryCapacity() (uint64, error) {
-	out, err := os.ReadFile("/proc/meminfo")
+	out, err := os.ReadFile(meminfoPath)
 	if err != nil {
 		return 0, err
 	}
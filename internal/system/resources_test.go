@@ -0,0 +1,84 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFakeCPU creates the sysfs topology files of a cpu under dir.
+func writeFakeCPU(t *testing.T, dir string, cpuID, coreID, packageID int) {
+	topology := filepath.Join(dir, fmt.Sprintf("cpu%d", cpuID), "topology")
+	require.NoError(t, os.MkdirAll(topology, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(topology, "core_id"), []byte(fmt.Sprintf("%d\n", coreID)), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(topology, "physical_package_id"), []byte(fmt.Sprintf("%d\n", packageID)), 0o644))
+}
+
+func setFakeSysfs(t *testing.T) (string, string) {
+	root := t.TempDir()
+	oldNodeDir, oldCPUsPath := nodeDir, cpusPath
+	t.Cleanup(func() {
+		nodeDir, cpusPath = oldNodeDir, oldCPUsPath
+	})
+	nodeDir = filepath.Join(root, "node")
+	cpusPath = filepath.Join(root, "cpu")
+	require.NoError(t, os.MkdirAll(nodeDir, 0o755))
+	require.NoError(t, os.MkdirAll(cpusPath, 0o755))
+	return nodeDir, cpusPath
+}
+
+func TestGetNUMANodes(t *testing.T) {
+	nodes, cpus := setFakeSysfs(t)
+	// two nodes with two hyperthreaded cores each, siblings numbered like on most x86 machines
+	writeFakeCPU(t, filepath.Join(nodes, "node0"), 0, 0, 0)
+	writeFakeCPU(t, filepath.Join(nodes, "node0"), 1, 1, 0)
+	writeFakeCPU(t, filepath.Join(nodes, "node0"), 4, 0, 0)
+	writeFakeCPU(t, filepath.Join(nodes, "node0"), 5, 1, 0)
+	writeFakeCPU(t, filepath.Join(nodes, "node1"), 2, 0, 1)
+	writeFakeCPU(t, filepath.Join(nodes, "node1"), 3, 1, 1)
+	writeFakeCPU(t, filepath.Join(nodes, "node1"), 6, 0, 1)
+	writeFakeCPU(t, filepath.Join(nodes, "node1"), 7, 1, 1)
+	// cpu 7 is offline
+	require.NoError(t, os.WriteFile(filepath.Join(cpus, "online"), []byte("0-6\n"), 0o644))
+
+	got, err := GetNUMANodes()
+	require.NoError(t, err)
+	assert.Equal(t, []NUMANode{
+		{
+			ID: 0,
+			Cores: []CPUCore{
+				{ID: 0, SocketID: 0, CPUs: []int{0, 4}},
+				{ID: 1, SocketID: 0, CPUs: []int{1, 5}},
+			},
+		},
+		{
+			ID: 1,
+			Cores: []CPUCore{
+				{ID: 0, SocketID: 1, CPUs: []int{2, 6}},
+				{ID: 1, SocketID: 1, CPUs: []int{3}},
+			},
+		},
+	}, got)
+}
+
+func TestGetNUMANodesWithoutNUMATopology(t *testing.T) {
+	_, cpus := setFakeSysfs(t)
+	writeFakeCPU(t, cpus, 0, 0, 0)
+	writeFakeCPU(t, cpus, 1, 1, 0)
+
+	got, err := GetNUMANodes()
+	require.NoError(t, err)
+	assert.Equal(t, []NUMANode{
+		{
+			ID: 0,
+			Cores: []CPUCore{
+				{ID: 0, SocketID: 0, CPUs: []int{0}},
+				{ID: 1, SocketID: 0, CPUs: []int{1}},
+			},
+		},
+	}, got)
+}
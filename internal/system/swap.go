@@ -21,6 +21,9 @@ const (
 	swapTypeFile      = "file"
 )
 
+// cgroupControllersPath only exists when the unified cgroup v2 hierarchy is mounted.
+var cgroupControllersPath = "/sys/fs/cgroup/cgroup.controllers"
+
 type swapAspect struct {
 	nodeConfig *api.NodeConfig
 	logger     *zap.Logger
@@ -37,6 +40,13 @@ func (s *swapAspect) Name() string {
 }
 
 func (s *swapAspect) Setup() error {
+	if SwapLimited(s.nodeConfig) {
+		if err := verifyCgroupV2(); err != nil {
+			return err
+		}
+		s.logger.Info("Keeping swap enabled, kubelet will limit swap usage of burstable pods")
+		return nil
+	}
 	swapfiles, err := getSwapfilePaths()
 	if err != nil {
 		return err
@@ -55,6 +65,22 @@ func (s *swapAspect) Setup() error {
 	return disableSwapOnFstab()
 }
 
+// SwapLimited returns true if the node config keeps swap enabled with limited swap for pods.
+func SwapLimited(cfg *api.NodeConfig) bool {
+	return cfg.IsHybridNode() && cfg.Spec.Hybrid.Swap == api.SwapModeLimited
+}
+
+// verifyCgroupV2 checks the host uses cgroup v2, which kubelet requires to limit
+// the swap usage of pods.
+func verifyCgroupV2() error {
+	if _, err := os.Stat(cgroupControllersPath); errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("limited swap requires cgroup v2, but the host doesn't use the unified cgroup hierarchy")
+	} else if err != nil {
+		return fmt.Errorf("checking cgroup version: %w", err)
+	}
+	return nil
+}
+
 // Check if there are swaps of type partition exist on host because currently
 // nodeadm can only disable file type swap, if it's partition type, nodeadm
 // can only temporarily disable the swap, and swap will come back after host reboot.
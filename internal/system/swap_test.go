@@ -8,6 +8,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/api"
 )
 
 func TestGetSwapfilePathsFromFile(t *testing.T) {
@@ -269,3 +272,19 @@ func TestPartitionSwapExistsLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestSwapAspectSetupLimited(t *testing.T) {
+	root := t.TempDir()
+	oldCgroupControllersPath := cgroupControllersPath
+	t.Cleanup(func() { cgroupControllersPath = oldCgroupControllersPath })
+	cgroupControllersPath = filepath.Join(root, "cgroup.controllers")
+	aspect := &swapAspect{
+		nodeConfig: &api.NodeConfig{Spec: api.NodeConfigSpec{Hybrid: &api.HybridOptions{Swap: api.SwapModeLimited}}},
+		logger:     zap.NewNop(),
+	}
+
+	assert.EqualError(t, aspect.Setup(), "limited swap requires cgroup v2, but the host doesn't use the unified cgroup hierarchy")
+
+	require.NoError(t, os.WriteFile(cgroupControllersPath, []byte("cpuset cpu io memory pids\n"), 0o644))
+	assert.NoError(t, aspect.Setup())
+}
@@ -17,13 +17,22 @@ func NewSwapValidator() *SwapValidator {
 }
 
 // Run validates the swap configuration
-func (v *SwapValidator) Run(ctx context.Context, informer validation.Informer, _ *api.NodeConfig) error {
+func (v *SwapValidator) Run(ctx context.Context, informer validation.Informer, nodeConfig *api.NodeConfig) error {
 	var err error
 	informer.Starting(ctx, "swap", "Validating swap configuration")
 	defer func() {
 		informer.Done(ctx, "swap", err)
 	}()
 
+	// Swap is expected to stay active in limited mode, kubelet only needs cgroup v2
+	if SwapLimited(nodeConfig) {
+		if err = verifyCgroupV2(); err != nil {
+			err = validation.WithRemediation(err,
+				"Boot the host with the unified cgroup hierarchy (systemd.unified_cgroup_hierarchy=1) or set hybrid.swap to disable.")
+		}
+		return err
+	}
+
 	swapfiles, err := getSwapfilePaths()
 	if err != nil {
 		err = fmt.Errorf("getting swapfile paths : %w", err)
@@ -2,6 +2,8 @@ package system
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -72,6 +74,21 @@ func TestSwapValidator_Run(t *testing.T) {
 	}
 }
 
+func TestSwapValidator_RunLimited(t *testing.T) {
+	oldCgroupControllersPath := cgroupControllersPath
+	t.Cleanup(func() { cgroupControllersPath = oldCgroupControllersPath })
+	cgroupControllersPath = filepath.Join(t.TempDir(), "cgroup.controllers")
+	nodeConfig := &api.NodeConfig{Spec: api.NodeConfigSpec{Hybrid: &api.HybridOptions{Swap: api.SwapModeLimited}}}
+	informer := &mockInformer{}
+
+	err := NewSwapValidator().Run(context.Background(), informer, nodeConfig)
+	assert.ErrorContains(t, err, "limited swap requires cgroup v2")
+	assert.Equal(t, err, informer.lastError)
+
+	assert.NoError(t, os.WriteFile(cgroupControllersPath, []byte("cpuset cpu io memory pids\n"), 0o644))
+	assert.NoError(t, NewSwapValidator().Run(context.Background(), informer, nodeConfig))
+}
+
 func TestNewSwapValidator(t *testing.T) {
 	validator := NewSwapValidator()
 
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os/exec"
 	"path"
+	"strings"
 
 	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/render"
 	"github.com/aws/eks-hybrid/internal/util"
 )
 
@@ -27,7 +29,10 @@ type sysctlAspect struct {
 	nodeConfig *api.NodeConfig
 }
 
-var _ SystemAspect = &sysctlAspect{}
+var (
+	_ SystemAspect   = &sysctlAspect{}
+	_ AspectRenderer = &sysctlAspect{}
+)
 
 func NewSysctlAspect(cfg *api.NodeConfig) SystemAspect {
 	return &sysctlAspect{nodeConfig: cfg}
@@ -38,14 +43,27 @@ func (s *sysctlAspect) Name() string {
 }
 
 func (s *sysctlAspect) Setup() error {
-	if err := writeSysctlConfig(); err != nil {
+	if err := writeSysctlConfig(s.nodeConfig, util.WriteFileWithDir); err != nil {
 		return err
 	}
 	return reloadSysctl()
 }
 
-func writeSysctlConfig() error {
-	return util.WriteFileWithDir(nodeadmSysctlConfPath, []byte(sysctlConfFileData), nodeadmSysctlFilePerm)
+// Render returns the sysctl drop-in file written by Setup.
+func (s *sysctlAspect) Render() ([]render.File, error) {
+	recorder := &render.Recorder{}
+	if err := writeSysctlConfig(s.nodeConfig, recorder.WriteFile); err != nil {
+		return nil, err
+	}
+	return recorder.Files(), nil
+}
+
+func writeSysctlConfig(cfg *api.NodeConfig, writeFile render.WriteFileFunc) error {
+	data := sysctlConfFileData
+	if hugepages := hugepagesSysctlConfig(cfg); hugepages != "" {
+		data = strings.TrimSuffix(data, "\n") + "\n" + hugepages
+	}
+	return writeFile(nodeadmSysctlConfPath, []byte(data), nodeadmSysctlFilePerm)
 }
 
 func reloadSysctl() error {
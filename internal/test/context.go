@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	"github.com/aws/eks-hybrid/internal/util"
 )
 
 // ContextWithTimeout returns a context with a timeout that is cancelled when the test ends.
@@ -12,3 +14,9 @@ func ContextWithTimeout(tb testing.TB, timeout time.Duration) context.Context {
 	tb.Cleanup(cancel)
 	return ctx
 }
+
+// ContextWithFastRetries returns a context that retries failed http requests without
+// waiting, for tests of server errors.
+func ContextWithFastRetries() context.Context {
+	return util.WithDownloadOptions(context.Background(), util.DownloadOptions{RetryBackoff: time.Millisecond})
+}
@@ -13,6 +13,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/signature"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
 
@@ -86,6 +87,10 @@ func RunInstallTest(t *testing.T, td TestData) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewGomegaWithT(t)
+			key := GenerateSigningKey(g)
+			verifier, err := signature.NewVerifier(key.PublicKey)
+			g.Expect(err).NotTo(HaveOccurred())
+			checksumSignature := key.Sign(g, []byte(tt.checksum))
 
 			server := NewHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
 				if tt.statusCode != http.StatusOK {
@@ -119,33 +124,44 @@ func RunInstallTest(t *testing.T, td TestData) {
 						return
 					}
 				}
+
+				if r.URL.Path == checksumPath+aws.SignatureSuffix {
+					if _, err := w.Write(checksumSignature); err != nil {
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+				}
 			})
 
 			var source aws.Source
 			if td.ArtifactName == "aws_signing_helper" {
 				source = aws.Source{
+					Verifier: verifier,
 					Iam: aws.IamRolesAnywhereRelease{
 						Artifacts: []aws.Artifact{
 							{
-								Arch:        runtime.GOARCH,
-								OS:          runtime.GOOS,
-								Name:        td.ArtifactName,
-								URI:         server.URL + "/latest/linux_amd64/" + td.BinaryName,
-								ChecksumURI: server.URL + "/latest/linux_amd64/" + td.BinaryName + ".sha256",
+								Arch:         runtime.GOARCH,
+								OS:           runtime.GOOS,
+								Name:         td.ArtifactName,
+								URI:          server.URL + "/latest/linux_amd64/" + td.BinaryName,
+								ChecksumURI:  server.URL + "/latest/linux_amd64/" + td.BinaryName + ".sha256",
+								SignatureURI: server.URL + "/latest/linux_amd64/" + td.BinaryName + ".sha256.sig",
 							},
 						},
 					},
 				}
 			} else {
 				source = aws.Source{
+					Verifier: verifier,
 					Eks: aws.EksPatchRelease{
 						Artifacts: []aws.Artifact{
 							{
-								Arch:        runtime.GOARCH,
-								OS:          runtime.GOOS,
-								Name:        td.ArtifactName,
-								URI:         server.URL + "/latest/linux_amd64/" + td.BinaryName,
-								ChecksumURI: server.URL + "/latest/linux_amd64/" + td.BinaryName + ".sha256",
+								Arch:         runtime.GOARCH,
+								OS:           runtime.GOOS,
+								Name:         td.ArtifactName,
+								URI:          server.URL + "/latest/linux_amd64/" + td.BinaryName,
+								ChecksumURI:  server.URL + "/latest/linux_amd64/" + td.BinaryName + ".sha256",
+								SignatureURI: server.URL + "/latest/linux_amd64/" + td.BinaryName + ".sha256.sig",
 							},
 						},
 					},
@@ -158,7 +174,7 @@ func RunInstallTest(t *testing.T, td TestData) {
 			g.Expect(err).NotTo(HaveOccurred())
 			defer os.RemoveAll(f)
 
-			err = td.Install(context.Background(), f, source, tr)
+			err = td.Install(ContextWithFastRetries(), f, source, tr)
 
 			if tt.wantErr != "" {
 				g.Expect(err).To(HaveOccurred())
@@ -0,0 +1,37 @@
+package test
+
+import (
+	"github.com/ProtonMail/gopenpgp/v3/crypto"
+	. "github.com/onsi/gomega"
+)
+
+// SigningKey is a PGP key to sign test artifacts with.
+type SigningKey struct {
+	key *crypto.Key
+	// PublicKey is the armored public key to verify the signatures with.
+	PublicKey string
+}
+
+// GenerateSigningKey creates a new PGP signing key
+func GenerateSigningKey(g *WithT) SigningKey {
+	key, err := crypto.PGP().KeyGeneration().
+		AddUserId("test", "test@example.com").
+		New().
+		GenerateKey()
+	g.Expect(err).NotTo(HaveOccurred())
+	publicKey, err := key.GetArmoredPublicKey()
+	g.Expect(err).NotTo(HaveOccurred())
+	return SigningKey{key: key, PublicKey: publicKey}
+}
+
+// Sign returns the armored detached signature of data
+func (k SigningKey) Sign(g *WithT, data []byte) []byte {
+	signer, err := crypto.PGP().Sign().
+		SigningKey(k.key).
+		Detached().
+		New()
+	g.Expect(err).NotTo(HaveOccurred())
+	signature, err := signer.Sign(data, crypto.Armor)
+	g.Expect(err).NotTo(HaveOccurred())
+	return signature
+}
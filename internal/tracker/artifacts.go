@@ -1,14 +1,20 @@
 package tracker
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path"
+	"path/filepath"
+	"time"
 
 	"github.com/pkg/errors"
 	"sigs.k8s.io/yaml"
 
+	"github.com/aws/eks-hybrid/cmd/nodeadm/version"
 	"github.com/aws/eks-hybrid/internal/artifact"
 	"github.com/aws/eks-hybrid/internal/util"
 )
@@ -21,10 +27,26 @@ const (
 	ContainerdSourceDocker ContainerdSourceName = "docker"
 )
 
-const trackerFile = "/opt/nodeadm/tracker"
+const (
+	trackerFile = "/opt/nodeadm/tracker"
+
+	// SchemaVersion is the version of the tracker files written by this nodeadm. v1 files,
+	// without a version, only recorded which artifacts were installed.
+	SchemaVersion = "v2"
+)
+
+// now is replaced in tests.
+var now = time.Now
 
 type Tracker struct {
+	Version   string `json:",omitempty"`
 	Artifacts *InstalledArtifacts
+	// Components records how each installed component was installed, keyed by the
+	// artifact name.
+	Components map[string]*Component `json:",omitempty"`
+	// KernelArgs were added to the kernel command line by nodeadm, and are removed on
+	// uninstall.
+	KernelArgs string `json:",omitempty"`
 }
 
 type InstalledArtifacts struct {
@@ -39,8 +61,157 @@ type InstalledArtifacts struct {
 	Iptables                bool
 }
 
-// Add adds a components as installed to the tracker
-func (tracker *Tracker) Add(componentName string) error {
+// Component is an installed component.
+type Component struct {
+	// Version is the version of the release the component was installed from.
+	Version string `json:",omitempty"`
+	// SourceURI is where the component was downloaded from.
+	SourceURI string `json:",omitempty"`
+	// Path is where the component is installed, used to upgrade and uninstall it.
+	Path string `json:",omitempty"`
+	// Files are the files installed for the component.
+	Files       []InstalledFile `json:",omitempty"`
+	InstalledAt time.Time
+	UpgradedAt  *time.Time `json:",omitempty"`
+	// NodeadmVersion is the version of nodeadm that last installed or upgraded the component.
+	NodeadmVersion string `json:",omitempty"`
+}
+
+// InstalledFile is a file installed for a component.
+type InstalledFile struct {
+	Path   string
+	SHA256 string
+}
+
+// Add adds a components as installed to the tracker. paths are the files or directories
+// installed for it, the first one being its install path.
+func (tracker *Tracker) Add(componentName string, paths ...string) error {
+	if err := tracker.markInstalled(componentName); err != nil {
+		return err
+	}
+	component := &Component{
+		InstalledAt:    now().UTC(),
+		NodeadmVersion: version.GitVersion,
+	}
+	if len(paths) > 0 {
+		component.Path = paths[0]
+	}
+	files, err := checksumFiles(paths)
+	if err != nil {
+		return err
+	}
+	component.Files = files
+	if tracker.Components == nil {
+		tracker.Components = map[string]*Component{}
+	}
+	tracker.Components[componentName] = component
+	return nil
+}
+
+// SetSource records the release version and URI a component was installed from.
+func (tracker *Tracker) SetSource(componentName, releaseVersion, uri string) {
+	if component, ok := tracker.Components[componentName]; ok {
+		component.Version = releaseVersion
+		component.SourceURI = uri
+	}
+}
+
+// Upgraded records a component was upgraded to releaseVersion from uri, either of which
+// can be empty when it's not known. paths replace the install path and files recorded
+// for the component when set, and the checksums of its files are updated.
+func (tracker *Tracker) Upgraded(componentName, releaseVersion, uri string, paths ...string) error {
+	if err := tracker.markInstalled(componentName); err != nil {
+		return err
+	}
+	component, ok := tracker.Components[componentName]
+	if !ok {
+		component = &Component{}
+		if tracker.Components == nil {
+			tracker.Components = map[string]*Component{}
+		}
+		tracker.Components[componentName] = component
+	}
+	if len(paths) > 0 {
+		component.Path = paths[0]
+	} else {
+		for _, file := range component.Files {
+			paths = append(paths, file.Path)
+		}
+	}
+	files, err := checksumFiles(paths)
+	if err != nil {
+		return err
+	}
+	upgradedAt := now().UTC()
+	component.Files = files
+	component.UpgradedAt = &upgradedAt
+	component.NodeadmVersion = version.GitVersion
+	if releaseVersion != "" {
+		component.Version = releaseVersion
+	}
+	if uri != "" {
+		component.SourceURI = uri
+	}
+	return nil
+}
+
+// InstallPath returns where a component is installed, or defaultPath when it wasn't
+// recorded, like for the components tracked before v2.
+func (tracker *Tracker) InstallPath(componentName, defaultPath string) string {
+	if component, ok := tracker.Components[componentName]; ok && component.Path != "" {
+		return component.Path
+	}
+	return defaultPath
+}
+
+// AddKernelArgs records nodeadm added args to the kernel command line, to remove them
+// on uninstall.
+func AddKernelArgs(args string) error {
+	tracker, err := GetCurrentState()
+	if err != nil {
+		return err
+	}
+	tracker.KernelArgs = args
+	return tracker.Save()
+}
+
+// checksumFiles returns the sha256 of the files in paths, and of the files under the
+// directories in paths.
+func checksumFiles(paths []string) ([]InstalledFile, error) {
+	var files []InstalledFile
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || !entry.Type().IsRegular() {
+				return err
+			}
+			checksum, err := checksumFile(path)
+			if err != nil {
+				return err
+			}
+			files = append(files, InstalledFile{Path: path, SHA256: checksum})
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "calculating checksums of installed files")
+		}
+	}
+	return files, nil
+}
+
+func checksumFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	digest := sha256.New()
+	if _, err := io.Copy(digest, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+func (tracker *Tracker) markInstalled(componentName string) error {
 	switch componentName {
 	case artifact.CniPlugins:
 		tracker.Artifacts.CniPlugins = true
@@ -58,6 +229,8 @@ func (tracker *Tracker) Add(componentName string) error {
 		tracker.Artifacts.Ssm = true
 	case artifact.Iptables:
 		tracker.Artifacts.Iptables = true
+	case artifact.Containerd:
+		// the containerd source is set when it's installed
 	default:
 		return fmt.Errorf("invalid artifact to track")
 	}
@@ -72,6 +245,7 @@ func (tracker *Tracker) Save() error {
 		return err
 	}
 	tracker.Artifacts.Containerd = containerdSource
+	tracker.Version = SchemaVersion
 	data, err := yaml.Marshal(tracker)
 	if err != nil {
 		return err
@@ -85,8 +259,13 @@ func Clear() error {
 }
 
 // GetInstalledArtifacts reads the tracker file and returns the current
-// installed artifacts
+// installed artifacts. v1 tracker files are migrated to the current schema,
+// which is written the next time the tracker is saved.
 func GetInstalledArtifacts() (*Tracker, error) {
+	return readTracker(trackerFile)
+}
+
+func readTracker(trackerFile string) (*Tracker, error) {
 	yamlFileData, err := os.ReadFile(trackerFile)
 	if err != nil {
 		return nil, err
@@ -104,9 +283,43 @@ func GetInstalledArtifacts() (*Tracker, error) {
 	}
 	artifacts.Artifacts.Containerd = containerdSource
 
+	if artifacts.Version == "" {
+		info, err := os.Stat(trackerFile)
+		if err != nil {
+			return nil, err
+		}
+		artifacts.migrateV1(info.ModTime())
+	} else if artifacts.Version != SchemaVersion {
+		return nil, fmt.Errorf("unsupported tracker version %s, upgrade nodeadm to read it", artifacts.Version)
+	}
+
 	return &artifacts, nil
 }
 
+// migrateV1 records the components of a v1 tracker, which only knew what was installed.
+// They are recorded as installed when the tracker was last written, without paths, so
+// upgrade and uninstall use the default ones.
+func (tracker *Tracker) migrateV1(modTime time.Time) {
+	installed := map[string]bool{
+		artifact.CniPlugins:              tracker.Artifacts.CniPlugins,
+		artifact.IamAuthenticator:        tracker.Artifacts.IamAuthenticator,
+		artifact.IamRolesAnywhere:        tracker.Artifacts.IamRolesAnywhere,
+		artifact.ImageCredentialProvider: tracker.Artifacts.ImageCredentialProvider,
+		artifact.Kubectl:                 tracker.Artifacts.Kubectl,
+		artifact.Kubelet:                 tracker.Artifacts.Kubelet,
+		artifact.Ssm:                     tracker.Artifacts.Ssm,
+		artifact.Iptables:                tracker.Artifacts.Iptables,
+		artifact.Containerd:              tracker.Artifacts.Containerd != ContainerdSourceNone,
+	}
+	tracker.Version = SchemaVersion
+	tracker.Components = map[string]*Component{}
+	for name, ok := range installed {
+		if ok {
+			tracker.Components[name] = &Component{InstalledAt: modTime.UTC()}
+		}
+	}
+}
+
 // GetCurrentState reads the tracker file and returns current state
 // If tracker file does not exist, it creates a new tracker
 func GetCurrentState() (*Tracker, error) {
@@ -114,7 +327,9 @@ func GetCurrentState() (*Tracker, error) {
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
 			return &Tracker{
-				Artifacts: &InstalledArtifacts{},
+				Version:    SchemaVersion,
+				Artifacts:  &InstalledArtifacts{},
+				Components: map[string]*Component{},
 			}, nil
 		}
 		return nil, err
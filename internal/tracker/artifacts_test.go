@@ -0,0 +1,180 @@
+package tracker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+func setNow(t *testing.T, at time.Time) {
+	original := now
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = original })
+}
+
+func writeFile(t *testing.T, path, content string) InstalledFile {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	checksum := sha256.Sum256([]byte(content))
+	return InstalledFile{Path: path, SHA256: hex.EncodeToString(checksum[:])}
+}
+
+func TestAddRecordsComponent(t *testing.T) {
+	g := NewWithT(t)
+	installedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	setNow(t, installedAt)
+	dir := t.TempDir()
+	binFile := writeFile(t, filepath.Join(dir, "kubelet"), "kubelet")
+	unitFile := writeFile(t, filepath.Join(dir, "kubelet.service"), "unit")
+
+	tracker := &Tracker{Artifacts: &InstalledArtifacts{}}
+	g.Expect(tracker.Add(artifact.Kubelet, binFile.Path, unitFile.Path)).To(Succeed())
+	tracker.SetSource(artifact.Kubelet, "1.31.0", "https://example.com/kubelet")
+
+	g.Expect(tracker.Artifacts.Kubelet).To(BeTrue())
+	g.Expect(tracker.Components).To(HaveKeyWithValue(artifact.Kubelet, &Component{
+		Version:        "1.31.0",
+		SourceURI:      "https://example.com/kubelet",
+		Path:           binFile.Path,
+		Files:          []InstalledFile{binFile, unitFile},
+		InstalledAt:    installedAt,
+		NodeadmVersion: tracker.Components[artifact.Kubelet].NodeadmVersion,
+	}))
+}
+
+func TestAddChecksumsDirectories(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	bridge := writeFile(t, filepath.Join(dir, "bridge"), "bridge")
+	loopback := writeFile(t, filepath.Join(dir, "loopback"), "loopback")
+
+	tracker := &Tracker{Artifacts: &InstalledArtifacts{}}
+	g.Expect(tracker.Add(artifact.CniPlugins, dir)).To(Succeed())
+
+	component := tracker.Components[artifact.CniPlugins]
+	g.Expect(component.Path).To(Equal(dir))
+	g.Expect(component.Files).To(Equal([]InstalledFile{bridge, loopback}))
+}
+
+func TestUpgraded(t *testing.T) {
+	g := NewWithT(t)
+	installedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	upgradedAt := installedAt.Add(24 * time.Hour)
+	setNow(t, installedAt)
+	binPath := filepath.Join(t.TempDir(), "kubectl")
+	writeFile(t, binPath, "v1")
+
+	tracker := &Tracker{Artifacts: &InstalledArtifacts{}}
+	g.Expect(tracker.Add(artifact.Kubectl, binPath)).To(Succeed())
+	tracker.SetSource(artifact.Kubectl, "1.30.0", "https://example.com/1.30/kubectl")
+
+	setNow(t, upgradedAt)
+	upgraded := writeFile(t, binPath, "v2")
+	g.Expect(tracker.Upgraded(artifact.Kubectl, "1.31.0", "https://example.com/1.31/kubectl")).To(Succeed())
+
+	component := tracker.Components[artifact.Kubectl]
+	g.Expect(component.Version).To(Equal("1.31.0"))
+	g.Expect(component.SourceURI).To(Equal("https://example.com/1.31/kubectl"))
+	g.Expect(component.Path).To(Equal(binPath))
+	g.Expect(component.Files).To(Equal([]InstalledFile{upgraded}))
+	g.Expect(component.InstalledAt).To(Equal(installedAt))
+	g.Expect(component.UpgradedAt).To(Equal(&upgradedAt))
+}
+
+func TestUpgradedUntrackedComponent(t *testing.T) {
+	g := NewWithT(t)
+	binPath := filepath.Join(t.TempDir(), "kubectl")
+	file := writeFile(t, binPath, "kubectl")
+
+	tracker := &Tracker{Artifacts: &InstalledArtifacts{}}
+	g.Expect(tracker.Upgraded(artifact.Kubectl, "1.31.0", "", binPath)).To(Succeed())
+
+	g.Expect(tracker.Artifacts.Kubectl).To(BeTrue())
+	g.Expect(tracker.Components[artifact.Kubectl].Files).To(Equal([]InstalledFile{file}))
+	g.Expect(tracker.Upgraded("unknown", "", "")).To(MatchError("invalid artifact to track"))
+}
+
+func TestInstallPath(t *testing.T) {
+	g := NewWithT(t)
+	tracker := &Tracker{
+		Artifacts: &InstalledArtifacts{Kubectl: true, Kubelet: true},
+		Components: map[string]*Component{
+			artifact.Kubectl: {Path: "/usr/bin/kubectl"},
+			artifact.Kubelet: {},
+		},
+	}
+
+	g.Expect(tracker.InstallPath(artifact.Kubectl, "/usr/local/bin/kubectl")).To(Equal("/usr/bin/kubectl"))
+	g.Expect(tracker.InstallPath(artifact.Kubelet, "/usr/bin/kubelet")).To(Equal("/usr/bin/kubelet"))
+	g.Expect(tracker.InstallPath(artifact.CniPlugins, "/opt/cni/bin")).To(Equal("/opt/cni/bin"))
+}
+
+func TestReadTrackerMigratesV1(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "tracker")
+	g.Expect(os.WriteFile(path, []byte(`Artifacts:
+  Containerd: distro
+  CniPlugins: true
+  Kubelet: true
+  Kubectl: false
+`), 0o644)).To(Succeed())
+	modTime := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g.Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+
+	tracker, err := readTracker(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tracker.Version).To(Equal(SchemaVersion))
+	g.Expect(tracker.Artifacts).To(Equal(&InstalledArtifacts{
+		Containerd: ContainerdSourceDistro,
+		CniPlugins: true,
+		Kubelet:    true,
+	}))
+	g.Expect(tracker.Components).To(Equal(map[string]*Component{
+		artifact.Containerd: {InstalledAt: modTime},
+		artifact.CniPlugins: {InstalledAt: modTime},
+		artifact.Kubelet:    {InstalledAt: modTime},
+	}))
+}
+
+func TestReadTrackerV2(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "tracker")
+	g.Expect(os.WriteFile(path, []byte(`Version: v2
+Artifacts:
+  Kubectl: true
+Components:
+  kubectl:
+    Version: 1.31.0
+    Path: /usr/bin/kubectl
+    InstalledAt: "2025-01-01T00:00:00Z"
+`), 0o644)).To(Succeed())
+
+	tracker, err := readTracker(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tracker.Artifacts.Containerd).To(Equal(ContainerdSourceNone))
+	g.Expect(tracker.Components).To(Equal(map[string]*Component{
+		artifact.Kubectl: {
+			Version:     "1.31.0",
+			Path:        "/usr/bin/kubectl",
+			InstalledAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}))
+}
+
+func TestReadTrackerUnsupportedVersion(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "tracker")
+	g.Expect(os.WriteFile(path, []byte("Version: v3\nArtifacts: {}\n"), 0o644)).To(Succeed())
+
+	_, err := readTracker(path)
+	g.Expect(err).To(MatchError(ContainSubstring("unsupported tracker version v3")))
+}
@@ -0,0 +1,327 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// maxResumes is how many times a download is resumed after the connection drops.
+	maxResumes = 5
+
+	copyBufferSize = 32 << 10
+
+	validatorSuffix = ".validator"
+)
+
+// DownloadOptions configure the http downloads made with a context.
+type DownloadOptions struct {
+	// MaxRate caps the bytes per second of all the downloads together. Zero doesn't
+	// limit them.
+	MaxRate int64
+
+	// PartialDir keeps the partial downloads of files, so they are resumed by later
+	// runs. When empty, downloads are only resumed within the same run.
+	PartialDir string
+
+	// RetryBackoff is the wait between the attempts of a failed request. Zero uses
+	// the default of the client.
+	RetryBackoff time.Duration
+}
+
+type downloadOptionsKey struct{}
+
+type downloadOptions struct {
+	limiter      *rate.Limiter
+	partialDir   string
+	retryBackoff time.Duration
+}
+
+// WithDownloadOptions returns a context that applies opts to the http downloads made
+// with it. The rate limit is shared by the downloads made in parallel.
+func WithDownloadOptions(ctx context.Context, opts DownloadOptions) context.Context {
+	o := downloadOptions{partialDir: opts.PartialDir, retryBackoff: opts.RetryBackoff}
+	if opts.MaxRate > 0 {
+		o.limiter = rate.NewLimiter(rate.Limit(opts.MaxRate), copyBufferSize)
+	}
+	return context.WithValue(ctx, downloadOptionsKey{}, o)
+}
+
+func downloadOptionsFromContext(ctx context.Context) downloadOptions {
+	o, _ := ctx.Value(downloadOptionsKey{}).(downloadOptions)
+	return o
+}
+
+// downloadTarget holds the content downloaded so far.
+type downloadTarget interface {
+	io.Writer
+	// Downloaded returns the size of the content downloaded so far and the ETag or
+	// Last-Modified of the response it came from.
+	Downloaded() (int64, string)
+	// Restart discards the downloaded content, to download it again from a response
+	// with validator.
+	Restart(validator string) error
+}
+
+// download downloads uri to dst, resuming from the content already in dst with range
+// requests. Resumed content must have the same ETag or Last-Modified, or it's
+// downloaded from the start.
+func (hc *retryHttpClient) download(ctx context.Context, uri string, dst downloadTarget) error {
+	var err error
+	for range maxResumes + 1 {
+		var resumable bool
+		resumable, err = hc.downloadRemaining(ctx, uri, dst)
+		if err == nil || !resumable || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// downloadRemaining downloads the content of uri missing from dst, returning whether
+// the download can be resumed after an error.
+func (hc *retryHttpClient) downloadRemaining(ctx context.Context, uri string, dst downloadTarget) (bool, error) {
+	offset, validator := dst.Downloaded()
+	if offset > 0 && validator == "" {
+		// without a validator there's no telling the content didn't change
+		if err := dst.Restart(""); err != nil {
+			return false, err
+		}
+		offset = 0
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed creating request from url: %s: %w", uri, err)
+	}
+	request.Header.Add(userAgentHeader, userAgent)
+	if offset > 0 {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		request.Header.Set("If-Range", validator)
+	}
+	resp, err := hc.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("failed reading file from url: %s: %w", uri, err)
+	}
+	defer resp.Body.Close()
+
+	total := resp.ContentLength
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if start, ok := contentRangeStart(resp.Header.Get("Content-Range")); !ok || start != offset {
+			return true, errors.Join(
+				fmt.Errorf("unexpected content range %q resuming download from url: %s", resp.Header.Get("Content-Range"), uri),
+				dst.Restart(""),
+			)
+		}
+		if total >= 0 {
+			total += offset
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// the partial content is longer than the file
+		return true, errors.Join(
+			fmt.Errorf("partial download is larger than the file at url: %s", uri),
+			dst.Restart(""),
+		)
+	default:
+		// the server ignored the range or the content changed since the partial download
+		if err := dst.Restart(responseValidator(resp)); err != nil {
+			return false, err
+		}
+		offset = 0
+	}
+
+	if err := copyBody(ctx, dst, resp.Body, offset, total); err != nil {
+		return true, fmt.Errorf("failed reading file from url: %s: %w", uri, err)
+	}
+	return false, nil
+}
+
+// copyBody copies body to dst, limiting the rate and reporting the progress with the
+// options in ctx. read is what was downloaded before body.
+func copyBody(ctx context.Context, dst io.Writer, body io.Reader, read, total int64) error {
+	limiter := downloadOptionsFromContext(ctx).limiter
+	report := progressFromContext(ctx)
+	buf := make([]byte, copyBufferSize)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if limiter != nil {
+				if err := limiter.WaitN(ctx, n); err != nil {
+					return err
+				}
+			}
+			if _, err := dst.Write(buf[:n]); err != nil {
+				return err
+			}
+			read += int64(n)
+			if report != nil {
+				report(read, total)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// responseValidator returns the value for the If-Range header of a request resuming
+// the response. Weak ETags can't be used for ranges, so Last-Modified is used instead.
+func responseValidator(resp *http.Response) string {
+	if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return resp.Header.Get("Last-Modified")
+}
+
+// contentRangeStart parses the first byte of a Content-Range, formatted as
+// bytes start-end/size.
+func contentRangeStart(contentRange string) (int64, bool) {
+	byteRange, ok := strings.CutPrefix(contentRange, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	start, _, ok := strings.Cut(byteRange, "-")
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.ParseInt(start, 10, 64)
+	return value, err == nil
+}
+
+// memoryTarget keeps a download in memory.
+type memoryTarget struct {
+	bytes.Buffer
+	validator string
+}
+
+func (t *memoryTarget) Downloaded() (int64, string) {
+	return int64(t.Len()), t.validator
+}
+
+func (t *memoryTarget) Restart(validator string) error {
+	t.Reset()
+	t.validator = validator
+	return nil
+}
+
+// partialFile keeps a download in a file, with its validator in a file next to it.
+type partialFile struct {
+	file *os.File
+	// keep leaves the file in place when the download fails, to resume it later.
+	keep      bool
+	size      int64
+	validator string
+}
+
+// openPartialFile opens the partial download of uri in dir, or a temporary file
+// when dir is empty.
+func openPartialFile(dir, uri string) (*partialFile, error) {
+	if dir == "" {
+		file, err := os.CreateTemp("", "nodeadm-download")
+		if err != nil {
+			return nil, err
+		}
+		return &partialFile{file: file}, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	name := sha256.Sum256([]byte(uri))
+	path := filepath.Join(dir, hex.EncodeToString(name[:]))
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	// a missing validator restarts the download
+	validator, _ := os.ReadFile(path + validatorSuffix)
+	return &partialFile{
+		file:      file,
+		keep:      true,
+		size:      size,
+		validator: string(validator),
+	}, nil
+}
+
+func (f *partialFile) Write(p []byte) (int, error) {
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *partialFile) Downloaded() (int64, string) {
+	return f.size, f.validator
+}
+
+func (f *partialFile) Restart(validator string) error {
+	if err := f.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	f.size = 0
+	f.validator = validator
+	if !f.keep {
+		return nil
+	}
+	return os.WriteFile(f.file.Name()+validatorSuffix, []byte(validator), 0o600)
+}
+
+// complete returns a reader of the downloaded file, which is removed when it's closed.
+func (f *partialFile) complete() (io.ReadCloser, error) {
+	if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+		f.remove()
+		return nil, err
+	}
+	return &removeOnClose{partialFile: f}, nil
+}
+
+// abandon closes the file after a failed download, removing it unless it's kept
+// to resume later.
+func (f *partialFile) abandon() {
+	if f.keep {
+		f.file.Close()
+		return
+	}
+	f.remove()
+}
+
+func (f *partialFile) remove() error {
+	err := f.file.Close()
+	os.Remove(f.file.Name() + validatorSuffix)
+	return errors.Join(err, os.Remove(f.file.Name()))
+}
+
+type removeOnClose struct {
+	*partialFile
+}
+
+func (r *removeOnClose) Read(p []byte) (int, error) {
+	return r.file.Read(p)
+}
+
+func (r *removeOnClose) Close() error {
+	return r.remove()
+}
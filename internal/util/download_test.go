@@ -0,0 +1,196 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// flakyServer serves content with http.ServeContent, cutting the first drops responses
+// after dropAfter bytes of their body.
+type flakyServer struct {
+	mu       sync.Mutex
+	content  []byte
+	etag     string
+	modified time.Time
+	drops    int
+	ranges   []string
+}
+
+func (s *flakyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.ranges = append(s.ranges, r.Header.Get("Range"))
+	drop := s.drops > 0
+	if drop {
+		s.drops--
+	}
+	content, etag := s.content, s.etag
+	s.mu.Unlock()
+
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	if drop {
+		w = &droppingWriter{ResponseWriter: w, remaining: dropAfter}
+	}
+	http.ServeContent(w, r, "", s.modified, bytes.NewReader(content))
+}
+
+func (s *flakyServer) requestedRanges() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ranges
+}
+
+// droppingWriter drops the connection after writing remaining bytes of the body.
+type droppingWriter struct {
+	http.ResponseWriter
+	remaining int
+}
+
+func (w *droppingWriter) Write(p []byte) (int, error) {
+	if len(p) <= w.remaining {
+		w.remaining -= len(p)
+		return w.ResponseWriter.Write(p)
+	}
+	_, _ = w.ResponseWriter.Write(p[:w.remaining])
+	w.ResponseWriter.(http.Flusher).Flush()
+	panic(http.ErrAbortHandler)
+}
+
+const dropAfter = 10000
+
+func testContent() []byte {
+	return []byte(strings.Repeat("0123456789", 10000))
+}
+
+func TestGetHttpFileReaderResumes(t *testing.T) {
+	g := NewWithT(t)
+	content := testContent()
+	flaky := &flakyServer{content: content, etag: `"v1"`, drops: 2}
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	reader, err := GetHttpFileReader(context.Background(), server.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	data, err := io.ReadAll(reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reader.Close()).To(Succeed())
+	g.Expect(data).To(Equal(content))
+	g.Expect(flaky.requestedRanges()).To(Equal([]string{"", "bytes=10000-", "bytes=20000-"}))
+}
+
+func TestGetHttpFileResumes(t *testing.T) {
+	g := NewWithT(t)
+	content := testContent()
+	flaky := &flakyServer{content: content, modified: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), drops: 1}
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	data, err := GetHttpFile(context.Background(), server.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(data).To(Equal(content))
+	g.Expect(flaky.requestedRanges()).To(Equal([]string{"", "bytes=10000-"}))
+}
+
+func TestGetHttpFileRestartsWithoutValidator(t *testing.T) {
+	g := NewWithT(t)
+	content := testContent()
+	flaky := &flakyServer{content: content, drops: 1}
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	data, err := GetHttpFile(context.Background(), server.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(data).To(Equal(content))
+	g.Expect(flaky.requestedRanges()).To(Equal([]string{"", ""}))
+}
+
+func TestGetHttpFileReaderResumesAcrossRuns(t *testing.T) {
+	g := NewWithT(t)
+	partialDir := t.TempDir()
+	ctx := WithDownloadOptions(context.Background(), DownloadOptions{PartialDir: partialDir})
+	content := testContent()
+	flaky := &flakyServer{content: content, etag: `"v1"`, drops: maxResumes + 1}
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	_, err := GetHttpFileReader(ctx, server.URL)
+	g.Expect(err).To(HaveOccurred())
+	files, err := os.ReadDir(partialDir)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(files).To(HaveLen(2), "partial file and its validator are kept")
+
+	reader, err := GetHttpFileReader(ctx, server.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	data, err := io.ReadAll(reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reader.Close()).To(Succeed())
+	g.Expect(data).To(Equal(content))
+	ranges := flaky.requestedRanges()
+	g.Expect(ranges[len(ranges)-1]).NotTo(BeEmpty(), "last run resumed the partial download")
+
+	files, err = os.ReadDir(partialDir)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(files).To(BeEmpty())
+}
+
+func TestGetHttpFileReaderRestartsChangedContent(t *testing.T) {
+	g := NewWithT(t)
+	partialDir := t.TempDir()
+	ctx := WithDownloadOptions(context.Background(), DownloadOptions{PartialDir: partialDir})
+	flaky := &flakyServer{content: testContent(), etag: `"v1"`, drops: maxResumes + 1}
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	_, err := GetHttpFileReader(ctx, server.URL)
+	g.Expect(err).To(HaveOccurred())
+
+	changed := []byte(strings.Repeat("abcdefghij", 10000))
+	flaky.mu.Lock()
+	flaky.content, flaky.etag = changed, `"v2"`
+	flaky.mu.Unlock()
+
+	reader, err := GetHttpFileReader(ctx, server.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(data).To(Equal(changed))
+}
+
+func TestGetHttpFileMaxRate(t *testing.T) {
+	g := NewWithT(t)
+	content := testContent()
+	server := httptest.NewServer(&flakyServer{content: content})
+	defer server.Close()
+
+	// the first copyBufferSize bytes are the burst
+	ctx := WithDownloadOptions(context.Background(), DownloadOptions{MaxRate: int64(len(content)-copyBufferSize) * 4})
+	start := time.Now()
+	data, err := GetHttpFile(ctx, server.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(data).To(Equal(content))
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 200*time.Millisecond))
+}
+
+func TestContentRangeStart(t *testing.T) {
+	g := NewWithT(t)
+	start, ok := contentRangeStart("bytes 100-199/200")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(start).To(BeEquivalentTo(100))
+
+	_, ok = contentRangeStart("bytes */200")
+	g.Expect(ok).To(BeFalse())
+	_, ok = contentRangeStart("items 1-2/3")
+	g.Expect(ok).To(BeFalse())
+}
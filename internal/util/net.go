@@ -17,62 +17,94 @@ const userAgentHeader = "User-Agent"
 
 var userAgent = fmt.Sprintf("nodeadm/%s (%s/%s)", version.GitVersion, runtime.GOOS, runtime.GOARCH)
 
-func GetHttpFile(ctx context.Context, uri string) ([]byte, error) {
-	reader, err := GetHttpFileReader(ctx, uri)
-	if err != nil {
-		return nil, err
-	}
-	defer reader.Close()
+// HttpOption configures the client used to fetch a file over http.
+type HttpOption func(*retryHttpClient)
 
-	data, err := io.ReadAll(reader)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed reading file from url: %s", uri)
+// WithHttpClient sets the underlying http client used for requests. This allows
+// callers to customize transport settings, like a custom CA bundle.
+func WithHttpClient(client *http.Client) HttpOption {
+	return func(hc *retryHttpClient) {
+		hc.client = client
 	}
+}
 
-	return data, nil
+// GetHttpFile downloads the file at uri into memory. Downloads interrupted by a dropped
+// connection are resumed, with the options in ctx.
+func GetHttpFile(ctx context.Context, uri string, opts ...HttpOption) ([]byte, error) {
+	target := &memoryTarget{}
+	if err := newHttpClient(opts).download(ctx, uri, target); err != nil {
+		return nil, err
+	}
+	return target.Bytes(), nil
 }
 
-func GetHttpFileReader(ctx context.Context, uri string) (io.ReadCloser, error) {
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+// GetHttpFileReader downloads the file at uri and returns a reader of its content.
+// The file is downloaded to a partial file first, which is resumed when the connection
+// drops, and across runs when ctx has a partial downloads directory.
+func GetHttpFileReader(ctx context.Context, uri string, opts ...HttpOption) (io.ReadCloser, error) {
+	target, err := openPartialFile(downloadOptionsFromContext(ctx).partialDir, uri)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed creating request from url: %s", uri)
+		return nil, errors.Wrap(err, "opening partial download")
 	}
-	request.Header.Add(userAgentHeader, userAgent)
+	if err := newHttpClient(opts).download(ctx, uri, target); err != nil {
+		target.abandon()
+		return nil, err
+	}
+	return target.complete()
+}
 
+func newHttpClient(opts []HttpOption) *retryHttpClient {
 	httpRetryClient := newRetryableHttpClient(2*time.Second, 3)
-	resp, err := httpRetryClient.Do(request)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed reading file from url: %s", uri)
+	for _, opt := range opts {
+		opt(httpRetryClient)
 	}
-	return resp.Body, nil
+	return httpRetryClient
 }
 
 type retryHttpClient struct {
+	client     *http.Client
 	backoff    time.Duration
 	maxRetries int
 }
 
 func newRetryableHttpClient(backoff time.Duration, maxRetries int) *retryHttpClient {
 	return &retryHttpClient{
+		client:     http.DefaultClient,
 		backoff:    backoff,
 		maxRetries: maxRetries,
 	}
 }
 
+// Do sends req up to maxRetries times, waiting backoff between the attempts, until the
+// response has a status the download can use. The download options of the request
+// context can change the backoff.
 func (hc *retryHttpClient) Do(req *http.Request) (*http.Response, error) {
 	var resp *http.Response
 	var err error
 
-	for range hc.maxRetries {
-		resp, err = http.DefaultClient.Do(req)
+	backoff := hc.backoff
+	if o := downloadOptionsFromContext(req.Context()); o.retryBackoff > 0 {
+		backoff = o.retryBackoff
+	}
+	for attempt := range hc.maxRetries {
+		if attempt > 0 {
+			select {
+			case <-req.Context().Done():
+				return nil, fmt.Errorf("http request canceled while retrying: %s : %w, last error: %v", req.Host, req.Context().Err(), err)
+			case <-time.After(backoff):
+			}
+		}
+		resp, err = hc.client.Do(req)
 		if err != nil {
 			continue
 		}
-		if resp.StatusCode != http.StatusOK {
-			err = fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-			continue
+		switch resp.StatusCode {
+		// a range that's not satisfiable won't be the next time either
+		case http.StatusOK, http.StatusPartialContent, http.StatusRequestedRangeNotSatisfiable:
+			return resp, nil
 		}
-		return resp, nil
+		resp.Body.Close()
+		err = fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 	return nil, fmt.Errorf("max retries achieved for http request: %s : %w", req.Host, err)
 }
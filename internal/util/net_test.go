@@ -0,0 +1,55 @@
+package util
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRetryHttpClientWaitsBetweenAttempts(t *testing.T) {
+	g := NewWithT(t)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	start := time.Now()
+	resp, err := newRetryableHttpClient(50*time.Millisecond, 3).Do(request)
+	g.Expect(err).NotTo(HaveOccurred())
+	resp.Body.Close()
+	g.Expect(requests.Load()).To(BeEquivalentTo(3))
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 100*time.Millisecond))
+}
+
+func TestRetryHttpClientStopsWaitingWhenCanceled(t *testing.T) {
+	g := NewWithT(t)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	start := time.Now()
+	_, err = newRetryableHttpClient(time.Hour, 3).Do(request)
+	g.Expect(err).To(MatchError(context.DeadlineExceeded))
+	g.Expect(err).To(MatchError(ContainSubstring("unexpected status code: 503")))
+	g.Expect(requests.Load()).To(BeEquivalentTo(1))
+	g.Expect(time.Since(start)).To(BeNumerically("<", time.Minute))
+}
@@ -0,0 +1,20 @@
+package util
+
+import "context"
+
+// ProgressFunc is called with the bytes read so far from a download and its total
+// size, which is -1 when the server didn't send it.
+type ProgressFunc func(read, total int64)
+
+type progressKey struct{}
+
+// WithProgress returns a context that reports the progress of the http downloads made
+// with it to fn. A nil fn stops reporting the downloads made with the returned context.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+func progressFromContext(ctx context.Context) ProgressFunc {
+	fn, _ := ctx.Value(progressKey{}).(ProgressFunc)
+	return fn
+}
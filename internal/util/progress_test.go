@@ -0,0 +1,39 @@
+package util
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGetHttpFileReaderReportsProgress(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("0123456789"))
+	}))
+	defer server.Close()
+
+	var read, total int64
+	ctx := WithProgress(context.Background(), func(r, t int64) {
+		read, total = r, t
+	})
+	reader, err := GetHttpFileReader(ctx, server.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer reader.Close()
+	_, err = io.ReadAll(reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(read).To(BeEquivalentTo(10))
+	g.Expect(total).To(BeEquivalentTo(10))
+
+	read = 0
+	reader, err = GetHttpFileReader(WithProgress(ctx, nil), server.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer reader.Close()
+	_, err = io.ReadAll(reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(read).To(BeZero())
+}
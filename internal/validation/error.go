@@ -36,17 +36,17 @@ func WithRemediation(err error, remediation string) error {
 	}
 }
 
-// IsRemediable checks if an error has a remediation.
+// IsRemediable checks if an error, or an error it wraps like a [FieldError], has a remediation.
 func IsRemediable(err error) bool {
-	_, ok := err.(Remediable)
-	return ok
+	var fixable Remediable
+	return errors.As(err, &fixable)
 }
 
-// Remediation returns the Remediation message for an error if it has it.
-// Otherwise it returns an empty string.
+// Remediation returns the Remediation message for an error, or an error it wraps,
+// if it has it. Otherwise it returns an empty string.
 func Remediation(err error) string {
-	fixable, ok := err.(Remediable)
-	if !ok {
+	var fixable Remediable
+	if !errors.As(err, &fixable) {
 		return ""
 	}
 
@@ -96,3 +96,41 @@ func IsWarning(err error) bool {
 	_, ok := err.(Warning)
 	return ok
 }
+
+// FieldError is an error about a single field of the node configuration.
+type FieldError struct {
+	error
+	// Field is the dot separated JSON path of the field, e.g. spec.hybrid.ssm.activationId.
+	Field string
+}
+
+// NewFieldError returns a new [FieldError] for the field at path.
+func NewFieldError(path, err string) error {
+	return &FieldError{
+		error: errors.New(err),
+		Field: path,
+	}
+}
+
+// WithField makes err a [FieldError] for the field at path.
+func WithField(path string, err error) error {
+	return &FieldError{
+		error: err,
+		Field: path,
+	}
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.error
+}
+
+// Field returns the field path of an error if it has one.
+// Otherwise it returns an empty string.
+func Field(err error) string {
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		return ""
+	}
+	return fieldErr.Field
+}
@@ -2,6 +2,7 @@ package validation_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -29,6 +30,16 @@ func TestIsRemediable(t *testing.T) {
 			err:  validation.NewRemediableErr("one error", "just fix it"),
 			want: true,
 		},
+		{
+			name: "remediable field error",
+			err:  validation.WithField("spec.hybrid.iamRolesAnywhere.certificatePath", validation.NewRemediableErr("one error", "just fix it")),
+			want: true,
+		},
+		{
+			name: "non remediable field error",
+			err:  validation.NewFieldError("spec.cluster.name", "non fixable"),
+			want: false,
+		},
 		{
 			name: "non remediable",
 			err:  errors.New("non fixable"),
@@ -54,6 +65,16 @@ func TestRemediation(t *testing.T) {
 			err:  validation.NewRemediableErr("one error", "just fix it"),
 			want: "just fix it",
 		},
+		{
+			name: "remediable field error",
+			err:  validation.WithField("spec.hybrid.iamRolesAnywhere.certificatePath", validation.NewRemediableErr("one error", "just fix it")),
+			want: "just fix it",
+		},
+		{
+			name: "non remediable field error",
+			err:  validation.NewFieldError("spec.cluster.name", "non fixable"),
+			want: "",
+		},
 		{
 			name: "non remediable",
 			err:  errors.New("non fixable"),
@@ -67,3 +88,15 @@ func TestRemediation(t *testing.T) {
 		})
 	}
 }
+
+func TestField(t *testing.T) {
+	g := NewWithT(t)
+	remediable := validation.NewRemediableErr("certificate expired", "renew it")
+	err := fmt.Errorf("validating: %w", validation.WithField("spec.hybrid.iamRolesAnywhere.certificatePath", remediable))
+
+	g.Expect(validation.Field(err)).To(Equal("spec.hybrid.iamRolesAnywhere.certificatePath"))
+	g.Expect(err).To(MatchError("validating: certificate expired"))
+	g.Expect(errors.Is(err, remediable)).To(BeTrue())
+	g.Expect(validation.Field(validation.NewFieldError("spec.cluster.name", "Name is missing"))).To(Equal("spec.cluster.name"))
+	g.Expect(validation.Field(errors.New("no field"))).To(BeEmpty())
+}
@@ -15,3 +15,4 @@ nodeadm init --skip run,install-validation,k8s-authentication-validation --confi
 
 assert::files-equal /etc/containerd/config.toml expected-containerd-config.toml
 assert::files-equal /etc/containerd/config.d/00-nodeadm.toml expected-user-containerd-config.toml
+assert::file-permission-matches /etc/containerd/config.d/00-nodeadm.toml 600